@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rdark/za/internal/links"
+	"github.com/rdark/za/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+var backlinksNoteType string
+
+var backlinksCmd = &cobra.Command{
+	Use:   "backlinks <date>",
+	Short: "List the notes that link to a date's note",
+	Long: `List every link, in journal and standup notes, that points to the note of
+one type for a date. Use it to find references before deleting or renaming
+a note.
+
+This command is read-only. Date format: YYYY-MM-DD
+
+Examples:
+  za backlinks 2025-01-15                  # Links to the 2025-01-15 journal
+  za backlinks 2025-01-15 --type standup   # Links to the 2025-01-15 standup`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBacklinks,
+}
+
+func init() {
+	rootCmd.AddCommand(backlinksCmd)
+	backlinksCmd.Flags().StringVar(&backlinksNoteType, "type", string(notes.NoteTypeJournal), "Type of the linked note (journal or standup)")
+}
+
+func runBacklinks(cmd *cobra.Command, args []string) error {
+	noteType := notes.NoteType(backlinksNoteType)
+	if !noteType.IsValid() {
+		return fmt.Errorf("invalid note type: %q (expected journal or standup)", backlinksNoteType)
+	}
+
+	date, err := parseDateArg(args)
+	if err != nil {
+		return err
+	}
+
+	dirs, err := cfg.JournalDirs()
+	if err != nil {
+		return fmt.Errorf("failed to get journal directories: %w", err)
+	}
+	standupDir, err := cfg.StandupDir()
+	if err != nil {
+		return fmt.Errorf("failed to get standup directory: %w", err)
+	}
+	dirs = append(dirs, standupDir)
+
+	backlinks, err := links.FindBacklinks(date, noteType, dirs...)
+	if err != nil {
+		return fmt.Errorf("failed to find backlinks: %w", err)
+	}
+
+	if len(backlinks) == 0 {
+		fmt.Printf("No links to the %s for %s\n", noteType, date.Format(notes.DateFormat))
+		return nil
+	}
+	fmt.Print(formatBacklinks(backlinks))
+	return nil
+}
+
+// formatBacklinks renders backlinks as "path:line: link" lines
+func formatBacklinks(backlinks []links.Backlink) string {
+	var sb strings.Builder
+	for _, backlink := range backlinks {
+		fmt.Fprintf(&sb, "%s:%d: %s\n", backlink.Path, backlink.Link.Line, backlink.Link.Format(backlink.Link.Destination))
+	}
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestBacklinks(t *testing.T) {
+	root := t.TempDir()
+	journalDir := filepath.Join(root, "journal")
+	standupDir := filepath.Join(root, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	files := map[string]string{
+		"journal/2025-01-08.md": "# Log\n\n[Yesterday](2025-01-07)\n",
+		"standup/2025-01-07.md": "# Standup\n\n[Journal](../journal/2025-01-07)\n",
+		"standup/2025-01-08.md": "# Standup\n\n[Yesterday](2025-01-07)\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runBacklinks(nil, []string{"2025-01-07"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := filepath.Join(journalDir, "2025-01-08.md") + ":3: [Yesterday](2025-01-07)\n" +
+		filepath.Join(standupDir, "2025-01-07.md") + ":3: [Journal](../journal/2025-01-07)\n"
+	if string(outputBytes) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, outputBytes)
+	}
+}
+
+func TestBacklinks_InvalidType(t *testing.T) {
+	backlinksNoteType = "diary"
+	defer func() { backlinksNoteType = "journal" }()
+
+	if err := runBacklinks(nil, []string{"2025-01-07"}); err == nil {
+		t.Error("expected error for invalid note type")
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rdark/za/internal/links"
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+var checkLinksRecursive bool
+
+var checkLinksCmd = &cobra.Command{
+	Use:   "check-links <file|dir>",
+	Short: "Report stale date links and missing attachments without modifying notes",
+	Long: `Check the temporal and cross-reference links in a note, or every dated note
+in a directory, and report any that are stale. Attachment links and images,
+e.g. ![](diagram.png), are checked for a missing file. Files are never modified.
+
+Each stale link is reported as one of:
+- points to missing note: the linked note doesn't exist
+- points to wrong note: the linked note exists, but isn't the right one
+- cannot be resolved: no suitable note was found to link to
+- points to missing attachment: the linked file doesn't exist
+
+The command exits non-zero if any link is stale or broken, which makes it suitable for
+pre-commit hooks and CI. Use fix-links to repair the links.
+
+Examples:
+  za check-links journal/2025-01-15.md
+  za check-links journal/ --recursive`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCheckLinks,
+}
+
+func init() {
+	rootCmd.AddCommand(checkLinksCmd)
+	checkLinksCmd.Flags().BoolVarP(&checkLinksRecursive, "recursive", "r", false, "When given a directory, also check notes in subdirectories")
+}
+
+func runCheckLinks(cmd *cobra.Command, args []string) error {
+	target := args[0]
+
+	info, err := os.Stat(target)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("file does not exist: %s", target)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", target, err)
+	}
+
+	files := []string{target}
+	if info.IsDir() {
+		all, err := markdownFiles(target, checkLinksRecursive)
+		if err != nil {
+			return err
+		}
+		files = files[:0]
+		for _, filePath := range all {
+			if isDatedNote(filePath) {
+				files = append(files, filePath)
+			}
+		}
+	}
+
+	var stale, staleNotes int
+	for _, filePath := range files {
+		problems, err := checkLinksInNote(filePath)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filePath, err)
+		}
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+		if len(problems) > 0 {
+			stale += len(problems)
+			staleNotes++
+		}
+	}
+
+	if stale > 0 {
+		return fmt.Errorf("found %d stale or broken link(s) in %d of %d note(s)", stale, staleNotes, len(files))
+	}
+
+	fmt.Printf("✓ All links are correct in %d note(s)\n", len(files))
+	return nil
+}
+
+// checkLinksInNote returns a description of each stale link and missing
+// attachment in a note
+func checkLinksInNote(filePath string) ([]string, error) {
+	noteType, err := determineNoteType(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine note type: %w", err)
+	}
+
+	fileDate, err := notes.ParseDateFromFilename(filePath, finderOptions(noteType)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse date from filename: %w", err)
+	}
+
+	parser := markdown.NewParser()
+	doc, err := parser.ParseFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	stale, err := classifyAndResolveLinks(filePath, doc.ExtractLinks(), fileDate, noteType)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := make([]string, 0, len(stale))
+	for _, r := range stale {
+		link := r.Classified.Link
+		prefix := fmt.Sprintf("%s:%d: %s", filePath, link.Line, link.Format(link.Destination))
+
+		switch {
+		case r.Error != nil:
+			problems = append(problems, fmt.Sprintf("%s cannot be resolved: %v", prefix, r.Error))
+		case linkTargetExists(filePath, link):
+			problems = append(problems, fmt.Sprintf("%s points to wrong note, should be %s", prefix, r.SuggestedDestination))
+		default:
+			problems = append(problems, fmt.Sprintf("%s points to missing note, should be %s", prefix, r.SuggestedDestination))
+		}
+	}
+
+	attachments, err := checkAttachmentsInNote(filePath, doc.ExtractAllLinks())
+	if err != nil {
+		return nil, err
+	}
+
+	return append(problems, attachments...), nil
+}
+
+// checkAttachmentsInNote returns a description of each attachment link,
+// including images, that points to a missing file. Wiki links are resolved by
+// name elsewhere in the vault, so they aren't checked.
+func checkAttachmentsInNote(filePath string, allLinks []markdown.Link) ([]string, error) {
+	classifier := links.NewClassifier(cfg)
+
+	var problems []string
+	for _, link := range allLinks {
+		if link.Wiki || classifier.Classify(link).Type != links.LinkTypeAttachment {
+			continue
+		}
+		err := links.ValidateAttachment(filePath, link)
+		if errors.Is(err, links.ErrAttachmentNotFound) {
+			problems = append(problems, fmt.Sprintf("%s:%d: %s points to missing attachment",
+				filePath, link.Line, link.Format(link.Destination)))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return problems, nil
+}
+
+// linkTargetExists reports whether the note a link currently points to
+// exists, resolving the destination relative to the linking note. Wiki links
+// are resolved by name within the linking note's directory.
+func linkTargetExists(filePath string, link markdown.Link) bool {
+	target := link.Path()
+	if !strings.HasSuffix(target, ".md") {
+		target += ".md"
+	}
+
+	path := filepath.Join(filepath.Dir(filePath), filepath.FromSlash(target))
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
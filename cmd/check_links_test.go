@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestCheckLinks(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	// Journals on 01-06, 01-07 and 01-09 (01-08 missing)
+	files := map[string]string{
+		"2025-01-06.md": "# Daily Log\n",
+		"2025-01-07.md": "# Daily Log\n\n* [Yesterday](2025-01-06)\n",
+		"2025-01-09.md": "# Daily Log\n\n* [Yesterday](2025-01-08)\n* [Previous](2025-01-06)\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	stalePath := filepath.Join(journalDir, "2025-01-09.md")
+	problems, err := checkLinksInNote(stalePath)
+	if err != nil {
+		t.Fatalf("checkLinksInNote() error = %v", err)
+	}
+
+	want := []string{
+		stalePath + ":3: [Yesterday](2025-01-08) points to missing note, should be 2025-01-07",
+		stalePath + ":4: [Previous](2025-01-06) points to wrong note, should be 2025-01-07",
+	}
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("checkLinksInNote() =\n%s\nwant:\n%s", strings.Join(problems, "\n"), strings.Join(want, "\n"))
+	}
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stdout = oldStdout }()
+
+	if err := runCheckLinks(nil, []string{filepath.Join(journalDir, "2025-01-07.md")}); err != nil {
+		t.Errorf("runCheckLinks() on a correct note error = %v", err)
+	}
+	if err := runCheckLinks(nil, []string{journalDir}); err == nil {
+		t.Error("runCheckLinks() on a directory with stale links should fail")
+	}
+
+	// Nothing is ever written
+	for name, content := range files {
+		got, err := os.ReadFile(filepath.Join(journalDir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if string(got) != content {
+			t.Errorf("%s was modified:\n%s", name, got)
+		}
+	}
+}
+
+func TestCheckLinks_Attachments(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(filepath.Join(journalDir, "assets"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "assets", "my diagram.png"), []byte("png"), 0644); err != nil {
+		t.Fatalf("failed to write attachment: %v", err)
+	}
+
+	notePath := filepath.Join(journalDir, "2025-01-06.md")
+	content := `# Daily Log
+
+* ![Diagram](assets/my%20diagram.png)
+* ![Chart](assets/chart.png)
+* [Report](report.pdf)
+* [[missing.png]]
+* [Site](https://example.com/logo.png)
+`
+	if err := os.WriteFile(notePath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write note: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	problems, err := checkLinksInNote(notePath)
+	if err != nil {
+		t.Fatalf("checkLinksInNote() error = %v", err)
+	}
+
+	want := []string{
+		notePath + ":4: ![Chart](assets/chart.png) points to missing attachment",
+		notePath + ":5: [Report](report.pdf) points to missing attachment",
+	}
+	if strings.Join(problems, "\n") != strings.Join(want, "\n") {
+		t.Errorf("checkLinksInNote() =\n%s\nwant:\n%s", strings.Join(problems, "\n"), strings.Join(want, "\n"))
+	}
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stdout = oldStdout }()
+
+	if err := runCheckLinks(nil, []string{notePath}); err == nil {
+		t.Error("runCheckLinks() on a note with missing attachments should fail")
+	}
+
+	// Once the files exist, the note is clean
+	for _, name := range []string{filepath.Join("assets", "chart.png"), "report.pdf"} {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte("data"), 0644); err != nil {
+			t.Fatalf("failed to write attachment: %v", err)
+		}
+	}
+	if err := runCheckLinks(nil, []string{notePath}); err != nil {
+		t.Errorf("runCheckLinks() with all attachments present error = %v", err)
+	}
+}
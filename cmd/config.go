@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the loaded configuration",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective configuration and where it came from",
+	Long: `Show the config file za read and every effective setting, with where its
+value came from: the config file, a ZA_* environment variable, or the
+built-in default.
+
+Use this when a setting doesn't seem to take effect.
+
+Examples:
+  za config show
+  za config show --config ~/notes/.za.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShow,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	return writeConfigSource(os.Stdout, cfg)
+}
+
+// writeConfigSource prints the config file used, the vault root and each
+// setting as "key  value  source", with values in JSON
+func writeConfigSource(out io.Writer, c *config.Config) error {
+	if c.Source.File != "" {
+		fmt.Fprintf(out, "Config file: %s\n", c.Source.File)
+	} else {
+		fmt.Fprintln(out, "Config file: none found (using defaults and environment)")
+	}
+	if c.VaultRoot != "" {
+		fmt.Fprintf(out, "Vault root:  %s\n", c.VaultRoot)
+	}
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, setting := range c.Source.Settings {
+		value, err := json.Marshal(setting.Value)
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", setting.Key, err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t(%s)\n", setting.Key, value, setting.Source)
+	}
+	return w.Flush()
+}
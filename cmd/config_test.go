@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestWriteConfigSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		source config.Source
+		want   string
+	}{
+		{
+			name: "config file",
+			source: config.Source{
+				File: "/notes/.za.yaml",
+				Settings: []config.Setting{
+					{Key: "journal.dir", Value: "./journal", Source: config.SourceFile},
+					{Key: "journal.skip_text", Value: []string{"lunch"}, Source: config.SourceDefault},
+					{Key: "search_window_days", Value: "12", Source: config.SourceEnv},
+				},
+			},
+			want: "Config file: /notes/.za.yaml\n\n" +
+				"journal.dir         \"./journal\"  (file)\n" +
+				"journal.skip_text   [\"lunch\"]    (default)\n" +
+				"search_window_days  \"12\"         (env)\n",
+		},
+		{
+			name:   "no config file",
+			source: config.Source{},
+			want:   "Config file: none found (using defaults and environment)\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := writeConfigSource(&out, &config.Config{Source: tt.source}); err != nil {
+				t.Fatalf("writeConfigSource() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, out.String())
+			}
+		})
+	}
+}
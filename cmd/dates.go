@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
+)
+
+// now returns the current time; tests replace it to fix "today"
+var now = time.Now
+
+// today returns the current date in the configured time zone. Like dates
+// parsed from arguments and filenames, it is midnight UTC, so the two can be
+// compared directly.
+func today() time.Time {
+	y, m, d := now().In(cfg.Location()).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// parseDateArg parses an optional YYYY-MM-DD date argument, defaulting to today
+func parseDateArg(args []string) (time.Time, error) {
+	if len(args) == 0 {
+		return today(), nil
+	}
+
+	date, err := time.Parse(notes.DateFormat, args[0])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format (expected YYYY-MM-DD): %w", err)
+	}
+	return date, nil
+}
+
+// parseDateRangeArg parses an optional date argument that may name a single
+// day (YYYY-MM-DD) or a whole month (YYYY-MM). It returns the inclusive range
+// of dates covered and whether the argument was a month. With no argument the
+// range is today.
+func parseDateRangeArg(args []string) (start, end time.Time, isMonth bool, err error) {
+	if len(args) > 0 && len(args[0]) == len(notes.MonthFormat) {
+		month, err := time.Parse(notes.MonthFormat, args[0])
+		if err != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("invalid month format (expected YYYY-MM): %w", err)
+		}
+		return month, month.AddDate(0, 1, -1), true, nil
+	}
+
+	date, err := parseDateArg(args)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("%w (or YYYY-MM for a whole month)", err)
+	}
+	return date, date, false, nil
+}
+
+// weekRange returns the first and last day (Monday and Sunday) of the ISO week
+// containing date
+func weekRange(date time.Time) (start, end time.Time) {
+	start, end = date, date
+	for util.IsSameWeek(start.AddDate(0, 0, -1), date) {
+		start = start.AddDate(0, 0, -1)
+	}
+	for util.IsSameWeek(end.AddDate(0, 0, 1), date) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
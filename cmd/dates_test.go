@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/notes"
+)
+
+func TestParseDateArg_Timezone(t *testing.T) {
+	oldNow := now
+	defer func() { now = oldNow }()
+
+	// 14:30 UTC on the 7th is already the 8th in Sydney, still the 7th in
+	// London and 06:30 on the 7th in Los Angeles
+	now = func() time.Time { return time.Date(2025, 1, 7, 14, 30, 0, 0, time.UTC) }
+
+	tests := []struct {
+		timezone string
+		want     string
+	}{
+		{"Australia/Sydney", "2025-01-08"},
+		{"Europe/London", "2025-01-07"},
+		{"America/Los_Angeles", "2025-01-07"},
+		{"UTC", "2025-01-07"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			cfg = config.DefaultConfig()
+			cfg.Timezone = tt.timezone
+
+			date, err := parseDateArg(nil)
+			if err != nil {
+				t.Fatalf("parseDateArg() error = %v", err)
+			}
+			if got := date.Format(notes.DateFormat); got != tt.want {
+				t.Errorf("parseDateArg() = %s, want %s", got, tt.want)
+			}
+
+			// Today compares equal to the same date given as an argument
+			parsed, _ := parseDateArg([]string{tt.want})
+			if !date.Equal(parsed) {
+				t.Errorf("parseDateArg() = %v, want %v", date, parsed)
+			}
+		})
+	}
+}
+
+func TestParseDateRangeArg(t *testing.T) {
+	tests := []struct {
+		name      string
+		arg       string
+		wantStart string
+		wantEnd   string
+		wantMonth bool
+		wantErr   bool
+	}{
+		{
+			name:      "single day",
+			arg:       "2025-01-15",
+			wantStart: "2025-01-15",
+			wantEnd:   "2025-01-15",
+		},
+		{
+			name:      "month with 31 days",
+			arg:       "2025-01",
+			wantStart: "2025-01-01",
+			wantEnd:   "2025-01-31",
+			wantMonth: true,
+		},
+		{
+			name:      "february in a leap year",
+			arg:       "2024-02",
+			wantStart: "2024-02-01",
+			wantEnd:   "2024-02-29",
+			wantMonth: true,
+		},
+		{
+			name:    "invalid month",
+			arg:     "2025-13",
+			wantErr: true,
+		},
+		{
+			name:    "invalid day",
+			arg:     "2025-01-32",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, isMonth, err := parseDateRangeArg([]string{tt.arg})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateRangeArg(%q) error = %v, wantErr %v", tt.arg, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if start.Format(notes.DateFormat) != tt.wantStart || end.Format(notes.DateFormat) != tt.wantEnd {
+				t.Errorf("parseDateRangeArg(%q) = %s..%s, want %s..%s", tt.arg,
+					start.Format(notes.DateFormat), end.Format(notes.DateFormat), tt.wantStart, tt.wantEnd)
+			}
+			if isMonth != tt.wantMonth {
+				t.Errorf("parseDateRangeArg(%q) isMonth = %v, want %v", tt.arg, isMonth, tt.wantMonth)
+			}
+		})
+	}
+}
+
+func TestWeekRange(t *testing.T) {
+	tests := []struct {
+		date      string
+		wantStart string
+		wantEnd   string
+	}{
+		{date: "2025-01-15", wantStart: "2025-01-13", wantEnd: "2025-01-19"}, // Wednesday
+		{date: "2025-01-13", wantStart: "2025-01-13", wantEnd: "2025-01-19"}, // Monday
+		{date: "2025-01-19", wantStart: "2025-01-13", wantEnd: "2025-01-19"}, // Sunday
+		{date: "2024-12-31", wantStart: "2024-12-30", wantEnd: "2025-01-05"}, // Spans the new year
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			date, _ := time.Parse(notes.DateFormat, tt.date)
+			start, end := weekRange(date)
+			if start.Format(notes.DateFormat) != tt.wantStart || end.Format(notes.DateFormat) != tt.wantEnd {
+				t.Errorf("weekRange(%s) = %s..%s, want %s..%s", tt.date,
+					start.Format(notes.DateFormat), end.Format(notes.DateFormat), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rdark/za/internal/links"
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Audit notes for common organizational problems",
+	Long: `Audit the journal and standup directories for common problems.
+
+This command is read-only. For each note it checks:
+- The note type implied by its path matches the directory it lives in
+- The frontmatter "type" field (if present) agrees with its location
+- The filename matches the configured date format and doesn't name the other note type
+- The frontmatter "date" field (if present) matches the date in the filename
+- The frontmatter has a "tags" field
+- Links to other notes point to notes that exist
+- Standups have the configured work done and work today sections
+
+Problems are grouped by check and reported with the file and the specific
+conflict. The command exits non-zero if any problem is found.
+
+Examples:
+  za doctor`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctorNote is a note found while auditing, along with the type of the
+// configured directory it was found in
+type doctorNote struct {
+	Path    string
+	DirType notes.NoteType
+	Doc     *markdown.Document
+}
+
+// doctorProblem describes a single problem found in a note
+type doctorProblem struct {
+	Path    string
+	Message string
+
+	// Category is the category of the check that found the problem
+	Category string
+}
+
+// doctorCheck inspects a single note and reports any problems
+type doctorCheck func(note doctorNote) []doctorProblem
+
+// doctorChecks is the list of checks run against every note, each with the
+// category its problems are reported under
+var doctorChecks = []struct {
+	Category string
+	Check    doctorCheck
+}{
+	{"Note types", checkNoteType},
+	{"Dates", checkDateConsistency},
+	{"Tags", checkTagsField},
+	{"Links", checkNoteLinks},
+	{"Standup sections", checkStandupSections},
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	journalDirs, err := cfg.JournalDirs()
+	if err != nil {
+		return fmt.Errorf("failed to get journal directories: %w", err)
+	}
+	standupDir, err := cfg.StandupDir()
+	if err != nil {
+		return fmt.Errorf("failed to get standup directory: %w", err)
+	}
+
+	type noteDir struct {
+		dir      string
+		noteType notes.NoteType
+	}
+	var dirs []noteDir
+	for _, dir := range journalDirs {
+		dirs = append(dirs, noteDir{dir, notes.NoteTypeJournal})
+	}
+	dirs = append(dirs, noteDir{standupDir, notes.NoteTypeStandup})
+
+	var allNotes []doctorNote
+	for _, d := range dirs {
+		found, err := collectDoctorNotes(d.dir, d.noteType)
+		if err != nil {
+			return err
+		}
+		allNotes = append(allNotes, found...)
+	}
+
+	problems := runDoctorChecks(allNotes)
+
+	fmt.Printf("Checked %d notes\n", len(allNotes))
+	if len(problems) == 0 {
+		fmt.Println("✓ No problems found")
+		return nil
+	}
+
+	fmt.Printf("\nFound %d problem(s):\n", len(problems))
+	for _, c := range doctorChecks {
+		printed := false
+		for _, p := range problems {
+			if p.Category != c.Category {
+				continue
+			}
+			if !printed {
+				fmt.Printf("\n%s:\n", c.Category)
+				printed = true
+			}
+			fmt.Printf("  %s: %s\n", p.Path, p.Message)
+		}
+	}
+
+	return fmt.Errorf("found %d problem(s) in %d notes", len(problems), len(allNotes))
+}
+
+// collectDoctorNotes parses every markdown file in dir, including its
+// subdirectories, so notes stored under a nested path_layout are checked too
+func collectDoctorNotes(dir string, noteType notes.NoteType) ([]doctorNote, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "⚠ %s directory does not exist: %s\n", noteType, dir)
+		return nil, nil
+	}
+
+	paths, err := markdownFiles(dir, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s directory: %w", noteType, err)
+	}
+
+	parser := markdown.NewParser()
+	var found []doctorNote
+	for _, path := range paths {
+		doc, err := parser.ParseFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		found = append(found, doctorNote{
+			Path:    path,
+			DirType: noteType,
+			Doc:     doc,
+		})
+	}
+
+	return found, nil
+}
+
+// runDoctorChecks runs every doctor check against every note, returning
+// problems sorted by path
+func runDoctorChecks(allNotes []doctorNote) []doctorProblem {
+	var problems []doctorProblem
+	for _, note := range allNotes {
+		for _, c := range doctorChecks {
+			for _, p := range c.Check(note) {
+				p.Category = c.Category
+				problems = append(problems, p)
+			}
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Path < problems[j].Path
+	})
+
+	return problems
+}
+
+// checkNoteType compares the note's directory with the type derived from its
+// path, its frontmatter "type" field, and its filename
+func checkNoteType(note doctorNote) []doctorProblem {
+	var problems []doctorProblem
+	report := func(format string, args ...any) {
+		problems = append(problems, doctorProblem{
+			Path:    note.Path,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Type of the innermost configured directory containing the note, e.g. a
+	// standup directory nested inside the journal directory
+	if pathType := notes.NoteType(cfg.NoteTypeForPath(note.Path)); pathType != "" && pathType != note.DirType {
+		report("path suggests a %s note but it is in the %s directory", pathType, note.DirType)
+	}
+
+	// Frontmatter type
+	if fmType, ok := note.Doc.GetMetadataString("type"); ok && strings.TrimSpace(fmType) != "" {
+		normalized := notes.NoteType(strings.ToLower(strings.TrimSpace(fmType)))
+		if normalized != note.DirType {
+			report("frontmatter type %q disagrees with the %s directory", fmType, note.DirType)
+		}
+	}
+
+	// Filename
+	base := filepath.Base(note.Path)
+	if _, err := notes.ParseDateFromFilename(base, finderOptions(note.DirType)...); err != nil {
+		report("filename %q does not match the %q date format", base, cfg.FilenameFormatFor(string(note.DirType)))
+	}
+	lowerBase := strings.ToLower(base)
+	for _, other := range []notes.NoteType{notes.NoteTypeJournal, notes.NoteTypeStandup} {
+		if other != note.DirType && strings.Contains(lowerBase, string(other)) {
+			report("filename %q suggests a %s note but it is in the %s directory", base, other, note.DirType)
+		}
+	}
+
+	return problems
+}
+
+// checkDateConsistency compares the frontmatter "date" field with the date in
+// the filename. Filenames that aren't dates are reported by checkNoteType.
+func checkDateConsistency(note doctorNote) []doctorProblem {
+	fileDate, err := notes.ParseDateFromFilename(note.Path, finderOptions(note.DirType)...)
+	if err != nil {
+		return nil
+	}
+
+	if mismatch := notes.CompareFrontmatterDate(note.Doc, fileDate); mismatch != nil {
+		return []doctorProblem{{Path: note.Path, Message: mismatch.String()}}
+	}
+	return nil
+}
+
+// checkTagsField reports notes whose frontmatter has no "tags" field
+func checkTagsField(note doctorNote) []doctorProblem {
+	if _, ok := note.Doc.GetMetadata("tags"); ok {
+		return nil
+	}
+	return []doctorProblem{{Path: note.Path, Message: `frontmatter has no "tags" field`}}
+}
+
+// checkNoteLinks reports links to notes that don't exist. External links and
+// links that don't look like notes (e.g. images) are ignored.
+func checkNoteLinks(note doctorNote) []doctorProblem {
+	var problems []doctorProblem
+	classifier := links.NewClassifier(cfg)
+	for _, classified := range classifier.ClassifyAll(note.Doc.ExtractLinks()) {
+		link := classified.Link
+		if classified.Type == links.LinkTypeExternal || link.Path() == "" {
+			continue
+		}
+		if !link.IsDateLink(cfg.FilenameFormats()...) && !strings.HasSuffix(link.Path(), ".md") {
+			continue
+		}
+
+		if !linkTargetExists(note.Path, link) {
+			problems = append(problems, doctorProblem{
+				Path:    note.Path,
+				Message: fmt.Sprintf("line %d: %s points to a missing note", link.Line, link.Format(link.Destination)),
+			})
+		}
+	}
+	return problems
+}
+
+// checkStandupSections reports standups missing the configured work done or
+// work today section
+func checkStandupSections(note doctorNote) []doctorProblem {
+	if note.DirType != notes.NoteTypeStandup {
+		return nil
+	}
+
+	var problems []doctorProblem
+	for _, heading := range []string{cfg.Standup.WorkDoneSection, cfg.Standup.WorkTodaySection} {
+		if heading != "" && note.Doc.FindSectionByHeading(heading) == nil {
+			problems = append(problems, doctorProblem{
+				Path:    note.Path,
+				Message: fmt.Sprintf("missing %q section", heading),
+			})
+		}
+	}
+	return problems
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+)
+
+func TestCheckNoteType(t *testing.T) {
+	tests := []struct {
+		name      string
+		vaultRoot string
+		path      string
+		dirType   notes.NoteType
+		content   string
+		wantCount int
+		wantMsg   string
+	}{
+		{
+			name:    "consistent journal",
+			path:    "/vault/journal/2025-01-06.md",
+			dirType: notes.NoteTypeJournal,
+			content: "---\ntype: journal\n---\n# Daily Log\n",
+		},
+		{
+			name:      "frontmatter type disagrees",
+			path:      "/vault/journal/2025-01-06.md",
+			dirType:   notes.NoteTypeJournal,
+			content:   "---\ntype: standup\n---\n# Standup\n",
+			wantCount: 1,
+			wantMsg:   `frontmatter type "standup" disagrees with the journal directory`,
+		},
+		{
+			name:      "path suggests other type",
+			path:      "/vault/standup/2025-01-06.md",
+			dirType:   notes.NoteTypeJournal,
+			content:   "# Standup\n",
+			wantCount: 1,
+			wantMsg:   "path suggests a standup note but it is in the journal directory",
+		},
+		{
+			name:      "vault inside a journal directory",
+			vaultRoot: "/home/journal/vault",
+			path:      "/home/journal/vault/standup/2025-01-06.md",
+			dirType:   notes.NoteTypeStandup,
+			content:   "# Standup\n",
+		},
+		{
+			name:      "filename names other type",
+			path:      "/vault/journal/2025-01-06-standup.md",
+			dirType:   notes.NoteTypeJournal,
+			content:   "# Standup\n",
+			wantCount: 1,
+			wantMsg:   "suggests a standup note",
+		},
+		{
+			name:      "filename without date",
+			path:      "/vault/journal/notes.md",
+			dirType:   notes.NoteTypeJournal,
+			content:   "# Notes\n",
+			wantCount: 1,
+			wantMsg:   `does not match the "2006-01-02" date format`,
+		},
+	}
+
+	parser := markdown.NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = config.DefaultConfig()
+			cfg.VaultRoot = "/vault"
+			if tt.vaultRoot != "" {
+				cfg.VaultRoot = tt.vaultRoot
+			}
+
+			doc, err := parser.Parse(tt.path, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+
+			problems := checkNoteType(doctorNote{Path: tt.path, DirType: tt.dirType, Doc: doc})
+			if len(problems) != tt.wantCount {
+				t.Fatalf("checkNoteType() = %d problems %v, want %d", len(problems), problems, tt.wantCount)
+			}
+			if tt.wantCount > 0 && !strings.Contains(problems[0].Message, tt.wantMsg) {
+				t.Errorf("checkNoteType() message = %q, want it to contain %q", problems[0].Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestCheckDateConsistency(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		content   string
+		wantCount int
+	}{
+		{
+			name:    "matching date",
+			path:    "/vault/journal/2025-01-06.md",
+			content: "---\ndate: 2025-01-06\n---\n# Daily Log\n",
+		},
+		{
+			name:      "mismatched date",
+			path:      "/vault/journal/2025-01-06.md",
+			content:   "---\ndate: 2025-01-05\n---\n# Daily Log\n",
+			wantCount: 1,
+		},
+		{
+			name:    "filename without date",
+			path:    "/vault/journal/notes.md",
+			content: "---\ndate: 2025-01-05\n---\n# Notes\n",
+		},
+	}
+
+	cfg = config.DefaultConfig()
+
+	parser := markdown.NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(tt.path, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+
+			problems := checkDateConsistency(doctorNote{Path: tt.path, DirType: notes.NoteTypeJournal, Doc: doc})
+			if len(problems) != tt.wantCount {
+				t.Fatalf("checkDateConsistency() = %d problems %v, want %d", len(problems), problems, tt.wantCount)
+			}
+			if tt.wantCount > 0 && !strings.Contains(problems[0].Message, `frontmatter date "2025-01-05"`) {
+				t.Errorf("checkDateConsistency() message = %q", problems[0].Message)
+			}
+		})
+	}
+}
+
+func TestCollectDoctorNotes(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	files := map[string]string{
+		"2025-01-06.md": "---\ntype: journal\ntags: []\n---\n# Daily Log\n",
+		"2025-01-07.md": "---\ntype: standup\ntags: []\n---\n# Standup\n",
+		"readme.txt":    "not a note",
+		// Notes under a nested path_layout are found too
+		filepath.Join("2025", "01", "2025-01-08.md"): "---\ntype: standup\ntags: []\n---\n# Standup\n",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(journalDir, name)), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+
+	found, err := collectDoctorNotes(journalDir, notes.NoteTypeJournal)
+	if err != nil {
+		t.Fatalf("collectDoctorNotes() error = %v", err)
+	}
+	if len(found) != 3 {
+		t.Fatalf("collectDoctorNotes() = %d notes, want 3", len(found))
+	}
+
+	problems := runDoctorChecks(found)
+	if len(problems) != 2 {
+		t.Fatalf("runDoctorChecks() = %d problems %v, want 2", len(problems), problems)
+	}
+	for i, want := range []string{"2025-01-07.md", filepath.Join("2025", "01", "2025-01-08.md")} {
+		if rel, _ := filepath.Rel(journalDir, problems[i].Path); rel != want {
+			t.Errorf("problem %d reported for %s, want %s", i, rel, want)
+		}
+		if problems[i].Category != "Note types" {
+			t.Errorf("problem %d category = %q, want %q", i, problems[i].Category, "Note types")
+		}
+	}
+}
+
+func TestCheckTagsField(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantCount int
+	}{
+		{name: "tags field", content: "---\ntags: [\"daily\"]\n---\n# Log\n"},
+		{name: "empty tags field", content: "---\ntags: []\n---\n# Log\n"},
+		{name: "no tags field", content: "---\ntitle: Log\n---\n# Log\n", wantCount: 1},
+		{name: "no frontmatter", content: "# Log\n", wantCount: 1},
+	}
+
+	parser := markdown.NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := "/vault/journal/2025-01-06.md"
+			doc, err := parser.Parse(path, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+
+			problems := checkTagsField(doctorNote{Path: path, DirType: notes.NoteTypeJournal, Doc: doc})
+			if len(problems) != tt.wantCount {
+				t.Errorf("checkTagsField() = %d problems %v, want %d", len(problems), problems, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestCheckNoteLinks(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-05.md"), []byte("# Log\n"), 0644); err != nil {
+		t.Fatalf("failed to write note: %v", err)
+	}
+
+	content := `# Daily Log
+
+* [Yesterday](2025-01-05)
+* [Tomorrow](2025-01-07)
+* [[2025-01-04|Earlier]]
+* [Docs](https://example.com/2025-01-07)
+* ![Diagram](diagram.png)
+* [Section](#notes)
+`
+	path := filepath.Join(journalDir, "2025-01-06.md")
+
+	cfg = config.DefaultConfig()
+
+	doc, err := markdown.NewParser().Parse(path, []byte(content))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	problems := checkNoteLinks(doctorNote{Path: path, DirType: notes.NoteTypeJournal, Doc: doc})
+	want := []string{
+		"line 4: [Tomorrow](2025-01-07) points to a missing note",
+		"line 5: [[2025-01-04|Earlier]] points to a missing note",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("checkNoteLinks() = %v, want %v", problems, want)
+	}
+	for i, problem := range problems {
+		if problem.Message != want[i] {
+			t.Errorf("checkNoteLinks()[%d] = %q, want %q", i, problem.Message, want[i])
+		}
+	}
+}
+
+func TestCheckStandupSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		dirType  notes.NoteType
+		content  string
+		wantMsgs []string
+	}{
+		{
+			name:    "both sections",
+			dirType: notes.NoteTypeStandup,
+			content: "# Standup\n\n## Worked on yesterday\n\n* A\n\n## Working on Today\n\n* B\n",
+		},
+		{
+			name:     "missing work today",
+			dirType:  notes.NoteTypeStandup,
+			content:  "# Standup\n\n## Worked on yesterday\n\n* A\n",
+			wantMsgs: []string{`missing "Working on Today" section`},
+		},
+		{
+			name:    "missing both",
+			dirType: notes.NoteTypeStandup,
+			content: "# Standup\n",
+			wantMsgs: []string{
+				`missing "Worked on yesterday" section`,
+				`missing "Working on Today" section`,
+			},
+		},
+		{
+			name:    "journals are not checked",
+			dirType: notes.NoteTypeJournal,
+			content: "# Daily Log\n",
+		},
+	}
+
+	cfg = config.DefaultConfig()
+
+	parser := markdown.NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := "/vault/standup/2025-01-06.md"
+			doc, err := parser.Parse(path, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("failed to parse: %v", err)
+			}
+
+			problems := checkStandupSections(doctorNote{Path: path, DirType: tt.dirType, Doc: doc})
+			if len(problems) != len(tt.wantMsgs) {
+				t.Fatalf("checkStandupSections() = %v, want %v", problems, tt.wantMsgs)
+			}
+			for i, problem := range problems {
+				if problem.Message != tt.wantMsgs[i] {
+					t.Errorf("checkStandupSections()[%d] = %q, want %q", i, problem.Message, tt.wantMsgs[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var dueTodayCmd = &cobra.Command{
+	Use:   "due-today [date]",
+	Short: "List weekly goals due today",
+	Long: `List unfinished weekly goals (journal.week_goals_section, default
+"Goals of the Week") that are due on or before today.
+
+Goals are due when their text carries a due-date annotation matching
+journal.due_pattern (default: "(by Wed)" or "(by 2025-01-15)"). Weekday
+names resolve to that day in the current Monday-Sunday week.
+
+The goals are read from the most recent journal entry in the same week.
+Completed goals ([x]) are not listed.
+
+If no date is provided, uses today's date.
+Date format: YYYY-MM-DD
+
+Examples:
+  za due-today                    # Goals due today
+  za due-today 2025-01-15        # Goals due on a specific date`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDueToday,
+}
+
+func init() {
+	rootCmd.AddCommand(dueTodayCmd)
+}
+
+func runDueToday(cmd *cobra.Command, args []string) error {
+	// Parse date argument
+	targetDate, err := parseDateArg(args)
+	if err != nil {
+		return err
+	}
+
+	duePattern, err := cfg.Journal.DueRegexp()
+	if err != nil {
+		return fmt.Errorf("invalid journal.due_pattern: %w", err)
+	}
+
+	// Get journal directories
+	journalDirs, err := cfg.JournalDirs()
+	if err != nil {
+		return fmt.Errorf("failed to get journal directory: %w", err)
+	}
+
+	// Find the most recent journal, which must be in the same week
+	journalPath, err := notes.FindNoteByDateMulti(
+		targetDate,
+		notes.NoteTypeJournal,
+		journalDirs,
+		cfg.SearchWindowDays,
+		finderOptions(notes.NoteTypeJournal)...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to find journal entry: %w", err)
+	}
+
+	foundDate, err := notes.ParseDateFromFilename(journalPath, finderOptions(notes.NoteTypeJournal)...)
+	if err != nil {
+		return fmt.Errorf("failed to parse date from journal filename: %w", err)
+	}
+	if !util.IsSameWeek(foundDate, targetDate) {
+		fmt.Fprintf(os.Stderr, "No journal found for the week of %s (latest is %s)\n",
+			targetDate.Format(notes.DateFormat), foundDate.Format(notes.DateFormat))
+		return nil
+	}
+
+	// Parse journal file
+	parser := markdown.NewParser()
+	doc, err := parser.ParseFile(journalPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse journal: %w", err)
+	}
+
+	weekGoals := doc.FindSectionByHeading(cfg.Journal.WeekGoalsHeading())
+	if weekGoals == nil {
+		fmt.Fprintf(os.Stderr, "No %s section found in %s\n", cfg.Journal.WeekGoalsHeading(), journalPath)
+		return nil
+	}
+
+	items := markdown.AnnotateDueDates(markdown.ParseGoalItems(weekGoals.Content), duePattern, targetDate)
+	due := filterGoalsDueBy(items, targetDate)
+
+	if len(due) == 0 {
+		fmt.Println("No goals due today")
+		return nil
+	}
+
+	today := truncateToDay(targetDate)
+	for _, item := range due {
+		if item.Due.Before(today) {
+			fmt.Printf("* %s (overdue)\n", item.Text)
+		} else {
+			fmt.Printf("* %s\n", item.Text)
+		}
+	}
+
+	return nil
+}
+
+// filterGoalsDueBy returns unfinished goals with a due date on or before date
+func filterGoalsDueBy(items []markdown.GoalItem, date time.Time) []markdown.GoalItem {
+	day := truncateToDay(date)
+
+	var due []markdown.GoalItem
+	for _, item := range items {
+		if item.HasCheckbox && item.Checked {
+			continue
+		}
+		if item.HasDueDate() && !truncateToDay(item.Due).After(day) {
+			due = append(due, item)
+		}
+	}
+	return due
+}
+
+// truncateToDay returns midnight at the start of the given date
+func truncateToDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestDueToday(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	// Monday's journal carries the week's goals
+	content := `# Daily Log 2025-01-13
+
+## Goals of the Week
+
+* [ ] Ship auth service (by Tue)
+* [ ] Write docs (by Wed)
+* [ ] Plan Q2 (by Fri)
+* [x] Fix CI (by Mon)
+* [ ] Refactor tests
+`
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-13.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	// Wednesday, with no journal of its own yet
+	err := runDueToday(nil, []string{"2025-01-15"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+	output := string(outputBytes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, "* Ship auth service (by Tue) (overdue)") {
+		t.Errorf("expected overdue goal, got:\n%s", output)
+	}
+	if !strings.Contains(output, "* Write docs (by Wed)\n") {
+		t.Errorf("expected goal due today, got:\n%s", output)
+	}
+	for _, unwanted := range []string{"Plan Q2", "Fix CI", "Refactor tests"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("did not expect %q in output:\n%s", unwanted, output)
+		}
+	}
+}
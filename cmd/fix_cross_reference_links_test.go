@@ -83,6 +83,41 @@ title: Journal 2025-01-21
 	}
 }
 
+func TestFixCrossReferenceLinks_FilenameFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	currentDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	journalPath := filepath.Join(journalDir, "2025-01-21.md")
+	if err := os.WriteFile(journalPath, []byte("# Daily Log\n\n* [Standup](../standup/20250120.md)\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	cfg = &config.Config{
+		Journal:          config.JournalConfig{Dir: journalDir},
+		Standup:          config.StandupConfig{Dir: standupDir, FilenameFormat: "20060102"},
+		SearchWindowDays: 30,
+	}
+
+	if err := fixCrossReferenceLinks(currentDate, notes.NoteTypeJournal, notes.NoteTypeStandup, journalDir); err != nil {
+		t.Fatalf("fixCrossReferenceLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read updated journal: %v", err)
+	}
+	if !strings.Contains(string(updated), "[Standup](../standup/20250121.md)") {
+		t.Errorf("expected Standup link in the standup filename format, got:\n%s", updated)
+	}
+}
+
 func TestFixCrossReferenceLinks_JournalFixesStandup(t *testing.T) {
 	tempDir := t.TempDir()
 	journalDir := filepath.Join(tempDir, "journal")
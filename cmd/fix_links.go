@@ -1,23 +1,41 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/rdark/za/internal/links"
 	"github.com/rdark/za/internal/markdown"
 	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
-	dryRun bool
+	dryRun            bool
+	fixLinkTypes      []string
+	noCrossReferences bool
+	verifyFixes       bool
+	fixLinksRecursive bool
+	fixLinksJSON      bool
+	fixLinksStrict    bool
+	fixLinksStdin     bool
+	fixLinksDate      string
+	fixLinksNoteType  string
+	fixLinksCount     bool
 )
 
 var fixLinksCmd = &cobra.Command{
-	Use:   "fix-links <file>",
-	Short: "Fix relative date links in a note file",
+	Use:   "fix-links <file|dir> | --stdin --date <date> --type <type>",
+	Short: "Fix relative date links in a note file or directory",
 	Long: `Fix relative date links in a note file by resolving them to actual entries.
 
 This command analyzes all links in a markdown file and updates temporal links
@@ -26,176 +44,761 @@ to point to actual existing notes, skipping gaps like weekends and holidays.
 
 The command handles:
 - Temporal links: Yesterday/Previous, Tomorrow/Next (with synonyms)
+- Weekly links: Last Week/Next Week, resolved to the note about 7 days away
 - Cross-references: Journal <-> Standup
 - Gap handling: Skips missing days, weekends, holidays
 
 By default, the file is modified in place. Use --dry-run to preview changes
-without modifying the file.`,
-	Args: cobra.ExactArgs(1),
+without modifying the file. Before writing, the updated content is re-parsed
+to verify every fixed link; if verification fails nothing is written
+(disable with --verify=false).
+
+If a directory is given, every dated note in it is fixed and a summary is
+printed per file, followed by a total. Links are resolved for several notes
+in parallel, then written one note at a time. Use --recursive to include
+subdirectories (e.g. with a nested path_layout). --dry-run works in this mode
+too.
+
+Use --json to print a JSON array describing each link change (file, text,
+oldDestination, newDestination, type, line, error, applied) for editor or hook
+integration. Human-readable progress is then written to stderr.
+
+Use --stdin to fix a note piped in on stdin and print the fixed markdown to
+stdout, e.g. as an editor formatter. The note's date and type can't be taken
+from a path, so --date and --type are required. Nothing else is printed
+unless --verbose is set, in which case the fixes are listed on stderr.
+
+Use --count to finish with a summary of how many links were fixed, were
+already correct, could not be resolved, or were external and skipped. Given
+a directory, it totals every note.
+
+Links that cannot be resolved are reported and left alone. With --strict,
+they also make the command exit non-zero, e.g. to fail a CI build. A
+cross-reference whose note for the same date doesn't exist yet is left
+alone without being reported.
+
+With --verbose, each fix or error also shows how the link was resolved: the
+direction and size of the search window, and the dates checked without
+finding a note.
+
+Use --types (or its alias --only-type, which may be repeated) to restrict which
+kinds of links are fixed (previous, next, previous-week, next-week,
+cross-reference, or their full names such as temporal_previous), or
+--no-cross-references to leave cross-references alone.
+
+Examples:
+  za fix-links journal/2025-01-15.md --types previous,next
+  za fix-links journal/2025-01-15.md --only-type cross_reference
+  za fix-links journal/2025-01-15.md --no-cross-references
+  za fix-links journal/ --dry-run
+  za fix-links journal/ --recursive
+  cat note.md | za fix-links --stdin --date 2025-01-06 --type journal`,
+	Args: fixLinksArgs,
 	RunE: runFixLinks,
 }
 
 func init() {
 	rootCmd.AddCommand(fixLinksCmd)
 	fixLinksCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview changes without modifying the file")
+	fixLinksCmd.Flags().StringSliceVar(&fixLinkTypes, "types", nil, "Only fix these link types (previous, next, previous-week, next-week, cross-reference); alias --only-type")
+	fixLinksCmd.Flags().SetNormalizeFunc(fixLinksFlagAliases)
+	fixLinksCmd.Flags().BoolVar(&noCrossReferences, "no-cross-references", false, "Do not fix cross-reference links")
+	fixLinksCmd.Flags().BoolVar(&verifyFixes, "verify", true, "Re-parse the result and refuse to write if any fixed link is wrong")
+	fixLinksCmd.Flags().BoolVarP(&fixLinksRecursive, "recursive", "r", false, "When given a directory, also fix notes in subdirectories")
+	fixLinksCmd.Flags().BoolVar(&fixLinksJSON, "json", false, "Print a JSON report of link changes instead of text")
+	fixLinksCmd.Flags().BoolVar(&fixLinksStrict, "strict", false, "Exit with an error if any link could not be resolved")
+	fixLinksCmd.Flags().BoolVar(&fixLinksCount, "count", false, "Finish with a count of fixed, correct, unresolved and external links")
+	fixLinksCmd.Flags().BoolVar(&fixLinksStdin, "stdin", false, "Read a note from stdin and write the fixed note to stdout")
+	fixLinksCmd.Flags().StringVar(&fixLinksDate, "date", "", "Date of the note read with --stdin (YYYY-MM-DD)")
+	fixLinksCmd.Flags().StringVar(&fixLinksNoteType, "type", "", "Type of the note read with --stdin (journal or standup)")
+}
+
+// fixLinksArgs requires a file or directory, unless the note is read from
+// stdin
+func fixLinksArgs(cmd *cobra.Command, args []string) error {
+	if fixLinksStdin {
+		return cobra.NoArgs(cmd, args)
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// fixLinksFlagAliases maps alternative fix-links flag names to their flags
+func fixLinksFlagAliases(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "only-type" {
+		name = "types"
+	}
+	return pflag.NormalizedName(name)
 }
 
 func runFixLinks(cmd *cobra.Command, args []string) error {
-	filePath := args[0]
+	// Determine which link types to fix
+	selectedTypes, err := selectedLinkTypes()
+	if err != nil {
+		return err
+	}
+
+	if fixLinksStdin {
+		return runFixLinksStdin(os.Stdin, os.Stdout, selectedTypes)
+	}
+	target := args[0]
+
+	// With --json, stdout carries only the report
+	out := io.Writer(os.Stdout)
+	if fixLinksJSON {
+		out = os.Stderr
+	}
+	if len(selectedTypes) == 0 {
+		fmt.Fprintln(out, "No link types selected, nothing to fix")
+		return printLinkFixReports(nil)
+	}
 
 	// Check file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return fmt.Errorf("file does not exist: %s", filePath)
+	info, err := os.Stat(target)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("file does not exist: %s", target)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", target, err)
+	}
+
+	if info.IsDir() {
+		return fixLinksInDir(out, target, selectedTypes)
+	}
+
+	fixes, stats, err := fixLinksInNote(out, target, selectedTypes)
+	if err != nil {
+		return err
+	}
+	printLinkFixStats(out, stats)
+	if err := printLinkFixReports(linkFixReports(target, fixes)); err != nil {
+		return err
+	}
+	return checkUnresolved(len(fixes) - countFixes(fixes))
+}
+
+// stdinNoteName stands in for the path of a note read from stdin in messages
+const stdinNoteName = "<stdin>"
+
+// runFixLinksStdin fixes the links in the note read from in, whose date and
+// type come from --date and --type, and writes the result to out. Fixes are
+// listed on stderr only with --verbose, so out carries just the note.
+func runFixLinksStdin(in io.Reader, out io.Writer, selectedTypes []links.LinkType) error {
+	if fixLinksJSON {
+		return fmt.Errorf("--json cannot be used with --stdin")
+	}
+	if fixLinksDate == "" || fixLinksNoteType == "" {
+		return fmt.Errorf("--stdin requires --date and --type")
+	}
+
+	fileDate, err := parseDateArg([]string{fixLinksDate})
+	if err != nil {
+		return err
+	}
+	noteType := notes.NoteType(fixLinksNoteType)
+	if !noteType.IsValid() {
+		return fmt.Errorf("invalid note type: %q (expected journal or standup)", fixLinksNoteType)
+	}
+
+	log := io.Discard
+	if verbose {
+		log = os.Stderr
+	}
+
+	fixes, stats, err := fixLinksInStream(in, out, log, fileDate, noteType, selectedTypes)
+	if err != nil {
+		return err
+	}
+	printLinkFixStats(os.Stderr, stats)
+	return checkUnresolved(len(fixes) - countFixes(fixes))
+}
+
+// fixLinksInStream reads a note of noteType for fileDate from in and writes
+// it to out with its links fixed, listing the fixes on log. With --dry-run,
+// or if no link types are selected, the note is written unchanged. If an
+// error is returned, nothing was written.
+func fixLinksInStream(in io.Reader, out, log io.Writer, fileDate time.Time, noteType notes.NoteType, selectedTypes []links.LinkType) ([]links.ResolvedLink, linkFixStats, error) {
+	content, err := io.ReadAll(in)
+	if err != nil {
+		return nil, linkFixStats{}, fmt.Errorf("failed to read note: %w", err)
+	}
+
+	newContent := string(content)
+	var needsUpdate []links.ResolvedLink
+	var stats linkFixStats
+	if len(selectedTypes) > 0 {
+		doc, err := markdown.NewParser().Parse(stdinNoteName, content)
+		if err != nil {
+			return nil, linkFixStats{}, fmt.Errorf("failed to parse note: %w", err)
+		}
+
+		allLinks := doc.ExtractLinks()
+		needsUpdate, err = classifyAndResolveLinks(stdinNoteName, allLinks, fileDate, noteType, selectedTypes...)
+		if err != nil {
+			return nil, linkFixStats{}, err
+		}
+		printLinkFixes(log, needsUpdate)
+		stats = newLinkFixStats(allLinks, selectedTypes, needsUpdate)
+
+		if countFixes(needsUpdate) > 0 && !dryRun {
+			newContent, needsUpdate, err = applyLinkFixes(doc, needsUpdate)
+			if err != nil {
+				return nil, linkFixStats{}, fmt.Errorf("failed to apply link fixes: %w", err)
+			}
+			stats = newLinkFixStats(allLinks, selectedTypes, needsUpdate)
+			if verifyFixes {
+				if err := verifyLinkFixes(stdinNoteName, newContent, needsUpdate); err != nil {
+					return nil, linkFixStats{}, fmt.Errorf("verification failed: %w", err)
+				}
+			}
+
+			// The parser reads "\n" line endings; keep the note's own, as
+			// util.RewriteFile does
+			if util.Newline(content) == "\r\n" {
+				newContent = strings.ReplaceAll(newContent, "\n", "\r\n")
+			}
+		}
+	}
+
+	if _, err := io.WriteString(out, newContent); err != nil {
+		return nil, linkFixStats{}, fmt.Errorf("failed to write note: %w", err)
+	}
+	return needsUpdate, stats, nil
+}
+
+// fixLinksInDir fixes links in every dated note in dir (and its
+// subdirectories with --recursive), printing a summary per file and overall.
+// Files that are not dated notes are skipped.
+func fixLinksInDir(out io.Writer, dir string, selectedTypes []links.LinkType) error {
+	files, err := markdownFiles(dir, fixLinksRecursive)
+	if err != nil {
+		return err
+	}
+
+	var notePaths []string
+	for _, filePath := range files {
+		if isDatedNote(filePath) {
+			notePaths = append(notePaths, filePath)
+		}
+	}
+
+	// Resolve every note's links in parallel, then apply them one by one
+	var processed, changed, total, failed, unresolved int
+	var stats linkFixStats
+	var reports []linkFixReport
+	for _, result := range links.BatchFix(notePaths, cfg, links.WithLinkTypes(selectedTypes...), links.WithTrace(verbose)) {
+		filePath := result.Path
+		fmt.Fprintf(out, "==> %s\n", filePath)
+		processed++
+
+		fixes, err := result.Fixes, result.Err
+		if err == nil {
+			fixes, err = applyNoteFixes(out, filePath, result.Document, result.Links, result.Fixes)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ %s: %v\n", filePath, err)
+			failed++
+		} else {
+			stats.add(newLinkFixStats(result.Links, selectedTypes, fixes))
+			if fixed := countFixes(fixes); fixed > 0 {
+				changed++
+				total += fixed
+			}
+		}
+		unresolved += len(fixes) - countFixes(fixes)
+		reports = append(reports, linkFixReports(filePath, fixes)...)
+		fmt.Fprintln(out)
+	}
+
+	verb := "Updated"
+	if dryRun {
+		verb = "Would update"
+	}
+	fmt.Fprintf(out, "%s %d links in %d of %d notes\n", verb, total, changed, processed)
+	printLinkFixStats(out, stats)
+
+	if err := printLinkFixReports(reports); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to fix links in %d note(s)", failed)
+	}
+	return checkUnresolved(unresolved)
+}
+
+// checkUnresolved returns an error for unresolved links when --strict is set
+func checkUnresolved(unresolved int) error {
+	if fixLinksStrict && unresolved > 0 {
+		return fmt.Errorf("%d link(s) could not be resolved", unresolved)
+	}
+	return nil
+}
+
+// markdownFiles returns the .md files in dir, sorted. With recursive set it
+// also descends into subdirectories.
+func markdownFiles(dir string, recursive bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".md") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// isDatedNote reports whether filePath is a journal or standup note with a
+// date in its filename
+func isDatedNote(filePath string) bool {
+	noteType, err := determineNoteType(filePath)
+	if err != nil {
+		return false
 	}
+	_, err = notes.ParseDateFromFilename(filePath, finderOptions(noteType)...)
+	return err == nil
+}
 
+// fixLinksInNote fixes the links in a single note and returns the links that
+// needed updating, including any that could not be resolved, and counts of
+// the note's links. With --dry-run nothing is written. If an error is
+// returned, the file was not modified.
+func fixLinksInNote(out io.Writer, filePath string, selectedTypes []links.LinkType) ([]links.ResolvedLink, linkFixStats, error) {
 	// Determine note type from path
 	noteType, err := determineNoteType(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to determine note type: %w", err)
+		return nil, linkFixStats{}, fmt.Errorf("failed to determine note type: %w", err)
 	}
 
 	// Parse date from filename
-	fileDate, err := notes.ParseDateFromFilename(filePath)
+	fileDate, err := notes.ParseDateFromFilename(filePath, finderOptions(noteType)...)
 	if err != nil {
-		return fmt.Errorf("failed to parse date from filename: %w", err)
+		return nil, linkFixStats{}, fmt.Errorf("failed to parse date from filename: %w", err)
 	}
 
 	// Parse the file
 	parser := markdown.NewParser()
 	doc, err := parser.ParseFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to parse file: %w", err)
+		return nil, linkFixStats{}, fmt.Errorf("failed to parse file: %w", err)
 	}
 
-	// Extract all links
+	// Classify, resolve, and filter links that need fixing
 	allLinks := doc.ExtractLinks()
+	needsUpdate, err := classifyAndResolveLinks(filePath, allLinks, fileDate, noteType, selectedTypes...)
+	if err != nil {
+		return nil, linkFixStats{}, err
+	}
+
+	fixes, err := applyNoteFixes(out, filePath, doc, allLinks, needsUpdate)
+	if err != nil {
+		return nil, linkFixStats{}, err
+	}
+	return fixes, newLinkFixStats(allLinks, selectedTypes, fixes), nil
+}
 
+// applyNoteFixes reports the fixes for the links found in the note at
+// filePath and, unless --dry-run is set, writes them to the file. It returns
+// the fixes, including any links that could not be resolved. If an error is
+// returned, the file was not modified.
+func applyNoteFixes(out io.Writer, filePath string, doc *markdown.Document, allLinks []markdown.Link, needsUpdate []links.ResolvedLink) ([]links.ResolvedLink, error) {
 	if len(allLinks) == 0 {
-		fmt.Println("No links found in file")
-		return nil
+		fmt.Fprintln(out, "No links found in file")
+		return nil, nil
+	}
+
+	if len(needsUpdate) == 0 {
+		fmt.Fprintln(out, "All links are already correct!")
+		return nil, nil
 	}
 
-	// Classify links
-	classifier := links.NewClassifier(cfg)
-	classified := classifier.ClassifyAll(allLinks)
+	fmt.Fprintf(out, "\n%d links need updating:\n\n", len(needsUpdate))
+	printLinkFixes(out, needsUpdate)
+
+	if countFixes(needsUpdate) == 0 {
+		fmt.Fprintln(out, "\nNo links could be resolved, nothing to change")
+		return needsUpdate, nil
+	}
 
-	// Filter to only fixable links
-	fixable := make([]links.ClassifiedLink, 0)
-	for _, c := range classified {
-		if c.NeedsFixing() {
-			fixable = append(fixable, c)
+	// If dry-run, stop here
+	if dryRun {
+		fmt.Fprintln(out, "\n[DRY RUN] No changes made")
+		return needsUpdate, nil
+	}
+
+	// Apply changes
+	fmt.Fprintln(out, "\nApplying changes...")
+
+	newContent, needsUpdate, err := applyLinkFixes(doc, needsUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply link fixes: %w", err)
+	}
+
+	// Verify the rewritten content before touching the file
+	if verifyFixes {
+		if err := verifyLinkFixes(filePath, newContent, needsUpdate); err != nil {
+			return nil, fmt.Errorf("verification failed, %s was not modified: %w", filePath, err)
 		}
 	}
 
-	if len(fixable) == 0 {
-		fmt.Println("No fixable links found in file")
-		return nil
+	// Write back to file
+	if err := util.RewriteFile(filePath, []byte(newContent)); err != nil {
+		return nil, fmt.Errorf("failed to write file: %w", err)
 	}
 
-	fmt.Printf("Found %d fixable links\n", len(fixable))
+	fmt.Fprintf(out, "\n✓ Successfully updated %d links in %s\n", countFixes(needsUpdate), filePath)
 
-	// Resolve links
-	resolver := links.NewResolver(cfg, fileDate, noteType)
-	resolved := resolver.ResolveAll(fixable)
+	return needsUpdate, nil
+}
 
-	// Filter to links that need updating
-	needsUpdate := links.FilterNeedsUpdate(resolved)
+// printTrace prints how a link was resolved, if it was traced (--verbose)
+func printTrace(out io.Writer, r links.ResolvedLink) {
+	trace := r.Trace
+	if trace == nil {
+		return
+	}
 
-	if len(needsUpdate) == 0 {
-		fmt.Println("All links are already correct!")
-		return nil
+	if trace.Direction == links.SearchSameDay {
+		fmt.Fprintf(out, "   Search: %s (%s), same day only\n",
+			trace.From.Format(notes.DateFormat), r.Classified.Type)
+	} else {
+		fmt.Fprintf(out, "   Search: %s from %s, up to %d days (%s)\n",
+			trace.Direction, trace.From.Format(notes.DateFormat), trace.Days, r.Classified.Type)
 	}
 
-	fmt.Printf("\n%d links need updating:\n\n", len(needsUpdate))
+	examined := make([]string, 0, len(trace.Examined))
+	for _, date := range trace.Examined {
+		examined = append(examined, date.Format(notes.DateFormat))
+	}
+	switch {
+	case r.Error != nil:
+		fmt.Fprintf(out, "   No note on any of %d dates checked\n", len(examined))
+	case len(examined) == 0:
+		fmt.Fprintf(out, "   Found %s on the first date checked\n", r.ResolvedDate.Format(notes.DateFormat))
+	default:
+		fmt.Fprintf(out, "   No note on %s; found %s\n", strings.Join(examined, ", "), r.ResolvedDate.Format(notes.DateFormat))
+	}
+}
 
-	// Display changes
+// printLinkFixes lists each link fix, or the error for a link that could not
+// be resolved
+func printLinkFixes(out io.Writer, needsUpdate []links.ResolvedLink) {
 	for i, r := range needsUpdate {
 		if r.Error != nil {
-			fmt.Printf("%d. [%s](%s) - ERROR: %v\n",
+			fmt.Fprintf(out, "%d. %s - ERROR: %v\n",
 				i+1,
-				r.Classified.Link.Text,
-				r.Classified.Link.Destination,
+				r.Classified.Link.Format(r.Classified.Link.Destination),
 				r.Error,
 			)
+			printTrace(out, r)
 			continue
 		}
 
-		fmt.Printf("%d. [%s](%s)\n",
+		fmt.Fprintf(out, "%d. %s\n",
 			i+1,
-			r.Classified.Link.Text,
-			r.Classified.Link.Destination,
+			r.Classified.Link.Format(r.Classified.Link.Destination),
 		)
-		fmt.Printf("   → %s\n",
+		fmt.Fprintf(out, "   → %s\n",
 			r.SuggestedDestination,
 		)
-		fmt.Printf("   Type: %s\n",
+		fmt.Fprintf(out, "   Type: %s\n",
 			r.Classified.Type,
 		)
+		if len(r.SkippedDates) > 0 {
+			fmt.Fprintf(out, "   Skipped %s\n", formatSkippedDates(r))
+		}
+		printTrace(out, r)
 	}
+}
 
-	// If dry-run, stop here
+// formatSkippedDates lists the days a fix skips over, e.g.
+// "Sat 01-11, Sun 01-12", marking configured holidays
+func formatSkippedDates(r links.ResolvedLink) string {
+	holidays := make(map[string]bool, len(r.SkippedHolidays))
+	for _, holiday := range r.SkippedHolidays {
+		holidays[holiday.Format(notes.DateFormat)] = true
+	}
+
+	days := make([]string, 0, len(r.SkippedDates))
+	for _, date := range r.SkippedDates {
+		day := date.Format("Mon 01-02")
+		if holidays[date.Format(notes.DateFormat)] {
+			day += " (holiday)"
+		}
+		days = append(days, day)
+	}
+	return strings.Join(days, ", ")
+}
+
+// linkFixStats counts what fix-links did with a note's links
+type linkFixStats struct {
+	// Fixable is the number of links of the selected types with a date
+	Fixable int
+
+	// Fixed is the number of fixable links that were (or, with --dry-run,
+	// would be) updated
+	Fixed int
+
+	// Unresolved is the number of fixable links that could not be resolved
+	Unresolved int
+
+	// External is the number of external links, which are never fixed
+	External int
+}
+
+// newLinkFixStats counts allLinks, found in one note, given the fixes made
+// to them for selectedTypes
+func newLinkFixStats(allLinks []markdown.Link, selectedTypes []links.LinkType, fixes []links.ResolvedLink) linkFixStats {
+	var stats linkFixStats
+	classified := links.NewClassifier(cfg).ClassifyAll(allLinks)
+	stats.External = len(links.FilterByType(classified, links.LinkTypeExternal))
+	for _, link := range links.FilterByTypes(classified, selectedTypes...) {
+		if link.NeedsFixing() {
+			stats.Fixable++
+		}
+	}
+	stats.Fixed = countFixes(fixes)
+	stats.Unresolved = len(fixes) - stats.Fixed
+	return stats
+}
+
+// add adds other's counts to s
+func (s *linkFixStats) add(other linkFixStats) {
+	s.Fixable += other.Fixable
+	s.Fixed += other.Fixed
+	s.Unresolved += other.Unresolved
+	s.External += other.External
+}
+
+// String summarises the counts, e.g. "Fixed 3 of 6 fixable links (2 already
+// correct, 1 unresolved, 1 external skipped)"
+func (s linkFixStats) String() string {
+	verb := "Fixed"
 	if dryRun {
-		fmt.Println("\n[DRY RUN] No changes made")
-		return nil
+		verb = "Would fix"
 	}
+	return fmt.Sprintf("%s %d of %d fixable links (%d already correct, %d unresolved, %d external skipped)",
+		verb, s.Fixed, s.Fixable, s.Fixable-s.Fixed-s.Unresolved, s.Unresolved, s.External)
+}
 
-	// Apply changes
-	fmt.Println("\nApplying changes...")
+// printLinkFixStats prints stats with --count
+func printLinkFixStats(out io.Writer, stats linkFixStats) {
+	if fixLinksCount {
+		fmt.Fprintf(out, "\n%s\n", stats)
+	}
+}
 
-	newContent, err := applyLinkFixes(doc, needsUpdate)
-	if err != nil {
-		return fmt.Errorf("failed to apply link fixes: %w", err)
+// countFixes returns how many of the resolved links have a fix (no error)
+func countFixes(fixes []links.ResolvedLink) int {
+	count := 0
+	for _, fix := range fixes {
+		if fix.Error == nil {
+			count++
+		}
 	}
+	return count
+}
 
-	// Write back to file
-	if err := os.WriteFile(filePath, []byte(newContent), 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
+// linkFixReport is a single link change as reported by fix-links --json
+type linkFixReport struct {
+	File           string `json:"file"`
+	Text           string `json:"text"`
+	OldDestination string `json:"oldDestination"`
+	NewDestination string `json:"newDestination,omitempty"`
+	Type           string `json:"type"`
+	Line           int    `json:"line"`
+	Error          string `json:"error,omitempty"`
+
+	// SkippedDates are the days the fix skips over
+	SkippedDates []string `json:"skippedDates,omitempty"`
+
+	// SkippedHolidays are the configured holidays among SkippedDates
+	SkippedHolidays []string `json:"skippedHolidays,omitempty"`
+
+	// Applied is true if the change was written to the file
+	Applied bool `json:"applied"`
+}
+
+// linkFixReports converts the links fixed in filePath into reports
+func linkFixReports(filePath string, fixes []links.ResolvedLink) []linkFixReport {
+	reports := make([]linkFixReport, 0, len(fixes))
+	for _, fix := range fixes {
+		report := linkFixReport{
+			File:           filePath,
+			Text:           fix.Classified.Link.Text,
+			OldDestination: fix.Classified.Link.Destination,
+			NewDestination: fix.SuggestedDestination,
+			Type:           string(fix.Classified.Type),
+			Line:           fix.Classified.Link.Line,
+			Applied:        !dryRun && fix.Error == nil,
+		}
+		if fix.Error != nil {
+			report.Error = fix.Error.Error()
+		}
+		for _, date := range fix.SkippedDates {
+			report.SkippedDates = append(report.SkippedDates, date.Format(notes.DateFormat))
+		}
+		for _, holiday := range fix.SkippedHolidays {
+			report.SkippedHolidays = append(report.SkippedHolidays, holiday.Format(notes.DateFormat))
+		}
+		reports = append(reports, report)
 	}
+	return reports
+}
 
-	fmt.Printf("\n✓ Successfully updated %d links in %s\n", len(needsUpdate), filePath)
+// printLinkFixReports prints reports as a JSON array if --json is set
+func printLinkFixReports(reports []linkFixReport) error {
+	if !fixLinksJSON {
+		return nil
+	}
+	if reports == nil {
+		reports = []linkFixReport{}
+	}
 
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON report: %w", err)
+	}
+	fmt.Println(string(data))
 	return nil
 }
 
-// determineNoteType determines the note type from the file path by checking
-// if any path component matches "journal" or "standup" (case-insensitive).
-func determineNoteType(filePath string) (notes.NoteType, error) {
-	// Normalize path separators and split into components
-	normalizedPath := strings.ReplaceAll(filePath, "\\", "/")
+// selectedLinkTypes returns the link types fix-links should resolve, based on
+// the --types and --no-cross-references flags. Defaults to all fixable types.
+func selectedLinkTypes() ([]links.LinkType, error) {
+	var selected []links.LinkType
+	if len(fixLinkTypes) == 0 {
+		selected = []links.LinkType{
+			links.LinkTypeTemporalPrevious,
+			links.LinkTypeTemporalNext,
+			links.LinkTypeTemporalWeekPrevious,
+			links.LinkTypeTemporalWeekNext,
+			links.LinkTypeCrossReference,
+		}
+	} else {
+		for _, name := range fixLinkTypes {
+			linkType, err := links.ParseLinkType(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --types value: %w", err)
+			}
+			if !links.IsFixableType(linkType) {
+				return nil, fmt.Errorf("invalid --types value: %s links are never fixed", linkType)
+			}
+			selected = append(selected, linkType)
+		}
+	}
 
-	// Check each component for journal or standup
-	for component := range strings.SplitSeq(normalizedPath, "/") {
-		lowerComponent := strings.ToLower(component)
-		switch lowerComponent {
-		case "journal":
-			return notes.NoteTypeJournal, nil
-		case "standup":
-			return notes.NoteTypeStandup, nil
+	if noCrossReferences {
+		filtered := selected[:0]
+		for _, linkType := range selected {
+			if linkType != links.LinkTypeCrossReference {
+				filtered = append(filtered, linkType)
+			}
 		}
+		selected = filtered
 	}
 
+	return selected, nil
+}
+
+// determineNoteType determines the note type from the configured directory
+// containing the file, or else from a "journal" or "standup" directory in its
+// path (case-insensitive).
+func determineNoteType(filePath string) (notes.NoteType, error) {
+	if noteType := links.NoteTypeOf(cfg, filePath); noteType != "" {
+		return noteType, nil
+	}
 	return "", fmt.Errorf("cannot determine note type from path: %s (expected path to contain 'journal' or 'standup' directory)", filePath)
 }
 
-// applyLinkFixes applies link fixes to the document content
-func applyLinkFixes(doc *markdown.Document, fixes []links.ResolvedLink) (string, error) {
-	content := string(doc.Content)
+// applyLinkFixes applies link fixes to the document content. Each fix
+// replaces just the destination of its link, at the link's position in the
+// document, so identical links elsewhere are left alone. Reference-style
+// links, whose destination is defined elsewhere, are skipped with a warning.
+// It returns the new content and the fixes without the skipped links.
+func applyLinkFixes(doc *markdown.Document, fixes []links.ResolvedLink) (string, []links.ResolvedLink, error) {
+	var replacements []markdown.DestinationReplacement
+	applied := make([]links.ResolvedLink, 0, len(fixes))
+	for _, fix := range fixes {
+		if fix.Error != nil {
+			applied = append(applied, fix)
+			continue
+		}
+		link := fix.Classified.Link
+		if doc.IsReferenceLink(link) {
+			fmt.Fprintf(os.Stderr, "⚠ %s:%d: %s is a reference-style link, left unchanged\n",
+				doc.FilePath, link.Line, link.Format(link.Destination))
+			continue
+		}
+		applied = append(applied, fix)
+		replacements = append(replacements, markdown.DestinationReplacement{
+			Link:        link,
+			Destination: fix.SuggestedDestination,
+		})
+	}
 
-	// Sort fixes by line number (descending) to avoid position shifts
-	// For now, do simple string replacement (could be improved with AST manipulation)
+	content, err := doc.ReplaceLinkDestinations(replacements)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(content), applied, nil
+}
 
+// verifyLinkFixes re-parses the rewritten content and confirms that every
+// applied fix produced a link with the suggested destination, and that the
+// resolved target note exists. It guards against applyLinkFixes rewriting the
+// wrong text.
+func verifyLinkFixes(filePath, newContent string, fixes []links.ResolvedLink) error {
+	parser := markdown.NewParser()
+	doc, err := parser.Parse(filePath, []byte(newContent))
+	if err != nil {
+		return fmt.Errorf("failed to re-parse content: %w", err)
+	}
+
+	// Count links in the new content by text and destination
+	present := make(map[[2]string]int)
+	for _, link := range doc.ExtractLinks() {
+		present[[2]string{link.Text, link.Destination}]++
+	}
+
+	var problems []string
 	for _, fix := range fixes {
 		if fix.Error != nil {
 			continue
 		}
 
-		// Build old and new link strings
-		oldLink := fmt.Sprintf("[%s](%s)", fix.Classified.Link.Text, fix.Classified.Link.Destination)
-		newLink := fmt.Sprintf("[%s](%s)", fix.Classified.Link.Text, fix.SuggestedDestination)
+		key := [2]string{fix.Classified.Link.Text, fix.SuggestedDestination}
+		if present[key] == 0 {
+			problems = append(problems, fmt.Sprintf("[%s](%s) was not rewritten to %s",
+				fix.Classified.Link.Text, fix.Classified.Link.Destination, fix.SuggestedDestination))
+			continue
+		}
+		present[key]--
 
-		// Replace (only first occurrence to be safe)
-		content = strings.Replace(content, oldLink, newLink, 1)
+		if fix.ResolvedPath != "" {
+			if _, err := os.Stat(fix.ResolvedPath); err != nil {
+				problems = append(problems, fmt.Sprintf("[%s](%s) points to missing note %s",
+					fix.Classified.Link.Text, fix.SuggestedDestination, fix.ResolvedPath))
+			}
+		}
 	}
 
-	return content, nil
+	if len(problems) > 0 {
+		return fmt.Errorf("%d link(s) failed verification:\n  %s", len(problems), strings.Join(problems, "\n  "))
+	}
+
+	return nil
 }
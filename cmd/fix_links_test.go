@@ -1,9 +1,20 @@
 package cmd
 
 import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/links"
+	"github.com/rdark/za/internal/markdown"
 	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
 )
 
 func TestDetermineNoteType(t *testing.T) {
@@ -61,6 +72,12 @@ func TestDetermineNoteType(t *testing.T) {
 			want:     notes.NoteTypeJournal,
 			wantErr:  false,
 		},
+		{
+			name:     "innermost directory wins",
+			filePath: "/home/journal/vault/standup/2025-10-27.md",
+			want:     notes.NoteTypeStandup,
+			wantErr:  false,
+		},
 		{
 			name:     "invalid path",
 			filePath: "/path/to/notes/2025-10-27.md",
@@ -82,3 +99,1424 @@ func TestDetermineNoteType(t *testing.T) {
 		})
 	}
 }
+
+func TestRunFixLinks_NoCrossReferences(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	// Journals on 01-06 and 01-08 (01-07 missing), standup on 01-08
+	for _, date := range []string{"2025-01-06", "2025-01-08"} {
+		if err := os.WriteFile(filepath.Join(journalDir, date+".md"), []byte("# Daily Log\n"), 0644); err != nil {
+			t.Fatalf("failed to create journal: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(standupDir, "2025-01-08.md"), []byte("# Standup\n"), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := `# Daily Log 2025-01-08
+
+* [Yesterday](2025-01-07)
+* [Standup](../standup/2025-01-07)
+`
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	dryRun = false
+	fixLinkTypes = nil
+	noCrossReferences = true
+	defer func() { noCrossReferences = false }()
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	got := string(updated)
+
+	if !strings.Contains(got, "[Yesterday](2025-01-06)") {
+		t.Errorf("expected Yesterday link to be fixed, got:\n%s", got)
+	}
+	if !strings.Contains(got, "[Standup](../standup/2025-01-07)") {
+		t.Errorf("expected Standup link to be left untouched, got:\n%s", got)
+	}
+}
+
+func TestRunFixLinks_TypesCrossReferenceOnly(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-06.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(standupDir, "2025-01-08.md"), []byte("# Standup\n"), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := `# Daily Log 2025-01-08
+
+* [Yesterday](2025-01-07)
+* [Standup](../standup/2025-01-07)
+`
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	dryRun = false
+	fixLinkTypes = []string{"cross-reference"}
+	defer func() { fixLinkTypes = nil }()
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	got := string(updated)
+
+	if !strings.Contains(got, "[Yesterday](2025-01-07)") {
+		t.Errorf("expected Yesterday link to be left untouched, got:\n%s", got)
+	}
+	if !strings.Contains(got, "[Standup](../standup/2025-01-08)") {
+		t.Errorf("expected Standup link to be fixed, got:\n%s", got)
+	}
+}
+
+func TestRunFixLinks_CrossReferenceWithoutSameDateNote(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	// Only the previous day's standup exists
+	if err := os.WriteFile(filepath.Join(standupDir, "2025-01-07.md"), []byte("# Standup\n"), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := "# Daily Log 2025-01-08\n\n* [Standup](../standup/2025-01-08)\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	dryRun = false
+	fixLinkTypes = nil
+	verifyFixes = true
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if string(updated) != content {
+		t.Errorf("expected the cross-reference to stay on the same date, got:\n%s", updated)
+	}
+}
+
+func TestRunFixLinks_VaultRootRoundTrip(t *testing.T) {
+	vaultRoot := t.TempDir()
+	journalDir := filepath.Join(vaultRoot, "journal")
+	standupDir := filepath.Join(vaultRoot, "work", "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+	standupPath := filepath.Join(standupDir, "2025-01-07.md")
+	if err := os.WriteFile(standupPath, []byte("# Standup\n"), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-07.md")
+	if err := os.WriteFile(journalPath, []byte("# Daily Log\n\n* [Standup](../standup/2025-01-06)\n"), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.VaultRoot = vaultRoot
+	cfg.Journal.Dir = "journal"
+	cfg.Standup.Dir = "work/standup"
+
+	dryRun = false
+	fixLinkTypes = nil
+	verifyFixes = true
+	defer func() { fixLinksStrict = false }()
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	doc, err := markdown.NewParser().ParseFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to parse journal: %v", err)
+	}
+	written := doc.ExtractLinks()
+	if len(written) != 1 || written[0].Destination != "../work/standup/2025-01-07" {
+		t.Fatalf("links after fixing = %+v, want one to ../work/standup/2025-01-07", written)
+	}
+
+	// The written link must be recognised so that later passes check it
+	classified := links.NewClassifier(cfg).Classify(written[0])
+	if !written[0].IsDateLink() || written[0].GetDateFromDestination() != "2025-01-07" || !classified.NeedsFixing() {
+		t.Errorf("written link %q is not detected as a fixable date link", written[0].Destination)
+	}
+
+	// Once the standup is gone, a second pass leaves the link alone: the
+	// standup may simply not have been written yet
+	if err := os.Remove(standupPath); err != nil {
+		t.Fatalf("failed to remove standup: %v", err)
+	}
+	fixLinksStrict = true
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Errorf("second runFixLinks error = %v, want none for a missing same-day standup", err)
+	}
+	content, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if !strings.Contains(string(content), "(../work/standup/2025-01-07)") {
+		t.Errorf("journal after second pass = %q, want the link unchanged", content)
+	}
+}
+
+func TestRunFixLinks_OnlyTypeCrossReference(t *testing.T) {
+	// --only-type is an alias for --types
+	if flag := fixLinksCmd.Flags().Lookup("only-type"); flag == nil || flag.Name != "types" {
+		t.Fatalf("expected --only-type to be an alias for --types, got %v", flag)
+	}
+
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-06.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-10.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(standupDir, "2025-01-08.md"), []byte("# Standup\n"), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := `# Daily Log 2025-01-08
+
+* [Yesterday](2025-01-07)
+* [Tomorrow](2025-01-09)
+* [Standup](../standup/2025-01-07)
+`
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	dryRun = false
+	fixLinkTypes = []string{"cross_reference"}
+	defer func() { fixLinkTypes = nil }()
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	want := `# Daily Log 2025-01-08
+
+* [Yesterday](2025-01-07)
+* [Tomorrow](2025-01-09)
+* [Standup](../standup/2025-01-08)
+`
+	if string(updated) != want {
+		t.Errorf("expected only the Standup link to be fixed, got:\n%s", updated)
+	}
+}
+
+func TestSelectedLinkTypes(t *testing.T) {
+	defer func() {
+		fixLinkTypes = nil
+		noCrossReferences = false
+	}()
+
+	fixLinkTypes = []string{"previous", "cross-reference"}
+	noCrossReferences = true
+	got, err := selectedLinkTypes()
+	if err != nil {
+		t.Fatalf("selectedLinkTypes() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != links.LinkTypeTemporalPrevious {
+		t.Errorf("selectedLinkTypes() = %v, want [temporal_previous]", got)
+	}
+
+	fixLinkTypes = []string{"bogus"}
+	noCrossReferences = false
+	if _, err := selectedLinkTypes(); err == nil {
+		t.Error("selectedLinkTypes() should fail for unknown type")
+	}
+
+	fixLinkTypes = []string{"temporal_next", "external"}
+	if _, err := selectedLinkTypes(); err == nil {
+		t.Error("selectedLinkTypes() should fail for a type that is never fixed")
+	}
+}
+
+func TestVerifyLinkFixes_CatchesBadReplacement(t *testing.T) {
+	// The same link text appears inside an inline code span. A rewrite of the
+	// code span instead of the real link leaves the real link stale.
+	content := "# Daily Log\n\nUse `[Yesterday](2025-01-07)` to link back.\n\n* [Yesterday](2025-01-07)\n"
+
+	parser := markdown.NewParser()
+	doc, err := parser.Parse("journal/2025-01-08.md", []byte(content))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	allLinks := doc.ExtractLinks()
+	if len(allLinks) != 1 {
+		t.Fatalf("expected 1 link, got %d", len(allLinks))
+	}
+
+	fixes := []links.ResolvedLink{
+		{
+			Classified: links.ClassifiedLink{
+				Link: allLinks[0],
+				Type: links.LinkTypeTemporalPrevious,
+			},
+			NeedsUpdate:          true,
+			SuggestedDestination: "2025-01-06",
+		},
+	}
+
+	badContent := strings.Replace(content, "(2025-01-07)", "(2025-01-06)", 1)
+	if err := verifyLinkFixes(doc.FilePath, badContent, fixes); err == nil {
+		t.Errorf("verifyLinkFixes() should fail when the real link was not rewritten, content:\n%s", badContent)
+	}
+}
+
+func TestVerifyLinkFixes_Success(t *testing.T) {
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "2025-01-06.md")
+	if err := os.WriteFile(target, []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create target: %v", err)
+	}
+
+	content := "# Daily Log\n\n* [Yesterday](2025-01-07)\n"
+	parser := markdown.NewParser()
+	doc, err := parser.Parse("journal/2025-01-08.md", []byte(content))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	fixes := []links.ResolvedLink{
+		{
+			Classified: links.ClassifiedLink{
+				Link: doc.ExtractLinks()[0],
+				Type: links.LinkTypeTemporalPrevious,
+			},
+			ResolvedPath:         target,
+			NeedsUpdate:          true,
+			SuggestedDestination: "2025-01-06",
+		},
+	}
+
+	newContent, _, err := applyLinkFixes(doc, fixes)
+	if err != nil {
+		t.Fatalf("applyLinkFixes failed: %v", err)
+	}
+
+	if err := verifyLinkFixes(doc.FilePath, newContent, fixes); err != nil {
+		t.Errorf("verifyLinkFixes() unexpected error: %v", err)
+	}
+
+	// A fix pointing at a missing note should fail verification
+	fixes[0].ResolvedPath = filepath.Join(tempDir, "missing.md")
+	if err := verifyLinkFixes(doc.FilePath, newContent, fixes); err == nil {
+		t.Error("verifyLinkFixes() should fail when the resolved note is missing")
+	}
+}
+
+func TestRunFixLinks_CodeSpanOnSameLine(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-06.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	// The code span shares a line with the real link and comes first, but
+	// only the real link is rewritten
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := "# Daily Log\n\nUse `[Yesterday](2025-01-07)` like [Yesterday](2025-01-07)\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	dryRun = false
+	fixLinkTypes = nil
+	verifyFixes = true
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	after, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	want := "# Daily Log\n\nUse `[Yesterday](2025-01-07)` like [Yesterday](2025-01-06)\n"
+	if string(after) != want {
+		t.Errorf("expected only the real link to be fixed, got:\n%s", after)
+	}
+}
+
+func TestRunFixLinks_ReferenceLinkSkipped(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	for _, name := range []string{"2025-01-06.md", "2025-01-09.md"} {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte("# Daily Log\n"), 0644); err != nil {
+			t.Fatalf("failed to create journal: %v", err)
+		}
+	}
+
+	// The reference-style link can't be rewritten in place, but mustn't stop
+	// the inline link being fixed
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := "# Daily Log\n\n* [Yesterday][y]\n* [Tomorrow](2025-01-10)\n\n[y]: 2025-01-07\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stderr, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+	dryRun = false
+	fixLinkTypes = nil
+	verifyFixes = true
+	fixLinksStrict = true
+	defer func() { fixLinksStrict = false }()
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	after, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	want := "# Daily Log\n\n* [Yesterday][y]\n* [Tomorrow](2025-01-09)\n\n[y]: 2025-01-07\n"
+	if string(after) != want {
+		t.Errorf("runFixLinks() wrote:\n%s\nwant:\n%s", after, want)
+	}
+}
+
+func TestRunFixLinks_JournalDirs(t *testing.T) {
+	tempDir := t.TempDir()
+	workDir := filepath.Join(tempDir, "work", "journal")
+	personalDir := filepath.Join(tempDir, "personal", "journal")
+	for _, dir := range []string{workDir, personalDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "2025-01-07.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	// The note is in the second directory; its previous note is in the first
+	journalPath := filepath.Join(personalDir, "2025-01-08.md")
+	if err := os.WriteFile(journalPath, []byte("# Daily Log\n\n* [Yesterday](2025-01-06)\n"), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = ""
+	cfg.Journal.Dirs = []string{workDir, personalDir}
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stdout = oldStdout }()
+
+	dryRun = false
+	fixLinkTypes = nil
+	verifyFixes = true
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	after, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	want := "# Daily Log\n\n* [Yesterday](../../work/journal/2025-01-07)\n"
+	if string(after) != want {
+		t.Errorf("runFixLinks() wrote:\n%s\nwant:\n%s", after, want)
+	}
+
+	// The rewritten link is now correct
+	problems, err := checkLinksInNote(journalPath)
+	if err != nil {
+		t.Fatalf("checkLinksInNote() error = %v", err)
+	}
+	if len(problems) > 0 {
+		t.Errorf("checkLinksInNote() = %v, want no problems", problems)
+	}
+
+	// A link with the right date but the wrong directory is broken
+	if err := os.WriteFile(journalPath, []byte("# Daily Log\n\n* [Yesterday](2025-01-07)\n"), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+	problems, err = checkLinksInNote(journalPath)
+	if err != nil {
+		t.Fatalf("checkLinksInNote() error = %v", err)
+	}
+	wantProblem := journalPath + ":3: [Yesterday](2025-01-07) points to missing note, should be ../../work/journal/2025-01-07"
+	if len(problems) != 1 || problems[0] != wantProblem {
+		t.Errorf("checkLinksInNote() = %v, want [%s]", problems, wantProblem)
+	}
+}
+
+func TestApplyLinkFixes_DuplicateLinks(t *testing.T) {
+	// The same standup link appears in the nav block and in prose; only the
+	// prose link is being fixed.
+	content := "# Daily Log\n\n[Standup](../standup/2025-01-20.md)\n\n## Notes\n\nSee [Standup](../standup/2025-01-20.md) for details.\n"
+
+	parser := markdown.NewParser()
+	doc, err := parser.Parse("journal/2025-01-21.md", []byte(content))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	allLinks := doc.ExtractLinks()
+	if len(allLinks) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(allLinks))
+	}
+	if allLinks[0].Line != 3 || allLinks[1].Line != 7 {
+		t.Fatalf("expected links on lines 3 and 7, got %d and %d", allLinks[0].Line, allLinks[1].Line)
+	}
+
+	fixes := []links.ResolvedLink{
+		{
+			Classified: links.ClassifiedLink{
+				Link: allLinks[1],
+				Type: links.LinkTypeCrossReference,
+			},
+			NeedsUpdate:          true,
+			SuggestedDestination: "../standup/2025-01-21.md",
+		},
+	}
+
+	newContent, _, err := applyLinkFixes(doc, fixes)
+	if err != nil {
+		t.Fatalf("applyLinkFixes failed: %v", err)
+	}
+
+	want := "# Daily Log\n\n[Standup](../standup/2025-01-20.md)\n\n## Notes\n\nSee [Standup](../standup/2025-01-21.md) for details.\n"
+	if newContent != want {
+		t.Errorf("applyLinkFixes() =\n%s\nwant:\n%s", newContent, want)
+	}
+}
+
+func TestApplyLinkFixes_SameLine(t *testing.T) {
+	content := "[Yesterday](2025-01-07) and [Yesterday](2025-01-07)\n"
+
+	parser := markdown.NewParser()
+	doc, err := parser.Parse("journal/2025-01-08.md", []byte(content))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	allLinks := doc.ExtractLinks()
+	if len(allLinks) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(allLinks))
+	}
+
+	var fixes []links.ResolvedLink
+	for i, dest := range []string{"2025-01-06", "2025-01-05"} {
+		fixes = append(fixes, links.ResolvedLink{
+			Classified:           links.ClassifiedLink{Link: allLinks[i], Type: links.LinkTypeTemporalPrevious},
+			NeedsUpdate:          true,
+			SuggestedDestination: dest,
+		})
+	}
+
+	newContent, _, err := applyLinkFixes(doc, fixes)
+	if err != nil {
+		t.Fatalf("applyLinkFixes failed: %v", err)
+	}
+
+	want := "[Yesterday](2025-01-06) and [Yesterday](2025-01-05)\n"
+	if newContent != want {
+		t.Errorf("applyLinkFixes() = %q, want %q", newContent, want)
+	}
+}
+
+func TestRunFixLinks_WikiLinks(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	// Journals on 01-06 and 01-08 (01-07 missing)
+	for _, date := range []string{"2025-01-06", "2025-01-08"} {
+		if err := os.WriteFile(filepath.Join(journalDir, date+".md"), []byte("# Daily Log\n"), 0644); err != nil {
+			t.Fatalf("failed to create journal: %v", err)
+		}
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := "# Daily Log 2025-01-08\n\n* [[2025-01-07|Yesterday]]\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	dryRun = false
+	fixLinkTypes = nil
+	verifyFixes = true
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+
+	want := "# Daily Log 2025-01-08\n\n* [[2025-01-06|Yesterday]]\n"
+	if string(updated) != want {
+		t.Errorf("expected wiki link to be fixed, got:\n%s", updated)
+	}
+}
+
+func TestRunFixLinks_KeepsTitle(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-06.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := "# Daily Log 2025-01-08\n\n* [Yesterday](2025-01-07 \"Previous day\")\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	dryRun = false
+	fixLinkTypes = nil
+	verifyFixes = true
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	want := "# Daily Log 2025-01-08\n\n* [Yesterday](2025-01-06 \"Previous day\")\n"
+	if string(updated) != want {
+		t.Errorf("expected link title to be kept, got:\n%s", updated)
+	}
+}
+
+func TestRunFixLinks_Backup(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-06.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := "# Daily Log 2025-01-08\n\n* [Yesterday](2025-01-07)\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	dryRun = false
+	fixLinkTypes = nil
+	util.BackupOnRewrite = true
+	defer func() { util.BackupOnRewrite = false }()
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if want := "# Daily Log 2025-01-08\n\n* [Yesterday](2025-01-06)\n"; string(updated) != want {
+		t.Errorf("expected link to be fixed, got:\n%s", updated)
+	}
+
+	backup, err := os.ReadFile(journalPath + util.BackupSuffix)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+	if string(backup) != content {
+		t.Errorf("expected backup to hold the original content, got:\n%s", backup)
+	}
+}
+
+func TestRunFixLinks_PreservesCRLF(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-06.md"), []byte("# Daily Log\r\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := "---\r\ntags: [journal]\r\n---\r\n# Daily Log 2025-01-08\r\n\r\n* [Yesterday](2025-01-07)\r\n* Notes\r\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	dryRun = false
+	fixLinkTypes = nil
+	verifyFixes = true
+
+	if err := runFixLinks(nil, []string{journalPath}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+
+	updated, err := os.ReadFile(journalPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	want := "---\r\ntags: [journal]\r\n---\r\n# Daily Log 2025-01-08\r\n\r\n* [Yesterday](2025-01-06)\r\n* Notes\r\n"
+	if string(updated) != want {
+		t.Errorf("expected CRLF line endings to be kept, got %q", updated)
+	}
+}
+
+func TestRunFixLinks_Directory(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	nestedDir := filepath.Join(journalDir, "2025")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	// 01-06 and 01-08 exist (01-07 missing); 01-08 and the nested 01-09 link
+	// to the missing day. README.md is not a dated note and is skipped.
+	files := map[string]string{
+		filepath.Join(journalDir, "2025-01-06.md"): "# Daily Log\n",
+		filepath.Join(journalDir, "2025-01-08.md"): "# Daily Log\n\n* [Yesterday](2025-01-07)\n",
+		filepath.Join(journalDir, "README.md"):     "See [Yesterday](2025-01-07)\n",
+		filepath.Join(nestedDir, "2025-01-09.md"):  "# Daily Log\n\n* [Yesterday](2025-01-07)\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	fixLinkTypes = nil
+	verifyFixes = true
+	defer func() {
+		dryRun = false
+		fixLinksRecursive = false
+	}()
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	// Dry run changes nothing
+	dryRun = true
+	if err := runFixLinks(nil, []string{journalDir}); err != nil {
+		t.Fatalf("runFixLinks dry run failed: %v", err)
+	}
+	for path, content := range files {
+		if got := read(path); got != content {
+			t.Errorf("dry run modified %s:\n%s", path, got)
+		}
+	}
+
+	// Without --recursive only the top-level notes are fixed
+	dryRun = false
+	if err := runFixLinks(nil, []string{journalDir}); err != nil {
+		t.Fatalf("runFixLinks failed: %v", err)
+	}
+	if got := read(filepath.Join(journalDir, "2025-01-08.md")); !strings.Contains(got, "[Yesterday](2025-01-06)") {
+		t.Errorf("expected 2025-01-08 to be fixed, got:\n%s", got)
+	}
+	if got := read(filepath.Join(journalDir, "README.md")); got != files[filepath.Join(journalDir, "README.md")] {
+		t.Errorf("README.md should be skipped, got:\n%s", got)
+	}
+	nestedPath := filepath.Join(nestedDir, "2025-01-09.md")
+	if got := read(nestedPath); got != files[nestedPath] {
+		t.Errorf("nested note should not be fixed without --recursive, got:\n%s", got)
+	}
+
+	// With --recursive the nested note is fixed too, relative to its own
+	// directory
+	fixLinksRecursive = true
+	if err := runFixLinks(nil, []string{journalDir}); err != nil {
+		t.Fatalf("runFixLinks --recursive failed: %v", err)
+	}
+	if got := read(nestedPath); !strings.Contains(got, "[Yesterday](../2025-01-08)") {
+		t.Errorf("expected nested note to be fixed, got:\n%s", got)
+	}
+}
+
+func TestRunFixLinks_JSON(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-06.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-08.md")
+	content := "# Daily Log\n\n* [Yesterday](2025-01-07)\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	fixLinkTypes = nil
+	verifyFixes = true
+	fixLinksJSON = true
+	defer func() {
+		dryRun = false
+		fixLinksJSON = false
+	}()
+
+	// Silence the human-readable progress on stderr
+	oldStderr := os.Stderr
+	os.Stderr, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stderr = oldStderr }()
+
+	for _, dry := range []bool{true, false} {
+		dryRun = dry
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runFixLinks(nil, []string{journalPath})
+
+		w.Close()
+		os.Stdout = oldStdout
+		outputBytes, _ := io.ReadAll(r)
+
+		if err != nil {
+			t.Fatalf("runFixLinks(dryRun=%v) error = %v", dry, err)
+		}
+
+		var reports []linkFixReport
+		if err := json.Unmarshal(outputBytes, &reports); err != nil {
+			t.Fatalf("output is not a JSON report: %v\n%s", err, outputBytes)
+		}
+
+		want := []linkFixReport{{
+			File:           journalPath,
+			Text:           "Yesterday",
+			OldDestination: "2025-01-07",
+			NewDestination: "2025-01-06",
+			Type:           string(links.LinkTypeTemporalPrevious),
+			Line:           3,
+			SkippedDates:   []string{"2025-01-07"},
+			Applied:        !dry,
+		}}
+		if !reflect.DeepEqual(reports, want) {
+			t.Errorf("runFixLinks(dryRun=%v) report = %+v, want %+v", dry, reports, want)
+		}
+	}
+}
+
+func TestRunFixLinks_Verbose(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-10.md"), []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-13.md")
+	content := "# Daily Log\n\n* [Yesterday](2025-01-12)\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	dryRun = true
+	fixLinkTypes = nil
+	verifyFixes = true
+	defer func() {
+		dryRun = false
+		verbose = false
+	}()
+
+	for _, v := range []bool{false, true} {
+		verbose = v
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runFixLinks(nil, []string{journalPath})
+
+		w.Close()
+		os.Stdout = oldStdout
+		outputBytes, _ := io.ReadAll(r)
+		output := string(outputBytes)
+
+		if err != nil {
+			t.Fatalf("runFixLinks(verbose=%v) error = %v", v, err)
+		}
+
+		wantLines := []string{
+			"Search: backward from 2025-01-12, up to 30 days (temporal_previous)",
+			"No note on 2025-01-12, 2025-01-11; found 2025-01-10",
+		}
+		for _, line := range wantLines {
+			if strings.Contains(output, line) != v {
+				t.Errorf("runFixLinks(verbose=%v) output contains %q = %v, want %v\n%s",
+					v, line, !v, v, output)
+			}
+		}
+	}
+}
+
+func TestFixLinksInStream(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+	for _, path := range []string{
+		filepath.Join(journalDir, "2025-01-03.md"),
+		filepath.Join(journalDir, "2025-01-07.md"),
+		filepath.Join(standupDir, "2025-01-06.md"),
+	} {
+		if err := os.WriteFile(path, []byte("# Note\n"), 0644); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	fixLinkTypes = nil
+	noCrossReferences = false
+	verifyFixes = true
+	defer func() { dryRun = false }()
+
+	allTypes, err := selectedLinkTypes()
+	if err != nil {
+		t.Fatalf("selectedLinkTypes() error = %v", err)
+	}
+
+	content := "# Daily Log 2025-01-06\n\n* [Yesterday](2025-01-05)\n* [Tomorrow](2025-01-07)\n* [Standup](../standup/2025-01-06)\n"
+	fixed := "# Daily Log 2025-01-06\n\n* [Yesterday](2025-01-03)\n* [Tomorrow](2025-01-07)\n* [Standup](../standup/2025-01-06)\n"
+	crlf := func(s string) string { return strings.ReplaceAll(s, "\n", "\r\n") }
+
+	tests := []struct {
+		name      string
+		dryRun    bool
+		types     []links.LinkType
+		content   string
+		want      string
+		wantFixes int
+	}{
+		{
+			name:      "fixes links",
+			types:     allTypes,
+			want:      fixed,
+			wantFixes: 1,
+		},
+		{
+			name:      "keeps CRLF line endings",
+			types:     allTypes,
+			content:   crlf(content),
+			want:      crlf(fixed),
+			wantFixes: 1,
+		},
+		{
+			name:    "CRLF note without fixes is unchanged",
+			types:   allTypes,
+			content: crlf(fixed),
+			want:    crlf(fixed),
+		},
+		{
+			name:      "dry run leaves the note unchanged",
+			dryRun:    true,
+			types:     allTypes,
+			want:      content,
+			wantFixes: 1,
+		},
+		{
+			name: "no link types selected",
+			want: content,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dryRun = tt.dryRun
+			in := tt.content
+			if in == "" {
+				in = content
+			}
+
+			var out, log strings.Builder
+			fixes, _, err := fixLinksInStream(strings.NewReader(in), &out, &log,
+				time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), notes.NoteTypeJournal, tt.types)
+			if err != nil {
+				t.Fatalf("fixLinksInStream() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("fixLinksInStream() wrote %q, want %q", out.String(), tt.want)
+			}
+			if got := countFixes(fixes); got != tt.wantFixes {
+				t.Errorf("fixLinksInStream() returned %d fixes, want %d", got, tt.wantFixes)
+			}
+			if tt.wantFixes > 0 && !strings.Contains(log.String(), "→ 2025-01-03") {
+				t.Errorf("fixLinksInStream() log = %q, want the fix listed", log.String())
+			}
+		})
+	}
+}
+
+func TestRunFixLinksStdin_Flags(t *testing.T) {
+	cfg = config.DefaultConfig()
+	defer func() {
+		fixLinksDate = ""
+		fixLinksNoteType = ""
+		fixLinksJSON = false
+	}()
+
+	tests := []struct {
+		name     string
+		date     string
+		noteType string
+		json     bool
+		wantErr  string
+	}{
+		{name: "missing date", noteType: "journal", wantErr: "--stdin requires --date and --type"},
+		{name: "missing type", date: "2025-01-06", wantErr: "--stdin requires --date and --type"},
+		{name: "invalid date", date: "06/01/2025", noteType: "journal", wantErr: "invalid date format"},
+		{name: "invalid type", date: "2025-01-06", noteType: "goals", wantErr: "invalid note type"},
+		{name: "json", date: "2025-01-06", noteType: "journal", json: true, wantErr: "--json cannot be used with --stdin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixLinksDate = tt.date
+			fixLinksNoteType = tt.noteType
+			fixLinksJSON = tt.json
+
+			var out strings.Builder
+			err := runFixLinksStdin(strings.NewReader("# Note\n"), &out, []links.LinkType{links.LinkTypeTemporalPrevious})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runFixLinksStdin() error = %v, want %q", err, tt.wantErr)
+			}
+			if out.Len() != 0 {
+				t.Errorf("runFixLinksStdin() wrote %q on error", out.String())
+			}
+		})
+	}
+}
+
+func TestRunFixLinks_Count(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	files := map[string]string{
+		"2025-01-03.md": "# Daily Log\n",
+		// One fixed, one already correct, one left alone (no standup yet) and
+		// one external; the project link is never fixable
+		"2025-01-06.md": `# Daily Log
+
+* [Yesterday](2025-01-05)
+* [Tomorrow](2025-01-07)
+* [Standup](../standup/2025-01-06)
+* [Docs](https://example.com)
+* [Spec](../projects/spec.md)
+`,
+		// One already correct, one unresolved (no later note) and one external
+		"2025-01-07.md": `# Daily Log
+
+* [Yesterday](2025-01-06)
+* [Tomorrow](2025-01-08)
+* [Site](https://example.org)
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	dryRun = true
+	fixLinkTypes = nil
+	noCrossReferences = false
+	verifyFixes = true
+	defer func() {
+		dryRun = false
+		fixLinksCount = false
+	}()
+
+	want := "Would fix 1 of 5 fixable links (3 already correct, 1 unresolved, 2 external skipped)"
+	for _, count := range []bool{false, true} {
+		fixLinksCount = count
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := runFixLinks(nil, []string{journalDir})
+
+		w.Close()
+		os.Stdout = oldStdout
+		outputBytes, _ := io.ReadAll(r)
+
+		if err != nil {
+			t.Fatalf("runFixLinks(count=%v) error = %v", count, err)
+		}
+		if got := strings.Contains(string(outputBytes), want); got != count {
+			t.Errorf("runFixLinks(count=%v) output contains %q = %v, want %v\n%s",
+				count, want, got, count, outputBytes)
+		}
+	}
+}
+
+func TestLinkFixStats(t *testing.T) {
+	cfg = config.DefaultConfig()
+	defer func() { dryRun = false }()
+
+	allLinks := []markdown.Link{
+		{Text: "Yesterday", Destination: "2025-01-05"},
+		{Text: "Tomorrow", Destination: "2025-01-07"},
+		{Text: "Standup", Destination: "../standup/2025-01-06"},
+		{Text: "Docs", Destination: "https://example.com"},
+		{Text: "Image", Destination: "img.png"},
+	}
+	fixes := []links.ResolvedLink{
+		{Classified: links.ClassifiedLink{Link: allLinks[0]}, NeedsUpdate: true},
+		{Classified: links.ClassifiedLink{Link: allLinks[2]}, Error: notes.ErrNoteNotFound},
+	}
+
+	tests := []struct {
+		name  string
+		types []links.LinkType
+		fixes []links.ResolvedLink
+		want  linkFixStats
+	}{
+		{
+			name:  "all fixable types",
+			types: []links.LinkType{links.LinkTypeTemporalPrevious, links.LinkTypeTemporalNext, links.LinkTypeCrossReference},
+			fixes: fixes,
+			want:  linkFixStats{Fixable: 3, Fixed: 1, Unresolved: 1, External: 1},
+		},
+		{
+			name:  "cross-references excluded",
+			types: []links.LinkType{links.LinkTypeTemporalPrevious, links.LinkTypeTemporalNext},
+			fixes: fixes[:1],
+			want:  linkFixStats{Fixable: 2, Fixed: 1, External: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newLinkFixStats(allLinks, tt.types, tt.fixes); got != tt.want {
+				t.Errorf("newLinkFixStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	var total linkFixStats
+	total.add(linkFixStats{Fixable: 3, Fixed: 1, Unresolved: 1, External: 1})
+	total.add(linkFixStats{Fixable: 4, Fixed: 2, External: 0})
+	want := "Fixed 3 of 7 fixable links (3 already correct, 1 unresolved, 1 external skipped)"
+	if got := total.String(); got != want {
+		t.Errorf("linkFixStats.String() = %q, want %q", got, want)
+	}
+
+	dryRun = true
+	if got := total.String(); !strings.HasPrefix(got, "Would fix 3 of 7") {
+		t.Errorf("linkFixStats.String() with --dry-run = %q, want it to start with %q", got, "Would fix 3 of 7")
+	}
+}
+
+func TestPrintTrace(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		resolved links.ResolvedLink
+		want     string
+	}{
+		{
+			name: "found on first date",
+			resolved: links.ResolvedLink{
+				Classified:   links.ClassifiedLink{Type: links.LinkTypeTemporalNext},
+				ResolvedDate: day(9),
+				Trace:        &links.Trace{Direction: links.SearchForward, From: day(9), Days: 30},
+			},
+			want: "   Search: forward from 2025-01-09, up to 30 days (temporal_next)\n" +
+				"   Found 2025-01-09 on the first date checked\n",
+		},
+		{
+			name: "not found",
+			resolved: links.ResolvedLink{
+				Classified: links.ClassifiedLink{Type: links.LinkTypeCrossReference},
+				Error:      notes.ErrNoteNotFound,
+				Trace: &links.Trace{
+					Direction: links.SearchSameDay,
+					From:      day(8),
+					Days:      1,
+					Examined:  []time.Time{day(8)},
+				},
+			},
+			want: "   Search: 2025-01-08 (cross_reference), same day only\n" +
+				"   No note on any of 1 dates checked\n",
+		},
+		{
+			name:     "no trace",
+			resolved: links.ResolvedLink{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			printTrace(&buf, tt.resolved)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("printTrace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSkippedDates(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 12, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name     string
+		resolved links.ResolvedLink
+		want     string
+	}{
+		{
+			name:     "weekend",
+			resolved: links.ResolvedLink{SkippedDates: []time.Time{day(21), day(22)}},
+			want:     "Sat 12-21, Sun 12-22",
+		},
+		{
+			name: "holidays",
+			resolved: links.ResolvedLink{
+				SkippedDates:    []time.Time{day(25), day(26)},
+				SkippedHolidays: []time.Time{day(25)},
+			},
+			want: "Wed 12-25 (holiday), Thu 12-26",
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSkippedDates(tt.resolved); got != tt.want {
+				t.Errorf("formatSkippedDates() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunFixLinks_Strict(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	// No journal before 2025-01-08, so the Yesterday link can't be resolved
+	brokenPath := filepath.Join(journalDir, "2025-01-08.md")
+	if err := os.WriteFile(brokenPath, []byte("# Daily Log\n\n* [Yesterday](2025-01-07)\n"), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+	// 2025-01-09 links correctly to 2025-01-08
+	okPath := filepath.Join(journalDir, "2025-01-09.md")
+	if err := os.WriteFile(okPath, []byte("# Daily Log\n\n* [Yesterday](2025-01-08)\n"), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	dryRun = false
+	fixLinkTypes = nil
+	defer func() { fixLinksStrict = false }()
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stdout = oldStdout }()
+
+	tests := []struct {
+		name    string
+		path    string
+		strict  bool
+		wantErr bool
+	}{
+		{"unresolved without strict", brokenPath, false, false},
+		{"unresolved with strict", brokenPath, true, true},
+		{"resolved with strict", okPath, true, false},
+		{"directory with strict", journalDir, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixLinksStrict = tt.strict
+			err := runFixLinks(nil, []string{tt.path})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runFixLinks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
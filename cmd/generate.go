@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rdark/za/internal/config"
 	"github.com/rdark/za/internal/github"
 	"github.com/rdark/za/internal/links"
 	"github.com/rdark/za/internal/markdown"
@@ -17,8 +18,27 @@ import (
 
 var (
 	skipWorkExtraction bool
+	skipGitHub         bool
+
+	generateJournalTemplate string
 )
 
+// prClient is the part of the GitHub client used by commands
+type prClient interface {
+	GetPRsCreatedYesterday(date time.Time) ([]github.PullRequest, error)
+	GetPRsMergedYesterday(date time.Time) ([]github.PullRequest, error)
+	GetPRsOpenAndUnreviewed(date time.Time) ([]github.PullRequest, error)
+}
+
+// newPRClient creates the GitHub client used by generate-standup and prs.
+// It is a variable so tests can substitute a stub.
+var newPRClient = func(ghCfg config.GitHubConfig) (prClient, error) {
+	if !github.IsAvailable() {
+		return nil, fmt.Errorf("gh CLI not available")
+	}
+	return github.NewClient(ghCfg.Org, ghCfg.LookbackDays), nil
+}
+
 var generateJournalCmd = &cobra.Command{
 	Use:   "generate-journal [date]",
 	Short: "Generate a new journal entry",
@@ -29,7 +49,8 @@ After creation, it automatically fixes any relative date links in the new file.
 
 Examples:
   za generate-journal                    # Generate today's journal
-  za generate-journal 2025-01-15        # Generate journal for specific date`,
+  za generate-journal 2025-01-15        # Generate journal for specific date
+  za generate-journal --template travel # Use the "travel" create command from journal.templates`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runGenerateJournal,
 }
@@ -46,7 +67,8 @@ After creation, it automatically fixes any relative date links in the new file.
 Examples:
   za generate-standup                    # Generate today's standup with yesterday's work
   za generate-standup 2025-01-15        # Generate standup for specific date
-  za generate-standup --no-work         # Generate without extracting work from journal`,
+  za generate-standup --no-work         # Generate without extracting work from journal
+  za generate-standup --no-github       # Generate without fetching GitHub PRs`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runGenerateStandup,
 }
@@ -55,24 +77,25 @@ func init() {
 	rootCmd.AddCommand(generateJournalCmd)
 	rootCmd.AddCommand(generateStandupCmd)
 
+	generateJournalCmd.Flags().StringVar(&generateJournalTemplate, "template", "", "Name of the journal.templates create command to use instead of journal.create")
+
 	generateStandupCmd.Flags().BoolVar(&skipWorkExtraction, "no-work", false, "Skip populating with work from previous day's journal")
+	generateStandupCmd.Flags().BoolVar(&skipGitHub, "no-github", false, "Skip populating with PRs from GitHub")
 }
 
 func runGenerateJournal(cmd *cobra.Command, args []string) error {
 	// Parse target date
-	var targetDate time.Time
-	var err error
-	if len(args) > 0 {
-		targetDate, err = time.Parse(notes.DateFormat, args[0])
-		if err != nil {
-			return fmt.Errorf("invalid date format, expected YYYY-MM-DD: %w", err)
-		}
-	} else {
-		targetDate = time.Now()
+	targetDate, err := parseDateArg(args)
+	if err != nil {
+		return err
 	}
 
-	// Check if create command is configured
-	if cfg.Journal.Create.Cmd == "" {
+	// Select the create command, and check it is configured
+	create, err := cfg.Journal.CreateCommandFor(generateJournalTemplate)
+	if err != nil {
+		return err
+	}
+	if create.Cmd == "" {
 		return fmt.Errorf("journal.create.cmd is not configured in .za.yaml")
 	}
 
@@ -84,7 +107,7 @@ func runGenerateJournal(cmd *cobra.Command, args []string) error {
 
 	// Build expected file path
 	dateStr := targetDate.Format(notes.DateFormat)
-	expectedPath := filepath.Join(journalDir, dateStr+".md")
+	expectedPath := notes.NotePath(journalDir, targetDate, finderOptions(notes.NoteTypeJournal)...)
 
 	// Check if file already exists
 	if _, err := os.Stat(expectedPath); err == nil {
@@ -93,11 +116,15 @@ func runGenerateJournal(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Generating journal entry for %s...\n", dateStr)
 
-	// Replace {date} placeholder in command
-	createCmd := strings.ReplaceAll(cfg.Journal.Create.Cmd, "{date}", dateStr)
+	// Replace {date} and the other placeholders in command
+	journalDirs, err := cfg.JournalDirs()
+	if err != nil {
+		return fmt.Errorf("failed to get journal directories: %w", err)
+	}
+	createCmd := expandCreatePlaceholders(create.Cmd, targetDate, notes.NoteTypeJournal, journalDirs)
 
 	// Execute create command
-	result := util.ExecuteShellCommand(createCmd, util.DefaultTimeout)
+	result := util.ExecuteShellCommand(createCmd, create.Timeout())
 
 	if result.Error != nil {
 		fmt.Fprintf(os.Stderr, "Failed to execute create command:\n")
@@ -112,7 +139,7 @@ func runGenerateJournal(cmd *cobra.Command, args []string) error {
 	// Verify file was created
 	if _, err := os.Stat(expectedPath); os.IsNotExist(err) {
 		// Try to find any newly created file in the journal directory
-		files, err := filepath.Glob(filepath.Join(journalDir, dateStr+"*.md"))
+		files, err := filepath.Glob(createdNotePattern(filepath.Dir(expectedPath), targetDate, notes.NoteTypeJournal))
 		if err != nil {
 			return fmt.Errorf("failed to search for created file: %w", err)
 		}
@@ -130,16 +157,8 @@ func runGenerateJournal(cmd *cobra.Command, args []string) error {
 		fmt.Printf("✓ Journal entry created: %s\n", expectedPath)
 	}
 
-	// Add company tag if it's a weekday and tag is configured
-	if cfg.CompanyTag != "" && util.IsWeekday(targetDate) {
-		fmt.Println("\nAdding company tag...")
-		companyTag := fmt.Sprintf("company:%s", cfg.CompanyTag)
-		if added, err := markdown.AddTagToFile(expectedPath, companyTag); err != nil {
-			fmt.Fprintf(os.Stderr, "⚠ Failed to add company tag: %v\n", err)
-		} else if added {
-			fmt.Printf("✓ Added tag: %s\n", companyTag)
-		}
-	}
+	// Add company tag if one is configured for this day
+	addCompanyTag(expectedPath, targetDate)
 
 	// Populate goals from previous journal
 	fmt.Println("\nPopulating goals from previous journal...")
@@ -172,20 +191,20 @@ func runGenerateJournal(cmd *cobra.Command, args []string) error {
 		// Don't fail the command if link fixing fails
 	}
 
+	// Run the post-create hook, if any
+	if err := runPostCreate(cfg.Journal.PostCreate, expectedPath, targetDate, notes.NoteTypeJournal, journalDirs); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v\n", err)
+		// Don't fail the command if the hook fails
+	}
+
 	return nil
 }
 
 func runGenerateStandup(cmd *cobra.Command, args []string) error {
 	// Parse target date
-	var targetDate time.Time
-	var err error
-	if len(args) > 0 {
-		targetDate, err = time.Parse(notes.DateFormat, args[0])
-		if err != nil {
-			return fmt.Errorf("invalid date format, expected YYYY-MM-DD: %w", err)
-		}
-	} else {
-		targetDate = time.Now()
+	targetDate, err := parseDateArg(args)
+	if err != nil {
+		return err
 	}
 
 	// Check if create command is configured
@@ -201,7 +220,7 @@ func runGenerateStandup(cmd *cobra.Command, args []string) error {
 
 	// Build expected file path
 	dateStr := targetDate.Format(notes.DateFormat)
-	expectedPath := filepath.Join(standupDir, dateStr+".md")
+	expectedPath := notes.NotePath(standupDir, targetDate, finderOptions(notes.NoteTypeStandup)...)
 
 	// Check if file already exists
 	if _, err := os.Stat(expectedPath); err == nil {
@@ -210,11 +229,11 @@ func runGenerateStandup(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Generating standup entry for %s...\n", dateStr)
 
-	// Replace {date} placeholder in command
-	createCmd := strings.ReplaceAll(cfg.Standup.Create.Cmd, "{date}", dateStr)
+	// Replace {date} and the other placeholders in command
+	createCmd := expandCreatePlaceholders(cfg.Standup.Create.Cmd, targetDate, notes.NoteTypeStandup, []string{standupDir})
 
 	// Execute create command
-	result := util.ExecuteShellCommand(createCmd, util.DefaultTimeout)
+	result := util.ExecuteShellCommand(createCmd, cfg.Standup.Create.Timeout())
 
 	if result.Error != nil {
 		fmt.Fprintf(os.Stderr, "Failed to execute create command:\n")
@@ -229,7 +248,7 @@ func runGenerateStandup(cmd *cobra.Command, args []string) error {
 	// Verify file was created
 	if _, err := os.Stat(expectedPath); os.IsNotExist(err) {
 		// Try to find any newly created file in the standup directory
-		files, err := filepath.Glob(filepath.Join(standupDir, dateStr+"*.md"))
+		files, err := filepath.Glob(createdNotePattern(filepath.Dir(expectedPath), targetDate, notes.NoteTypeStandup))
 		if err != nil {
 			return fmt.Errorf("failed to search for created file: %w", err)
 		}
@@ -247,16 +266,8 @@ func runGenerateStandup(cmd *cobra.Command, args []string) error {
 		fmt.Printf("✓ Standup entry created: %s\n", expectedPath)
 	}
 
-	// Add company tag if it's a weekday and tag is configured
-	if cfg.CompanyTag != "" && util.IsWeekday(targetDate) {
-		fmt.Println("\nAdding company tag...")
-		companyTag := fmt.Sprintf("company:%s", cfg.CompanyTag)
-		if added, err := markdown.AddTagToFile(expectedPath, companyTag); err != nil {
-			fmt.Fprintf(os.Stderr, "⚠ Failed to add company tag: %v\n", err)
-		} else if added {
-			fmt.Printf("✓ Added tag: %s\n", companyTag)
-		}
-	}
+	// Add company tag if one is configured for this day
+	addCompanyTag(expectedPath, targetDate)
 
 	// Extract work from previous journal by default
 	if !skipWorkExtraction {
@@ -295,13 +306,100 @@ func runGenerateStandup(cmd *cobra.Command, args []string) error {
 		// Don't fail the command if link fixing fails
 	}
 
+	// Run the post-create hook, if any
+	if err := runPostCreate(cfg.Standup.PostCreate, expectedPath, targetDate, notes.NoteTypeStandup, []string{standupDir}); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ %v\n", err)
+		// Don't fail the command if the hook fails
+	}
+
 	return nil
 }
 
+// createdNotePattern returns a glob matching the files in dir that a create
+// command may have written for a note of noteType on date, i.e. the name in
+// the configured filename format followed by any suffix
+func createdNotePattern(dir string, date time.Time, noteType notes.NoteType) string {
+	return filepath.Join(dir, date.Format(cfg.FilenameFormatFor(string(noteType)))+"*.md")
+}
+
+// expandCreatePlaceholders replaces the placeholders in a create command for
+// a note of noteType on date: {date}, {year}, {month}, {day}, {weekday},
+// {isoweek} and {prev_date}, the date of the previous note in dirs (empty if
+// there is none)
+func expandCreatePlaceholders(command string, date time.Time, noteType notes.NoteType, dirs []string) string {
+	var prevDate string
+	if strings.Contains(command, "{prev_date}") {
+		opts := finderOptions(noteType)
+		prevPath, err := notes.FindNoteByDateMulti(date.AddDate(0, 0, -1), noteType, dirs, cfg.SearchWindowDays, opts...)
+		if err == nil {
+			if found, err := notes.ParseDateFromFilename(prevPath, opts...); err == nil {
+				prevDate = found.Format(notes.DateFormat)
+			}
+		}
+	}
+
+	_, week := date.ISOWeek()
+	return strings.NewReplacer(
+		"{date}", date.Format(notes.DateFormat),
+		"{year}", date.Format("2006"),
+		"{month}", date.Format("01"),
+		"{day}", date.Format("02"),
+		"{weekday}", date.Weekday().String(),
+		"{isoweek}", fmt.Sprintf("%02d", week),
+		"{prev_date}", prevDate,
+	).Replace(command)
+}
+
+// runPostCreate runs a post_create hook for a generated note, replacing
+// {path} with the note's path and the create command placeholders as in
+// expandCreatePlaceholders. It does nothing if no hook is configured.
+func runPostCreate(hook config.CreateCommand, notePath string, date time.Time, noteType notes.NoteType, dirs []string) error {
+	if hook.Cmd == "" {
+		return nil
+	}
+
+	fmt.Println("\nRunning post-create command...")
+	command := expandCreatePlaceholders(hook.Cmd, date, noteType, dirs)
+	command = strings.ReplaceAll(command, "{path}", notePath)
+
+	result := util.ExecuteShellCommand(command, hook.Timeout())
+	if result.Error != nil {
+		if stderr := strings.TrimSpace(result.Stderr); stderr != "" {
+			return fmt.Errorf("post-create command failed: %w: %s", result.Error, stderr)
+		}
+		return fmt.Errorf("post-create command failed: %w", result.Error)
+	}
+
+	if stdout := strings.TrimSpace(result.Stdout); stdout != "" {
+		fmt.Println(stdout)
+	}
+	fmt.Println("✓ Post-create command finished")
+	return nil
+}
+
+// addCompanyTag adds a "company:<name>" tag to a generated note for each
+// configured company if date is one of company_tag_days. Failures only warn.
+func addCompanyTag(notePath string, date time.Time) {
+	names := cfg.CompanyTagNames()
+	if len(names) == 0 || !cfg.CompanyTagOn(date) {
+		return
+	}
+
+	fmt.Println("\nAdding company tag...")
+	for _, name := range names {
+		companyTag := fmt.Sprintf("company:%s", name)
+		if added, err := markdown.EnsureTagInFile(notePath, companyTag); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Failed to add company tag: %v\n", err)
+		} else if added {
+			fmt.Printf("✓ Added tag: %s\n", companyTag)
+		}
+	}
+}
+
 // populateStandupWithWork extracts work from previous day's journal and today's goals,
 // inserting them into the appropriate standup sections
 func populateStandupWithWork(standupDate time.Time, standupPath string) error {
-	journalDir, err := cfg.JournalDir()
+	journalDirs, err := cfg.JournalDirs()
 	if err != nil {
 		return err
 	}
@@ -312,7 +410,7 @@ func populateStandupWithWork(standupDate time.Time, standupPath string) error {
 	var completedGoals []string
 	parser := markdown.NewParser()
 
-	prevJournalPath, err := notes.FindNoteByDate(previousDate, notes.NoteTypeJournal, journalDir, cfg.SearchWindowDays)
+	prevJournalPath, err := notes.FindNoteByDateMulti(previousDate, notes.NoteTypeJournal, journalDirs, cfg.SearchWindowDays, finderOptions(notes.NoteTypeJournal)...)
 	if err != nil {
 		// No previous journal found - this is OK, just skip work extraction from journal
 		fmt.Println("No previous journal found to copy work from")
@@ -326,15 +424,16 @@ func populateStandupWithWork(standupDate time.Time, standupPath string) error {
 		}
 
 		// Extract work sections from previous journal
-		workSections = prevDoc.FindSectionsByHeadings(cfg.Journal.WorkDoneSections)
+		workSections = findWorkDoneSections(prevDoc)
 
-		// Extract completed goals from previous journal's "Goals of the Day"
-		prevGoalsSection := prevDoc.FindSectionByHeading("Goals of the Day")
+		// Extract completed goals from previous journal's daily goals
+		prevGoalsSection := prevDoc.FindSectionByHeading(cfg.Journal.DayGoalsHeading())
 		if prevGoalsSection != nil && strings.TrimSpace(prevGoalsSection.Content) != "" {
 			items := markdown.ParseGoalItems(prevGoalsSection.Content)
-			for _, item := range items {
-				// Only include completed checkbox items (as plain text, no checkbox)
-				if item.HasCheckbox && item.Checked {
+			// Only include completed checkbox items (as plain text, no checkbox),
+			// leaving out any matching journal.skip_text
+			for _, item := range markdown.FilterCompletedGoals(items) {
+				if !matchesSkipText(item.Text, cfg.Journal.SkipText) {
 					completedGoals = append(completedGoals, item.Text)
 				}
 			}
@@ -346,7 +445,7 @@ func populateStandupWithWork(standupDate time.Time, standupPath string) error {
 	if len(completedGoals) > 0 {
 		fmt.Printf("Adding %d completed goal(s) from yesterday\n", len(completedGoals))
 		for _, goal := range completedGoals {
-			yesterdayContent.WriteString(fmt.Sprintf("* %s\n", goal))
+			yesterdayContent.WriteString(fmt.Sprintf("%s %s\n", cfg.Bullet(), goal))
 		}
 	}
 	for _, section := range workSections {
@@ -357,32 +456,36 @@ func populateStandupWithWork(standupDate time.Time, standupPath string) error {
 		}
 	}
 
-	// Add GitHub PRs created yesterday if integration is enabled
-	if cfg.GitHub.Enabled {
-		if !github.IsAvailable() {
-			return fmt.Errorf("GitHub integration enabled but gh CLI not available")
+	// Set up GitHub client if integration is enabled
+	// Failures only warn so the standup is still created
+	var ghClient prClient
+	if cfg.GitHub.Enabled && !skipGitHub {
+		ghClient, err = newPRClient(cfg.GitHub)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Skipping GitHub PRs: %v\n", err)
+			ghClient = nil
 		}
+	}
 
+	// Add GitHub PRs created yesterday
+	if ghClient != nil {
 		fmt.Println("Fetching GitHub PRs created yesterday...")
-		ghClient := github.NewClient(cfg.GitHub.Org)
 		prs, err := ghClient.GetPRsCreatedYesterday(standupDate)
 		if err != nil {
-			return fmt.Errorf("failed to fetch GitHub PRs created yesterday: %w", err)
-		}
-
-		if len(prs) > 0 {
+			fmt.Fprintf(os.Stderr, "⚠ Failed to fetch GitHub PRs created yesterday: %v\n", err)
+		} else if len(prs) > 0 {
 			fmt.Printf("Adding %d PR(s) created yesterday\n", len(prs))
-			prContent := github.FormatPRsAsBulletPoints(prs, false)
+			prContent := github.FormatPRsAsBulletPoints(prs, cfg.Bullet(), "")
 			yesterdayContent.WriteString(prContent)
 		}
 	}
 
-	// Find today's journal for "Working on Today" section
+	// Find today's journal for the work-today section
 	var todayGoalItems []markdown.GoalItem
-	todayJournalPath, err := notes.FindNoteByDate(standupDate, notes.NoteTypeJournal, journalDir, cfg.SearchWindowDays)
+	todayJournalPath, err := notes.FindNoteByDateMulti(standupDate, notes.NoteTypeJournal, journalDirs, cfg.SearchWindowDays, finderOptions(notes.NoteTypeJournal)...)
 	if err == nil {
 		// Verify this is actually today's journal, not a fallback to an earlier date
-		foundDate, err := notes.ParseDateFromFilename(todayJournalPath)
+		foundDate, err := notes.ParseDateFromFilename(todayJournalPath, finderOptions(notes.NoteTypeJournal)...)
 		if err == nil {
 			// Compare just the date parts (year, month, day) not the full timestamp
 			standupY, standupM, standupD := standupDate.Date()
@@ -392,7 +495,7 @@ func populateStandupWithWork(standupDate time.Time, standupPath string) error {
 
 				todayDoc, err := parser.ParseFile(todayJournalPath)
 				if err == nil {
-					todayGoalsSection := todayDoc.FindSectionByHeading("Goals of the Day")
+					todayGoalsSection := todayDoc.FindSectionByHeading(cfg.Journal.DayGoalsHeading())
 					if todayGoalsSection != nil && strings.TrimSpace(todayGoalsSection.Content) != "" {
 						items := markdown.ParseGoalItems(todayGoalsSection.Content)
 						// Include all goals (completed and uncompleted) with their checkbox state
@@ -417,22 +520,19 @@ func populateStandupWithWork(standupDate time.Time, standupPath string) error {
 		fmt.Printf("Adding %d goal(s) for today\n", len(todayGoalItems))
 		for _, item := range todayGoalItems {
 			// Always format as plain bullets (no checkboxes) in standup
-			todayContent.WriteString(fmt.Sprintf("* %s\n", item.Text))
+			todayContent.WriteString(fmt.Sprintf("%s %s\n", cfg.Bullet(), item.Text))
 		}
 	}
 
-	// Add GitHub PRs open and unreviewed if integration is enabled
-	if cfg.GitHub.Enabled {
+	// Add GitHub PRs open and unreviewed
+	if ghClient != nil {
 		fmt.Println("Fetching open and unreviewed GitHub PRs...")
-		ghClient := github.NewClient(cfg.GitHub.Org)
 		prs, err := ghClient.GetPRsOpenAndUnreviewed(standupDate)
 		if err != nil {
-			return fmt.Errorf("failed to fetch open and unreviewed GitHub PRs: %w", err)
-		}
-
-		if len(prs) > 0 {
+			fmt.Fprintf(os.Stderr, "⚠ Failed to fetch open and unreviewed GitHub PRs: %v\n", err)
+		} else if prs = github.FilterUnreviewed(prs); len(prs) > 0 {
 			fmt.Printf("Adding %d open and unreviewed PR(s)\n", len(prs))
-			prContent := github.FormatPRsAsBulletPoints(prs, true)
+			prContent := github.FormatPRsAsBulletPoints(prs, cfg.Bullet(), github.PrefixNeedsReview)
 			todayContent.WriteString(prContent)
 		}
 	}
@@ -444,12 +544,12 @@ func populateStandupWithWork(standupDate time.Time, standupPath string) error {
 	}
 
 	// Insert content into standup sections
-	newContent := string(standupContent)
+	newContent := string(util.NormalizeNewlines(standupContent))
 
 	if yesterdayContent.Len() > 0 {
 		// Add leading newline for spacing after existing content (like links)
 		content := "\n" + yesterdayContent.String()
-		newContent, err = insertIntoStandupSection(newContent, cfg.Standup.WorkDoneSection, content)
+		newContent, err = insertIntoStandupSection(newContent, cfg.Standup.WorkDoneSection, content, true)
 		if err != nil {
 			return fmt.Errorf("failed to insert yesterday's work: %w", err)
 		}
@@ -458,14 +558,14 @@ func populateStandupWithWork(standupDate time.Time, standupPath string) error {
 	if todayContent.Len() > 0 {
 		// Add leading newline for spacing after existing content (like links)
 		content := "\n" + todayContent.String()
-		newContent, err = insertIntoStandupSection(newContent, "Working on Today", content)
+		newContent, err = insertIntoStandupSection(newContent, cfg.Standup.WorkTodaySection, content, true)
 		if err != nil {
 			return fmt.Errorf("failed to insert today's goals: %w", err)
 		}
 	}
 
 	// Write updated content back to file
-	if err := os.WriteFile(standupPath, []byte(newContent), 0644); err != nil {
+	if err := util.RewriteFile(standupPath, []byte(newContent)); err != nil {
 		return fmt.Errorf("failed to write standup file: %w", err)
 	}
 
@@ -482,7 +582,7 @@ func fixLinksInFile(filePath string) error {
 	}
 
 	// Parse date from filename
-	fileDate, err := notes.ParseDateFromFilename(filePath)
+	fileDate, err := notes.ParseDateFromFilename(filePath, finderOptions(noteType)...)
 	if err != nil {
 		return fmt.Errorf("failed to parse date from filename: %w", err)
 	}
@@ -501,29 +601,29 @@ func fixLinksInFile(filePath string) error {
 	}
 
 	// Classify, resolve, and filter links that need fixing
-	needsUpdate, err := classifyAndResolveLinks(allLinks, fileDate, noteType)
+	needsUpdate, err := classifyAndResolveLinks(filePath, allLinks, fileDate, noteType)
 	if err != nil {
 		return err
 	}
 
-	if len(needsUpdate) == 0 {
-		return nil // All links are correct
+	if countFixes(needsUpdate) == 0 {
+		return nil // All links are correct or unresolvable
 	}
 
-	fmt.Printf("Fixing %d links...\n", len(needsUpdate))
+	fmt.Printf("Fixing %d links...\n", countFixes(needsUpdate))
 
 	// Apply changes
-	newContent, err := applyLinkFixes(doc, needsUpdate)
+	newContent, needsUpdate, err := applyLinkFixes(doc, needsUpdate)
 	if err != nil {
 		return fmt.Errorf("failed to apply link fixes: %w", err)
 	}
 
 	// Write back to file
-	if err := os.WriteFile(filePath, []byte(newContent), 0644); err != nil {
+	if err := util.RewriteFile(filePath, []byte(newContent)); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
-	fmt.Printf("✓ Fixed %d links in %s\n", len(needsUpdate), filepath.Base(filePath))
+	fmt.Printf("✓ Fixed %d links in %s\n", countFixes(needsUpdate), filepath.Base(filePath))
 	return nil
 }
 
@@ -546,12 +646,12 @@ func hasGoalContent(sectionContent string) bool {
 func populateJournalGoals(currentDate time.Time, journalPath string) error {
 	// Find previous journal
 	previousDate := currentDate.AddDate(0, 0, -1)
-	journalDir, err := cfg.JournalDir()
+	journalDirs, err := cfg.JournalDirs()
 	if err != nil {
 		return err
 	}
 
-	prevJournalPath, err := notes.FindNoteByDate(previousDate, notes.NoteTypeJournal, journalDir, cfg.SearchWindowDays)
+	prevJournalPath, err := notes.FindNoteByDateMulti(previousDate, notes.NoteTypeJournal, journalDirs, cfg.SearchWindowDays, finderOptions(notes.NoteTypeJournal)...)
 	if err != nil {
 		// No previous journal found - this is fine
 		fmt.Println("No previous journal found to copy goals from")
@@ -568,7 +668,7 @@ func populateJournalGoals(currentDate time.Time, journalPath string) error {
 	}
 
 	// Parse the actual date from the previous journal filename
-	prevDate, err := notes.ParseDateFromFilename(prevJournalPath)
+	prevDate, err := notes.ParseDateFromFilename(prevJournalPath, finderOptions(notes.NoteTypeJournal)...)
 	if err != nil {
 		return fmt.Errorf("failed to parse date from previous journal: %w", err)
 	}
@@ -579,7 +679,7 @@ func populateJournalGoals(currentDate time.Time, journalPath string) error {
 		return fmt.Errorf("failed to read current journal: %w", err)
 	}
 
-	content := string(currentContent)
+	content := string(util.NormalizeNewlines(currentContent))
 
 	// Parse current document to check for existing goals sections
 	currentDoc, err := parser.ParseFile(journalPath)
@@ -587,20 +687,23 @@ func populateJournalGoals(currentDate time.Time, journalPath string) error {
 		return fmt.Errorf("failed to parse current journal: %w", err)
 	}
 
+	dayHeading := cfg.Journal.DayGoalsHeading()
+	weekHeading := cfg.Journal.WeekGoalsHeading()
+
 	var goalsToAdd strings.Builder
 	sectionsAdded := false
 
-	// 1. Copy "Goals of the Week" if same week (FIRST)
+	// 1. Copy weekly goals if same week (FIRST)
 	if util.IsSameWeek(prevDate, currentDate) {
-		weekGoalsSection := prevDoc.FindSectionByHeading("Goals of the Week")
+		weekGoalsSection := prevDoc.FindSectionByHeading(weekHeading)
 		if weekGoalsSection != nil && strings.TrimSpace(weekGoalsSection.Content) != "" {
 			// Check if current journal has this section with content
-			currentWeekSection := currentDoc.FindSectionByHeading("Goals of the Week")
+			currentWeekSection := currentDoc.FindSectionByHeading(weekHeading)
 			shouldAdd := currentWeekSection == nil || !hasGoalContent(currentWeekSection.Content)
 
 			if shouldAdd {
-				fmt.Println("Copying Goals of the Week (same week)")
-				goalsToAdd.WriteString("## Goals of the Week\n\n")
+				fmt.Printf("Copying %s (same week)\n", weekHeading)
+				goalsToAdd.WriteString("## " + weekHeading + "\n\n")
 				goalsToAdd.WriteString(strings.TrimSpace(weekGoalsSection.Content))
 				goalsToAdd.WriteString("\n\n")
 				sectionsAdded = true
@@ -608,13 +711,13 @@ func populateJournalGoals(currentDate time.Time, journalPath string) error {
 		}
 	}
 
-	// 2. Copy unfinished "Goals of the Day" items (SECOND)
-	// Always add this section, even if empty
-	currentDaySection := currentDoc.FindSectionByHeading("Goals of the Day")
+	// 2. Copy unfinished daily goals (SECOND)
+	// Add this section even if empty, unless journal.ensure_empty_goals_section is false
+	currentDaySection := currentDoc.FindSectionByHeading(dayHeading)
 	shouldAddDayGoals := currentDaySection == nil || !hasGoalContent(currentDaySection.Content)
 
 	if shouldAddDayGoals {
-		dayGoalsSection := prevDoc.FindSectionByHeading("Goals of the Day")
+		dayGoalsSection := prevDoc.FindSectionByHeading(dayHeading)
 		var unfinishedItems []markdown.GoalItem
 
 		if dayGoalsSection != nil && strings.TrimSpace(dayGoalsSection.Content) != "" {
@@ -625,26 +728,27 @@ func populateJournalGoals(currentDate time.Time, journalPath string) error {
 
 		if len(unfinishedItems) > 0 {
 			fmt.Printf("Copying %d unfinished goal(s) from yesterday\n", len(unfinishedItems))
-			formattedItems := markdown.FormatGoalItems(unfinishedItems)
-			goalsToAdd.WriteString("## Goals of the Day\n\n")
+			formattedItems := markdown.FormatGoalItems(unfinishedItems, markdown.WithBullet(cfg.Bullet()))
+			goalsToAdd.WriteString("## " + dayHeading + "\n\n")
 			goalsToAdd.WriteString(formattedItems)
 			goalsToAdd.WriteString("\n\n")
-		} else {
-			fmt.Println("Adding empty Goals of the Day section")
-			goalsToAdd.WriteString("## Goals of the Day\n\n")
+			sectionsAdded = true
+		} else if cfg.Journal.ShouldEnsureEmptyGoalsSection() {
+			fmt.Printf("Adding empty %s section\n", dayHeading)
+			goalsToAdd.WriteString("## " + dayHeading + "\n\n")
+			sectionsAdded = true
 		}
-		sectionsAdded = true
 	}
 
 	// Insert goals sections after Daily Log heading if any were added
 	if sectionsAdded {
-		newContent, err := insertAfterDailyLogSection(content, goalsToAdd.String())
+		newContent, err := insertAfterDailyLogSection(content, goalsToAdd.String(), dayHeading, weekHeading)
 		if err != nil {
 			return fmt.Errorf("failed to insert goals: %w", err)
 		}
 
 		// Write updated content back to file
-		if err := os.WriteFile(journalPath, []byte(newContent), 0644); err != nil {
+		if err := util.RewriteFile(journalPath, []byte(newContent)); err != nil {
 			return fmt.Errorf("failed to write journal file: %w", err)
 		}
 
@@ -657,11 +761,14 @@ func populateJournalGoals(currentDate time.Time, journalPath string) error {
 }
 
 // insertAfterDailyLogSection inserts content after the Daily Log h1 section,
-// removing any empty Goals sections that already exist
-func insertAfterDailyLogSection(fileContent, insertContent string) (string, error) {
+// removing any empty goals sections (with the given headings) that already exist
+func insertAfterDailyLogSection(fileContent, insertContent, dayHeading, weekHeading string) (string, error) {
+	dayGoalsLine := "## " + dayHeading
+	weekGoalsLine := "## " + weekHeading
+
 	// Check which sections we're inserting
-	insertingGoalsOfDay := strings.Contains(insertContent, "## Goals of the Day")
-	insertingGoalsOfWeek := strings.Contains(insertContent, "## Goals of the Week")
+	insertingGoalsOfDay := strings.Contains(insertContent, dayGoalsLine)
+	insertingGoalsOfWeek := strings.Contains(insertContent, weekGoalsLine)
 	lines := strings.Split(fileContent, "\n")
 
 	// Find the first h1 heading (Daily Log)
@@ -707,7 +814,7 @@ func insertAfterDailyLogSection(fileContent, insertContent string) (string, erro
 		trimmed := strings.TrimSpace(lines[i])
 
 		// Check if this is a Goals heading
-		if trimmed == "## Goals of the Week" || trimmed == "## Goals of the Day" {
+		if trimmed == weekGoalsLine || trimmed == dayGoalsLine {
 			// Find the extent of this section (until next heading or end of file)
 			sectionStart := i
 			sectionHeading := trimmed
@@ -734,9 +841,9 @@ func insertAfterDailyLogSection(fileContent, insertContent string) (string, erro
 
 			if !shouldKeep {
 				// Check if we should preserve this empty section
-				if sectionHeading == "## Goals of the Day" && !insertingGoalsOfDay {
+				if sectionHeading == dayGoalsLine && !insertingGoalsOfDay {
 					shouldKeep = true
-				} else if sectionHeading == "## Goals of the Week" && !insertingGoalsOfWeek {
+				} else if sectionHeading == weekGoalsLine && !insertingGoalsOfWeek {
 					shouldKeep = true
 				}
 			}
@@ -826,8 +933,10 @@ func insertAfterFrontmatter(fileContent, insertContent string) (string, error) {
 	return result.String(), nil
 }
 
-// insertIntoStandupSection inserts content into a specific section of a standup file
-func insertIntoStandupSection(fileContent, sectionHeading, insertContent string) (string, error) {
+// insertIntoStandupSection inserts content into a specific section of a standup file.
+// If the section is missing, it's added as a "##" heading at the end of the file
+// when create is set, and an error is returned otherwise.
+func insertIntoStandupSection(fileContent, sectionHeading, insertContent string, create bool) (string, error) {
 	lines := strings.Split(fileContent, "\n")
 
 	// Find the section heading (case-insensitive, supports both # and ## headings)
@@ -854,7 +963,14 @@ func insertIntoStandupSection(fileContent, sectionHeading, insertContent string)
 	}
 
 	if sectionIndex == -1 {
-		return fileContent, fmt.Errorf("section '%s' not found", sectionHeading)
+		if !create {
+			return fileContent, fmt.Errorf("section '%s' not found", sectionHeading)
+		}
+		content := strings.TrimRight(fileContent, "\n")
+		if content != "" {
+			content += "\n\n"
+		}
+		return content + "## " + sectionHeading + "\n" + insertContent, nil
 	}
 
 	// Find where to insert: after the heading and any existing content, before next heading
@@ -894,6 +1010,12 @@ func insertIntoStandupSection(fileContent, sectionHeading, insertContent string)
 		insertIndex++
 	}
 
+	// Leave out items the section already has
+	insertContent = withoutExistingItems(insertContent, lines[sectionIndex+1:insertIndex])
+	if strings.TrimSpace(insertContent) == "" {
+		return fileContent, nil
+	}
+
 	// Build result
 	var result strings.Builder
 
@@ -917,46 +1039,54 @@ func insertIntoStandupSection(fileContent, sectionHeading, insertContent string)
 	return result.String(), nil
 }
 
-// classifyAndResolveLinks classifies and resolves links, returning only those that need updating
-func classifyAndResolveLinks(allLinks []markdown.Link, fileDate time.Time, noteType notes.NoteType) ([]links.ResolvedLink, error) {
-	// Classify links
-	classifier := links.NewClassifier(cfg)
-	classified := classifier.ClassifyAll(allLinks)
-
-	// Filter to only fixable links
-	fixable := make([]links.ClassifiedLink, 0)
-	for _, c := range classified {
-		if c.NeedsFixing() {
-			fixable = append(fixable, c)
-		}
+// withoutExistingItems returns insertContent without the list items whose text,
+// trimmed and ignoring case, matches an item in existing lines or an earlier
+// item of insertContent. Other lines are kept.
+func withoutExistingItems(insertContent string, existing []string) string {
+	seen := make(map[string]bool)
+	for _, item := range markdown.ParseGoalItems(strings.Join(existing, "\n")) {
+		seen[strings.ToLower(item.Text)] = true
 	}
 
-	if len(fixable) == 0 {
-		return nil, nil
+	var kept []string
+	for _, line := range strings.Split(insertContent, "\n") {
+		if items := markdown.ParseGoalItems(line); len(items) == 1 {
+			key := strings.ToLower(items[0].Text)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		kept = append(kept, line)
 	}
+	return strings.Join(kept, "\n")
+}
 
-	// Resolve links
+// classifyAndResolveLinks classifies and resolves the links of the note at filePath, returning
+// only those that need updating or could not be resolved (with Error set). If linkTypes are
+// given, only links of those types are considered.
+func classifyAndResolveLinks(filePath string, allLinks []markdown.Link, fileDate time.Time, noteType notes.NoteType, linkTypes ...links.LinkType) ([]links.ResolvedLink, error) {
 	resolver := links.NewResolver(cfg, fileDate, noteType)
-	resolved := resolver.ResolveAll(fixable)
-
-	// Filter to links that need updating
-	needsUpdate := links.FilterNeedsUpdate(resolved)
-
-	return needsUpdate, nil
+	resolver.SetTrace(verbose)
+	if filePath != stdinNoteName {
+		resolver.SetNoteDir(filepath.Dir(filePath))
+	}
+	return resolver.ResolveFixes(allLinks, linkTypes...), nil
 }
 
-// formatDestination formats a date as a link destination
-func formatDestination(date time.Time, targetType notes.NoteType, targetDir string) string {
-	dateStr := date.Format(notes.DateFormat) + ".md"
+// formatDestination formats the link destination from a note in sourceDir
+// to the note at targetPath, keeping its filename (and so its configured
+// filename format)
+func formatDestination(sourceDir, targetPath string) string {
 	// Use relative path to target directory
-	return filepath.Join("..", string(targetType), dateStr)
+	return filepath.Join(links.RelativeLinkDir(sourceDir, filepath.Dir(targetPath)), filepath.Base(targetPath))
 }
 
 // fixPreviousLinks finds the previous note and updates its "next" links to point to the current date
 func fixPreviousLinks(currentDate time.Time, noteType notes.NoteType, noteDir string) error {
 	// Find previous day's note
 	previousDate := currentDate.AddDate(0, 0, -1)
-	prevNotePath, err := notes.FindNoteByDate(previousDate, noteType, noteDir, cfg.SearchWindowDays)
+	prevNotePath, err := notes.FindNoteByDate(previousDate, noteType, noteDir, cfg.SearchWindowDays, finderOptions(noteType)...)
 	if err != nil {
 		// No previous note found - this is fine
 		fmt.Println("No previous note found to update")
@@ -964,7 +1094,7 @@ func fixPreviousLinks(currentDate time.Time, noteType notes.NoteType, noteDir st
 	}
 
 	// Verify this is actually the previous day, not a much older fallback
-	foundDate, err := notes.ParseDateFromFilename(prevNotePath)
+	foundDate, err := notes.ParseDateFromFilename(prevNotePath, finderOptions(noteType)...)
 	if err != nil {
 		return fmt.Errorf("failed to parse date from previous note filename: %w", err)
 	}
@@ -1020,7 +1150,7 @@ func fixPreviousLinks(currentDate time.Time, noteType notes.NoteType, noteDir st
 		}
 
 		// Get current destination date from the link
-		currentDest := classified.Link.GetDateFromDestination()
+		currentDest := classified.Link.GetDateFromDestination(cfg.FilenameFormats()...)
 
 		// If it doesn't point to currentDate, it needs updating
 		if currentDest != currentDateStr {
@@ -1037,7 +1167,8 @@ func fixPreviousLinks(currentDate time.Time, noteType notes.NoteType, noteDir st
 			}
 
 			// Build suggested destination
-			suggestedDest := formatDestination(currentDate, notes.NoteType(targetType), dir)
+			targetPath := notes.NotePath(dir, currentDate, finderOptions(notes.NoteType(targetType))...)
+			suggestedDest := formatDestination(filepath.Dir(prevNotePath), targetPath)
 
 			needsUpdate = append(needsUpdate, links.ResolvedLink{
 				Classified:           classified,
@@ -1056,13 +1187,13 @@ func fixPreviousLinks(currentDate time.Time, noteType notes.NoteType, noteDir st
 	fmt.Printf("Updating %d 'next' link(s) in previous note...\n", len(needsUpdate))
 
 	// Apply changes
-	newContent, err := applyLinkFixes(doc, needsUpdate)
+	newContent, needsUpdate, err := applyLinkFixes(doc, needsUpdate)
 	if err != nil {
 		return fmt.Errorf("failed to apply link fixes to previous note: %w", err)
 	}
 
 	// Write back to file
-	if err := os.WriteFile(prevNotePath, []byte(newContent), 0644); err != nil {
+	if err := util.RewriteFile(prevNotePath, []byte(newContent)); err != nil {
 		return fmt.Errorf("failed to write previous note: %w", err)
 	}
 
@@ -1074,7 +1205,7 @@ func fixPreviousLinks(currentDate time.Time, noteType notes.NoteType, noteDir st
 // links to point to the newly created note of newlyCreatedNoteType
 func fixCrossReferenceLinks(currentDate time.Time, targetNoteType notes.NoteType, newlyCreatedNoteType notes.NoteType, targetDir string) error {
 	// Find today's note of the target type
-	targetNotePath, err := notes.FindNoteByDate(currentDate, targetNoteType, targetDir, cfg.SearchWindowDays)
+	targetNotePath, err := notes.FindNoteByDate(currentDate, targetNoteType, targetDir, cfg.SearchWindowDays, finderOptions(targetNoteType)...)
 	if err != nil {
 		// No target note found - this is fine
 		fmt.Printf("No %s found for today to update\n", targetNoteType)
@@ -1082,7 +1213,7 @@ func fixCrossReferenceLinks(currentDate time.Time, targetNoteType notes.NoteType
 	}
 
 	// Verify this is actually today's note, not a fallback to an earlier date
-	foundDate, err := notes.ParseDateFromFilename(targetNotePath)
+	foundDate, err := notes.ParseDateFromFilename(targetNotePath, finderOptions(targetNoteType)...)
 	if err != nil {
 		return fmt.Errorf("failed to parse date from target note filename: %w", err)
 	}
@@ -1133,7 +1264,7 @@ func fixCrossReferenceLinks(currentDate time.Time, targetNoteType notes.NoteType
 
 	for _, classified := range crossRefLinks {
 		// Get current destination date from the link
-		currentDest := classified.Link.GetDateFromDestination()
+		currentDest := classified.Link.GetDateFromDestination(cfg.FilenameFormats()...)
 
 		// If it doesn't point to currentDate, it needs updating
 		if currentDest != currentDateStr {
@@ -1150,7 +1281,8 @@ func fixCrossReferenceLinks(currentDate time.Time, targetNoteType notes.NoteType
 			}
 
 			// Build suggested destination
-			suggestedDest := formatDestination(currentDate, newlyCreatedNoteType, dir)
+			newNotePath := notes.NotePath(dir, currentDate, finderOptions(newlyCreatedNoteType)...)
+			suggestedDest := formatDestination(filepath.Dir(targetNotePath), newNotePath)
 
 			needsUpdate = append(needsUpdate, links.ResolvedLink{
 				Classified:           classified,
@@ -1169,13 +1301,13 @@ func fixCrossReferenceLinks(currentDate time.Time, targetNoteType notes.NoteType
 	fmt.Printf("Updating %d %s cross-reference link(s)...\n", len(needsUpdate), newlyCreatedNoteType)
 
 	// Apply changes
-	newContent, err := applyLinkFixes(doc, needsUpdate)
+	newContent, needsUpdate, err := applyLinkFixes(doc, needsUpdate)
 	if err != nil {
 		return fmt.Errorf("failed to apply link fixes to target note: %w", err)
 	}
 
 	// Write back to file
-	if err := os.WriteFile(targetNotePath, []byte(newContent), 0644); err != nil {
+	if err := util.RewriteFile(targetNotePath, []byte(newContent)); err != nil {
 		return fmt.Errorf("failed to write target note: %w", err)
 	}
 
@@ -80,15 +80,33 @@ func generateFullConfig() string {
 # Journal Configuration
 journal:
   # Directory containing journal entries (YYYY-MM-DD.md format)
+  # Environment variables and a leading ~ are expanded, e.g. ~/$COMPANY/journal
   dir: ./journal
 
+  # Additional journal directories to search, in priority order (optional)
+  # Example: dirs: ["./personal/journal"]
+  dirs: []
+
+  # Subdirectory layout within dir, using {year}, {month} and {day}
+  # Example: "{year}/{month}" for journal/2025/01/2025-01-06.md
+  # Leave empty for a flat directory
+  path_layout: ""
+
   # Section headings to extract for 'journal-work-done' command
   # za searches for these headings (case-insensitive) and extracts their content
   work_done_sections:
     - "work completed"
     - "worked on"
 
-  # Text patterns to skip when extracting content (optional)
+  # Order of extracted sections: "document" (as they appear in the note)
+  # or "config" (the order listed in work_done_sections)
+  work_done_order: document
+
+  # Text to skip when extracting work done (optional)
+  # Lines containing any of these (ignoring case) are dropped from
+  # journal-work-done output and the work and completed goals copied
+  # into new standups
+  # Example: skip_text: ["[Yesterday]", "TODO:"]
   skip_text: []
 
   # Synonyms for "previous day" links (used by fix-links command)
@@ -96,33 +114,81 @@ journal:
   link_previous_titles:
     - "Yesterday"
     - "Previous"
-    - "Last Week"
 
   # Synonyms for "next day" links
   link_next_titles:
     - "Tomorrow"
     - "Next"
+
+  # Synonyms for links to the note about a week before or after
+  link_previous_week_titles:
+    - "Last Week"
+  link_next_week_titles:
     - "Next Week"
 
+  # Regular expression matching due-date annotations on goals (used by due-today)
+  # The first capture group is the due value: a weekday name or YYYY-MM-DD
+  due_pattern: '\(by\s+([^)]+)\)'
+
+  # Headings of the daily and weekly goals sections, copied forward by
+  # generate-journal and read by generate-standup, journal-goals and due-today
+  day_goals_section: "Goals of the Day"
+  week_goals_section: "Goals of the Week"
+
+  # Add an empty daily goals section when there are no unfinished
+  # goals to copy from the previous journal
+  ensure_empty_goals_section: true
+
   # Command to create new journal entries (optional)
-  # {date} placeholder will be replaced with YYYY-MM-DD format
+  # Placeholders:
+  #   {date}      the note date, YYYY-MM-DD
+  #   {year}      e.g. 2025
+  #   {month}     e.g. 01
+  #   {day}       e.g. 06
+  #   {weekday}   e.g. Monday
+  #   {isoweek}   the ISO week number, e.g. 02
+  #   {prev_date} the date of the previous journal (empty if there is none)
   # Examples:
   #   cmd: "zk new --title 'Daily Log {date}' journal/"
   #   cmd: "~/scripts/create-journal.sh {date}"
   #   cmd: "touch journal/{date}.md && echo '---\ntitle: {date}\n---\n\n# Work Done\n\n' > journal/{date}.md"
   create:
     cmd: ""
+    # Seconds the command may run before it is stopped
+    timeout_seconds: 30
+
+  # Named alternative create commands, chosen with generate-journal --template
+  # Example:
+  #   templates:
+  #     travel:
+  #       cmd: "~/scripts/create-journal.sh --travel {date}"
+  #       timeout_seconds: 60  # defaults to journal.create.timeout_seconds
+  templates: {}
+
+  # Command run after a journal is created and its links fixed (optional)
+  # Supports the create command placeholders plus {path}, the new note's path.
+  # A failure only warns.
+  # Example:
+  #   cmd: "git add {path} && git commit -m 'Journal {date}'"
+  post_create:
+    cmd: ""
 
 # Standup Configuration
 standup:
   # Directory containing standup notes (YYYY-MM-DD.md format)
   dir: ./standup
 
+  # Subdirectory layout within dir (see journal.path_layout)
+  path_layout: ""
+
   # Single section heading to extract for 'standup-work-done' command
   # Unlike journal (which can have multiple sections), standup extracts one section
   work_done_section: "Worked on yesterday"
 
-  # Text patterns to skip (optional)
+  # Section heading holding today's planned work, used by 'standup-slack'
+  work_today_section: "Working on Today"
+
+  # Text to skip in standup-work-done output (optional, see journal.skip_text)
   skip_text: []
 
   # Link synonyms (same as journal)
@@ -132,18 +198,99 @@ standup:
   link_next_titles:
     - "Tomorrow"
     - "Next"
+  link_previous_week_titles:
+    - "Last Week"
+  link_next_week_titles:
+    - "Next Week"
 
   # Command to create new standup entries (optional)
+  # Supports the same placeholders as journal.create.cmd; {prev_date} is the
+  # date of the previous standup
   create:
     cmd: ""
+    timeout_seconds: 30
+
+  # Command run after a standup is created (see journal.post_create)
+  post_create:
+    cmd: ""
+
+  # Emoji prefixed to each standup-slack item, keyed by section heading (optional)
+  # Example:
+  #   slack_emoji:
+  #     "Worked on yesterday": "✅"
+  #     "Working on Today": "🔜"
+  slack_emoji: {}
+
+  # Additional sections for standup-slack to print after today's work (optional)
+  # Each is labelled with its lowercased heading, e.g. "blockers:", and
+  # omitted when empty
+  # Example: extra_sections: ["Blockers"]
+  extra_sections: []
 
 # General Settings
 
+# Root directory of your notes vault
+# Relative paths (e.g. journal.dir) are resolved against this directory.
+# A relative vault_root is resolved against this config file's directory.
+# If unset and this file is found in a parent of the current directory,
+# that directory is used as the vault root.
+# vault_root: .
+
+# Filename format for notes, as a Go time layout (without the .md extension)
+# Examples: "2006-01-02" (2025-01-06.md), "20060102" (20250106.md),
+#           "2006-01-02-daily" (2025-01-06-daily.md)
+# Override per note type with journal.filename_format / standup.filename_format
+filename_format: "2006-01-02"
+
 # How many days to search backwards when looking for notes
 # When a specific date doesn't exist, za searches backwards up to this many days
 # Example: If you ask for 2025-01-09 (missing) and 2025-01-08 exists,
 #          za will return 2025-01-08 if it's within the search window
 search_window_days: 30
+
+# How far link fixing searches back for "Yesterday" and ahead for "Tomorrow"
+# links (0 means search_window_days). A small forward window stops an old
+# note's "Tomorrow" link resolving to an unrelated note weeks later.
+search_window_backward_days: 0
+search_window_forward_days: 0
+
+# Treat empty or frontmatter-only notes as missing when searching
+# Useful if your tooling pre-creates placeholder notes for future days
+skip_empty_notes: false
+
+# Leave notes marked as drafts (e.g. "draft: true" in the frontmatter) out of
+# journal-work-done, standup-work-done and journal-week, using the previous
+# note instead
+skip_draft_notes: false
+draft_field: "draft"
+
+# Tag added to new journals and standups as "company:<company_tag>"
+# Leave empty to disable
+company_tag: "acme"
+
+# Several companies to tag, e.g. when splitting time across clients
+# When set, this replaces company_tag
+# Example: company_tags: ["acme", "globex"]
+company_tags: []
+
+# Days of the week on which the company tag is added (e.g. "Mon" or "Monday")
+# An empty list means every day
+company_tag_days: ["Mon", "Tue", "Wed", "Thu", "Fri"]
+
+# Dates (YYYY-MM-DD) you don't expect notes on. They don't change which notes
+# links resolve to; list-notes and fix-links just report them as holidays
+# instead of gaps
+# Example:
+#   holidays: ["2025-12-25", "2025-12-26"]
+holidays: []
+
+# List marker ("-" or "*") for items za adds to notes, e.g. goals copied
+# forward and standup work
+bullet_style: "-"
+
+# Time zone (e.g. "Europe/London") that decides which day is today when no
+# date is given. Empty means the system's local zone
+timezone: ""
 `
 }
 
@@ -153,14 +300,15 @@ func generateMinimalConfig() string {
   work_done_sections:
     - "work completed"
     - "worked on"
-  link_previous_titles: ["Yesterday", "Previous", "Last Week"]
-  link_next_titles: ["Tomorrow", "Next", "Next Week"]
+  link_previous_titles: ["Yesterday", "Previous"]
+  link_next_titles: ["Tomorrow", "Next"]
   create:
     cmd: ""
 
 standup:
   dir: ./standup
   work_done_section: "Worked on yesterday"
+  work_today_section: "Working on Today"
   link_previous_titles: ["Yesterday", "Previous"]
   link_next_titles: ["Tomorrow", "Next"]
   create:
@@ -205,9 +205,12 @@ func TestGenerateConfig_ValidYAML(t *testing.T) {
 	}
 
 	// Try to load the generated config
-	_, err = config.Load(outputFile)
+	loaded, err := config.Load(outputFile)
 	if err != nil {
-		t.Errorf("generated config is not valid YAML: %v", err)
+		t.Fatalf("generated config is not valid YAML: %v", err)
+	}
+	if len(loaded.Source.UnknownKeys) > 0 {
+		t.Errorf("generated config has unknown keys: %v", loaded.Source.UnknownKeys)
 	}
 }
 
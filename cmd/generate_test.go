@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,7 +10,9 @@ import (
 	"time"
 
 	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/github"
 	"github.com/rdark/za/internal/notes"
+	"github.com/spf13/cobra"
 )
 
 func TestGenerateJournal_MissingConfig(t *testing.T) {
@@ -131,6 +135,129 @@ func TestGenerateJournal_Success(t *testing.T) {
 	}
 }
 
+func TestGenerateJournal_Template(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    string
+		wantContent string
+		wantErr     string
+	}{
+		{name: "default create command", template: "", wantContent: "Default Journal"},
+		{name: "named template", template: "travel", wantContent: "Travel Journal"},
+		{name: "unknown template", template: "holiday", wantErr: "unknown journal template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			dateStr := "2025-01-20"
+			targetFile := filepath.Join(tempDir, dateStr+".md")
+
+			cfg = &config.Config{
+				Journal: config.JournalConfig{
+					Dir:              tempDir,
+					WorkDoneSections: []string{"work completed"},
+					Create:           config.CreateCommand{Cmd: "echo '# Default Journal' > " + targetFile},
+					Templates: map[string]config.CreateCommand{
+						"travel": {Cmd: "echo '# Travel Journal' > " + targetFile},
+					},
+				},
+				SearchWindowDays: 30,
+			}
+
+			generateJournalTemplate = tt.template
+			defer func() { generateJournalTemplate = "" }()
+
+			// Suppress output for test
+			oldStdout := os.Stdout
+			os.Stdout, _ = os.Open(os.DevNull)
+			defer func() { os.Stdout = oldStdout }()
+
+			err := runGenerateJournal(nil, []string{dateStr})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				if _, err := os.Stat(targetFile); !os.IsNotExist(err) {
+					t.Error("expected no file to be created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			content, err := os.ReadFile(targetFile)
+			if err != nil {
+				t.Fatalf("failed to read created file: %v", err)
+			}
+			if !strings.Contains(string(content), tt.wantContent) {
+				t.Errorf("expected file to contain %q, got: %s", tt.wantContent, content)
+			}
+		})
+	}
+}
+
+func TestGenerate_CreatedFileWithCustomFilenameFormat(t *testing.T) {
+	// The create command adds a suffix to the name in the configured
+	// format, so the created note has to be found by globbing for it
+	tests := []struct {
+		name string
+		run  func(*cobra.Command, []string) error
+		want string
+	}{
+		{
+			name: "journal",
+			run:  runGenerateJournal,
+			want: "✓ Journal entry created: ",
+		},
+		{
+			name: "standup",
+			run:  runGenerateStandup,
+			want: "✓ Standup entry created: ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			targetFile := filepath.Join(tempDir, "20-01-2025-work.md")
+			create := config.CreateCommand{Cmd: "echo '# Note' > " + targetFile}
+
+			cfg = &config.Config{
+				Journal: config.JournalConfig{
+					Dir:            tempDir,
+					FilenameFormat: "02-01-2006",
+					Create:         create,
+				},
+				Standup: config.StandupConfig{
+					Dir:            tempDir,
+					FilenameFormat: "02-01-2006",
+					Create:         create,
+				},
+				SearchWindowDays: 30,
+			}
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := tt.run(nil, []string{"2025-01-20"})
+
+			w.Close()
+			os.Stdout = oldStdout
+			output, _ := io.ReadAll(r)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(string(output), tt.want+targetFile) {
+				t.Errorf("output = %q, want %q", output, tt.want+targetFile)
+			}
+		})
+	}
+}
+
 func TestGenerateStandup_MissingConfig(t *testing.T) {
 	tempDir := t.TempDir()
 	cfg = &config.Config{
@@ -344,6 +471,200 @@ This is my standup
 	}
 }
 
+func TestInsertIntoStandupSection(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		heading string
+		insert  string
+		create  bool
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "existing section",
+			content: "# Standup\n\n## Worked on yesterday\n\n* Old item\n\n## Notes\n",
+			heading: "worked on yesterday",
+			insert:  "\n* New item\n",
+			want:    "# Standup\n\n## Worked on yesterday\n\n* Old item\n\n* New item\n\n## Notes\n",
+		},
+		{
+			name:    "existing section with create",
+			content: "## Working on Today\n\n## Notes\n",
+			heading: "Working on Today",
+			insert:  "\n* Goal\n",
+			create:  true,
+			want:    "## Working on Today\n\n* Goal\n\n## Notes\n",
+		},
+		{
+			name:    "missing section created at end",
+			content: "# Standup\n\n## Worked on yesterday\n\n* Old item\n",
+			heading: "Working on Today",
+			insert:  "\n* Goal\n",
+			create:  true,
+			want:    "# Standup\n\n## Worked on yesterday\n\n* Old item\n\n## Working on Today\n\n* Goal\n",
+		},
+		{
+			name:    "missing section created in empty file",
+			content: "",
+			heading: "Working on Today",
+			insert:  "\n* Goal\n",
+			create:  true,
+			want:    "## Working on Today\n\n* Goal\n",
+		},
+		{
+			name:    "existing items skipped",
+			content: "## Worked on yesterday\n\n* Fixed bug Y\n\n## Notes\n",
+			heading: "Worked on yesterday",
+			insert:  "\n*  fixed BUG y \n- [x] Wrote docs\n* Wrote docs\n",
+			want:    "## Worked on yesterday\n\n* Fixed bug Y\n\n- [x] Wrote docs\n\n## Notes\n",
+		},
+		{
+			name:    "all items existing",
+			content: "## Worked on yesterday\n\n* Fixed bug Y\n\n## Notes\n",
+			heading: "Worked on yesterday",
+			insert:  "\n* Fixed bug Y\n",
+			want:    "## Worked on yesterday\n\n* Fixed bug Y\n\n## Notes\n",
+		},
+		{
+			name:    "missing section without create",
+			content: "# Standup\n",
+			heading: "Working on Today",
+			insert:  "\n* Goal\n",
+			want:    "# Standup\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := insertIntoStandupSection(tt.content, tt.heading, tt.insert, tt.create)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("insertIntoStandupSection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("insertIntoStandupSection() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPopulateStandupWithWork_Twice(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	journalContent := "# Daily Log\n\n## Goals of the Day\n\n- [x] Write docs\n- [ ] Plan sprint\n\n# Work Completed\n\n- Implemented feature X\n"
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-20.md"), []byte(journalContent), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+	todayContent := "# Daily Log\n\n## Goals of the Day\n\n- [ ] Review PRs\n"
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-21.md"), []byte(todayContent), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	standupDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	standupPath := filepath.Join(standupDir, "2025-01-21.md")
+	standupContent := "## Worked on yesterday\n\n## Working on Today\n\n## Notes\n"
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Journal.WorkDoneSections = []string{"Work Completed"}
+	cfg.Standup.Dir = standupDir
+	cfg.Standup.WorkDoneSection = "Worked on yesterday"
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	for i := 0; i < 2; i++ {
+		if err := populateStandupWithWork(standupDate, standupPath); err != nil {
+			t.Fatalf("populate %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	updated, err := os.ReadFile(standupPath)
+	if err != nil {
+		t.Fatalf("failed to read standup: %v", err)
+	}
+
+	want := "## Worked on yesterday\n\n- Write docs\n- Implemented feature X\n\n## Working on Today\n\n- Review PRs\n\n## Notes\n"
+	if string(updated) != want {
+		t.Errorf("expected standup without duplicates:\n%q\ngot:\n%q", want, updated)
+	}
+}
+
+func TestPopulateStandupWithWork_BulletStyle(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	prevContent := "# Daily Log\n\n## Goals of the Day\n\n- [x] Write docs\n"
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-20.md"), []byte(prevContent), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+	todayContent := "# Daily Log\n\n## Goals of the Day\n\n- [ ] Review PRs\n"
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-21.md"), []byte(todayContent), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	standupPath := filepath.Join(tempDir, "2025-01-21.md")
+	standupContent := "## Worked on yesterday\n\n## Working on Today\n\n## Notes\n"
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = tempDir
+	cfg.GitHub = config.GitHubConfig{Enabled: true, Org: "acme"}
+	cfg.BulletStyle = "*"
+
+	oldClient := newPRClient
+	newPRClient = func(ghCfg config.GitHubConfig) (prClient, error) {
+		return &stubPRClient{
+			created: []github.PullRequest{{Number: 1, Title: "Add feature", URL: "https://github.com/acme/app/pull/1", Repo: "acme/app"}},
+			open:    []github.PullRequest{{Number: 2, Title: "Fix bug", URL: "https://github.com/acme/app/pull/2", Repo: "acme/app"}},
+		}, nil
+	}
+	defer func() { newPRClient = oldClient }()
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	standupDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	if err := populateStandupWithWork(standupDate, standupPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := os.ReadFile(standupPath)
+	if err != nil {
+		t.Fatalf("failed to read standup: %v", err)
+	}
+
+	want := "## Worked on yesterday\n\n" +
+		"* Write docs\n" +
+		"* [app#1](https://github.com/acme/app/pull/1): Add feature\n\n" +
+		"## Working on Today\n\n" +
+		"* Review PRs\n" +
+		"* needs-review: [app#2](https://github.com/acme/app/pull/2): Fix bug\n\n" +
+		"## Notes\n"
+	if string(updated) != want {
+		t.Errorf("expected every generated item to use *:\n%q\ngot:\n%q", want, updated)
+	}
+}
+
 func TestPopulateStandupWithWork_WithCompletedGoals(t *testing.T) {
 	tempDir := t.TempDir()
 	journalDir := filepath.Join(tempDir, "journal")
@@ -501,6 +822,91 @@ This is my standup
 	}
 }
 
+func TestPopulateStandupWithWork_SkipText(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+	if err := os.MkdirAll(standupDir, 0755); err != nil {
+		t.Fatalf("failed to create standup dir: %v", err)
+	}
+
+	journalContent := `---
+title: Previous Journal
+---
+
+## Goals of the Day
+
+* [x] Deploy to staging
+* [x] TODO review backlog
+
+# Work Completed
+
+* Fixed bug Y
+* Wrote standup notes
+* Updated documentation
+`
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-20.md"), []byte(journalContent), 0644); err != nil {
+		t.Fatalf("failed to create journal: %v", err)
+	}
+
+	standupDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	standupPath := filepath.Join(standupDir, "2025-01-21.md")
+	standupContent := `---
+title: Standup
+---
+
+## Worked on yesterday
+
+## Working on Today
+`
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	cfg = &config.Config{
+		Journal: config.JournalConfig{
+			Dir:              journalDir,
+			WorkDoneSections: []string{"Work Completed"},
+			SkipText:         []string{"standup notes", "todo review"},
+		},
+		Standup: config.StandupConfig{
+			Dir:             standupDir,
+			WorkDoneSection: "Worked on yesterday",
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Suppress output for test
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	if err := populateStandupWithWork(standupDate, standupPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updatedContent, err := os.ReadFile(standupPath)
+	if err != nil {
+		t.Fatalf("failed to read updated standup: %v", err)
+	}
+	contentStr := string(updatedContent)
+
+	for _, kept := range []string{"Deploy to staging", "Fixed bug Y", "Updated documentation"} {
+		if !strings.Contains(contentStr, kept) {
+			t.Errorf("expected standup to contain %q, got:\n%s", kept, contentStr)
+		}
+	}
+	for _, skipped := range []string{"Wrote standup notes", "TODO review backlog"} {
+		if strings.Contains(contentStr, skipped) {
+			t.Errorf("expected standup to NOT contain skipped %q, got:\n%s", skipped, contentStr)
+		}
+	}
+}
+
 func TestPopulateStandupWithWork_WithTodayGoals(t *testing.T) {
 	tempDir := t.TempDir()
 	journalDir := filepath.Join(tempDir, "journal")
@@ -568,8 +974,9 @@ title: Standup
 			WorkDoneSections: []string{"Work Completed"},
 		},
 		Standup: config.StandupConfig{
-			Dir:             standupDir,
-			WorkDoneSection: "Worked on yesterday",
+			Dir:              standupDir,
+			WorkDoneSection:  "Worked on yesterday",
+			WorkTodaySection: "Working on Today",
 		},
 		SearchWindowDays: 30,
 	}
@@ -623,6 +1030,96 @@ title: Standup
 	}
 }
 
+func TestPopulateStandupWithWork_CustomTodaySection(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+	if err := os.MkdirAll(standupDir, 0755); err != nil {
+		t.Fatalf("failed to create standup dir: %v", err)
+	}
+
+	// Create today's journal with goals
+	standupDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	todayJournalPath := filepath.Join(journalDir, standupDate.Format(notes.DateFormat)+".md")
+	todayJournalContent := `---
+title: Today's Journal
+---
+
+## Goals of the Day
+
+* [ ] Review code changes
+`
+	if err := os.WriteFile(todayJournalPath, []byte(todayJournalContent), 0644); err != nil {
+		t.Fatalf("failed to create today's journal: %v", err)
+	}
+
+	// Create a standup that uses a custom heading for today's work
+	standupPath := filepath.Join(standupDir, standupDate.Format(notes.DateFormat)+".md")
+	standupContent := `---
+title: Standup
+---
+
+## Worked on yesterday
+
+## Plan
+
+## Notes
+`
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	cfg = &config.Config{
+		Journal: config.JournalConfig{
+			Dir:              journalDir,
+			WorkDoneSections: []string{"Work Completed"},
+		},
+		Standup: config.StandupConfig{
+			Dir:              standupDir,
+			WorkDoneSection:  "Worked on yesterday",
+			WorkTodaySection: "Plan",
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Suppress output for test
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	if err := populateStandupWithWork(standupDate, standupPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updatedContent, err := os.ReadFile(standupPath)
+	if err != nil {
+		t.Fatalf("failed to read updated standup: %v", err)
+	}
+	contentStr := string(updatedContent)
+
+	// Goals should land under the configured heading
+	planIdx := strings.Index(contentStr, "## Plan")
+	notesIdx := strings.Index(contentStr, "## Notes")
+	reviewIdx := strings.Index(contentStr, "Review code changes")
+	if planIdx == -1 || notesIdx == -1 || reviewIdx == -1 {
+		t.Fatalf("missing sections or content: plan=%d, notes=%d, review=%d\n%s",
+			planIdx, notesIdx, reviewIdx, contentStr)
+	}
+	if reviewIdx < planIdx || reviewIdx > notesIdx {
+		t.Errorf("today's goals not in configured section: plan=%d, notes=%d, review=%d",
+			planIdx, notesIdx, reviewIdx)
+	}
+
+	// The default heading must not be added
+	if strings.Contains(contentStr, "Working on Today") {
+		t.Errorf("expected no default 'Working on Today' section, got:\n%s", contentStr)
+	}
+}
+
 func TestPopulateStandupWithWork_NoPreviousJournal(t *testing.T) {
 	tempDir := t.TempDir()
 	journalDir := filepath.Join(tempDir, "journal")
@@ -674,3 +1171,550 @@ func TestPopulateStandupWithWork_NoPreviousJournal(t *testing.T) {
 		t.Errorf("standup file was unexpectedly modified: got %q, want %q", string(content), standupContent)
 	}
 }
+
+func TestPopulateJournalGoals_EnsureEmptyGoalsSection(t *testing.T) {
+	tests := []struct {
+		name        string
+		ensure      *bool
+		wantSection bool
+	}{
+		{name: "default adds empty section", ensure: nil, wantSection: true},
+		{name: "enabled adds empty section", ensure: boolPtr(true), wantSection: true},
+		{name: "disabled omits empty section", ensure: boolPtr(false), wantSection: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			journalDir := filepath.Join(tempDir, "journal")
+			if err := os.MkdirAll(journalDir, 0755); err != nil {
+				t.Fatalf("failed to create journal dir: %v", err)
+			}
+
+			// Previous journal has only completed goals, so nothing to copy
+			prevContent := `# Daily Log 2025-01-20
+
+## Goals of the Day
+
+* [x] Finished task
+`
+			if err := os.WriteFile(filepath.Join(journalDir, "2025-01-20.md"), []byte(prevContent), 0644); err != nil {
+				t.Fatalf("failed to write previous journal: %v", err)
+			}
+
+			currentPath := filepath.Join(journalDir, "2025-01-21.md")
+			if err := os.WriteFile(currentPath, []byte("# Daily Log 2025-01-21\n"), 0644); err != nil {
+				t.Fatalf("failed to write current journal: %v", err)
+			}
+
+			cfg = &config.Config{
+				Journal: config.JournalConfig{
+					Dir:                     journalDir,
+					WorkDoneSections:        []string{"work completed"},
+					EnsureEmptyGoalsSection: tt.ensure,
+				},
+				SearchWindowDays: 30,
+			}
+
+			// Suppress output for test
+			oldStdout := os.Stdout
+			os.Stdout, _ = os.Open(os.DevNull)
+			defer func() { os.Stdout = oldStdout }()
+
+			currentDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+			if err := populateJournalGoals(currentDate, currentPath); err != nil {
+				t.Fatalf("populateJournalGoals failed: %v", err)
+			}
+
+			content, err := os.ReadFile(currentPath)
+			if err != nil {
+				t.Fatalf("failed to read journal: %v", err)
+			}
+
+			hasSection := strings.Contains(string(content), "## Goals of the Day")
+			if hasSection != tt.wantSection {
+				t.Errorf("Goals of the Day present = %v, want %v; content:\n%s", hasSection, tt.wantSection, content)
+			}
+		})
+	}
+}
+
+func TestPopulateJournalGoals_RenamedSections(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	prevContent := `# Daily Log 2025-01-20
+
+## This Week
+
+* Ship auth service
+
+## Today's Goals
+
+* [x] Finished task
+* [ ] Unfinished task
+`
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-20.md"), []byte(prevContent), 0644); err != nil {
+		t.Fatalf("failed to write previous journal: %v", err)
+	}
+
+	currentPath := filepath.Join(journalDir, "2025-01-21.md")
+	currentContent := `# Daily Log 2025-01-21
+
+## Today's Goals
+
+## Work Completed
+`
+	if err := os.WriteFile(currentPath, []byte(currentContent), 0644); err != nil {
+		t.Fatalf("failed to write current journal: %v", err)
+	}
+
+	cfg = &config.Config{
+		Journal: config.JournalConfig{
+			Dir:              journalDir,
+			WorkDoneSections: []string{"work completed"},
+			DayGoalsSection:  "Today's Goals",
+			WeekGoalsSection: "This Week",
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Suppress output for test
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	currentDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	if err := populateJournalGoals(currentDate, currentPath); err != nil {
+		t.Fatalf("populateJournalGoals failed: %v", err)
+	}
+
+	content, err := os.ReadFile(currentPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+
+	expected := `# Daily Log 2025-01-21
+
+## This Week
+
+* Ship auth service
+
+## Today's Goals
+
+- [ ] Unfinished task
+
+## Work Completed
+`
+	if string(content) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, content)
+	}
+}
+
+func TestPopulateJournalGoals_NestedGoals(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	prevContent := `# Daily Log 2025-01-20
+
+## Goals of the Day
+
+- [ ] Ship auth service
+    - [x] Write handler
+    - [ ] Add tests
+        - [ ] Integration tests
+- [x] Finished task
+    - [ ] Leftover subtask
+`
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-20.md"), []byte(prevContent), 0644); err != nil {
+		t.Fatalf("failed to write previous journal: %v", err)
+	}
+
+	currentPath := filepath.Join(journalDir, "2025-01-21.md")
+	if err := os.WriteFile(currentPath, []byte("# Daily Log 2025-01-21\n"), 0644); err != nil {
+		t.Fatalf("failed to write current journal: %v", err)
+	}
+
+	cfg = &config.Config{
+		Journal: config.JournalConfig{
+			Dir:              journalDir,
+			WorkDoneSections: []string{"work completed"},
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Suppress output for test
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	currentDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	if err := populateJournalGoals(currentDate, currentPath); err != nil {
+		t.Fatalf("populateJournalGoals failed: %v", err)
+	}
+
+	content, err := os.ReadFile(currentPath)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+
+	expected := "- [ ] Ship auth service\n  - [ ] Add tests\n    - [ ] Integration tests\n  - [ ] Leftover subtask\n"
+	if !strings.Contains(string(content), expected) {
+		t.Errorf("expected nested goals:\n%s\ngot:\n%s", expected, content)
+	}
+}
+
+// boolPtr returns a pointer to b
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// stubPRClient is a prClient returning canned results
+type stubPRClient struct {
+	created    []github.PullRequest
+	createdErr error
+	merged     []github.PullRequest
+	open       []github.PullRequest
+	openErr    error
+}
+
+func (s *stubPRClient) GetPRsCreatedYesterday(date time.Time) ([]github.PullRequest, error) {
+	return s.created, s.createdErr
+}
+
+func (s *stubPRClient) GetPRsMergedYesterday(date time.Time) ([]github.PullRequest, error) {
+	return s.merged, nil
+}
+
+func (s *stubPRClient) GetPRsOpenAndUnreviewed(date time.Time) ([]github.PullRequest, error) {
+	return s.open, s.openErr
+}
+
+func TestPopulateStandupWithWork_GitHub(t *testing.T) {
+	standupContent := `# Standup
+
+## Worked on yesterday
+
+## Working on Today
+`
+	tests := []struct {
+		name        string
+		client      *stubPRClient
+		noGitHub    bool
+		wantContain []string
+		wantMissing []string
+	}{
+		{
+			name: "adds created and open PRs",
+			client: &stubPRClient{
+				created: []github.PullRequest{{Number: 1, Title: "Add feature", URL: "https://github.com/acme/app/pull/1", Repo: "acme/app"}},
+				open:    []github.PullRequest{{Number: 2, Title: "Fix bug", URL: "https://github.com/acme/app/pull/2", Repo: "acme/app"}},
+			},
+			wantContain: []string{
+				"- [app#1](https://github.com/acme/app/pull/1): Add feature",
+				"- needs-review: [app#2](https://github.com/acme/app/pull/2): Fix bug",
+			},
+		},
+		{
+			name: "fetch failure only warns",
+			client: &stubPRClient{
+				createdErr: errors.New("gh search failed"),
+				open:       []github.PullRequest{{Number: 2, Title: "Fix bug", URL: "https://github.com/acme/app/pull/2", Repo: "acme/app"}},
+			},
+			wantContain: []string{"needs-review: [app#2]"},
+		},
+		{
+			name: "no-github skips client",
+			client: &stubPRClient{
+				created: []github.PullRequest{{Number: 1, Title: "Add feature", URL: "https://github.com/acme/app/pull/1", Repo: "acme/app"}},
+			},
+			noGitHub:    true,
+			wantMissing: []string{"app#1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			journalDir := filepath.Join(tempDir, "journal")
+			if err := os.MkdirAll(journalDir, 0755); err != nil {
+				t.Fatalf("failed to create journal dir: %v", err)
+			}
+			standupPath := filepath.Join(tempDir, "2025-01-21.md")
+			if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+				t.Fatalf("failed to create standup: %v", err)
+			}
+
+			cfg = &config.Config{
+				Journal: config.JournalConfig{
+					Dir:              journalDir,
+					WorkDoneSections: []string{"Work Completed"},
+				},
+				Standup: config.StandupConfig{
+					Dir:             tempDir,
+					WorkDoneSection: "Worked on yesterday",
+				},
+				GitHub:           config.GitHubConfig{Enabled: true, Org: "acme"},
+				SearchWindowDays: 30,
+			}
+
+			oldClient := newPRClient
+			newPRClient = func(ghCfg config.GitHubConfig) (prClient, error) { return tt.client, nil }
+			defer func() { newPRClient = oldClient }()
+
+			skipGitHub = tt.noGitHub
+			defer func() { skipGitHub = false }()
+
+			// Suppress output for test
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			os.Stdout, _ = os.Open(os.DevNull)
+			os.Stderr, _ = os.Open(os.DevNull)
+			defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+			standupDate := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+			if err := populateStandupWithWork(standupDate, standupPath); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			content, err := os.ReadFile(standupPath)
+			if err != nil {
+				t.Fatalf("failed to read standup: %v", err)
+			}
+			for _, want := range tt.wantContain {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("expected standup to contain %q, got:\n%s", want, content)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(string(content), missing) {
+					t.Errorf("expected standup not to contain %q, got:\n%s", missing, content)
+				}
+			}
+		})
+	}
+}
+
+func TestAddCompanyTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     time.Time
+		days     []string
+		tags     []string
+		wantTags []string
+	}{
+		{
+			name:     "scheduled day",
+			date:     time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC), // Tuesday
+			days:     []string{"Mon", "Tue", "Thu"},
+			wantTags: []string{"company:acme"},
+		},
+		{
+			name: "unscheduled weekday",
+			date: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), // Wednesday
+			days: []string{"Mon", "Tue", "Thu"},
+		},
+		{
+			name:     "weekend with no schedule",
+			date:     time.Date(2025, 1, 18, 0, 0, 0, 0, time.UTC), // Saturday
+			wantTags: []string{"company:acme"},
+		},
+		{
+			name:     "multiple companies",
+			date:     time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC), // Tuesday
+			tags:     []string{"acme", "globex"},
+			wantTags: []string{"company:acme", "company:globex"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notePath := filepath.Join(t.TempDir(), "note.md")
+			if err := os.WriteFile(notePath, []byte("---\ntags: [\"daily\"]\n---\n# Note\n"), 0644); err != nil {
+				t.Fatalf("failed to write note: %v", err)
+			}
+
+			cfg = config.DefaultConfig()
+			cfg.CompanyTagDays = tt.days
+			cfg.CompanyTags = tt.tags
+
+			// Suppress output for test
+			oldStdout := os.Stdout
+			os.Stdout, _ = os.Open(os.DevNull)
+			defer func() { os.Stdout = oldStdout }()
+
+			addCompanyTag(notePath, tt.date)
+
+			content, err := os.ReadFile(notePath)
+			if err != nil {
+				t.Fatalf("failed to read note: %v", err)
+			}
+			want := `tags: ["daily"]`
+			if len(tt.wantTags) > 0 {
+				want = `tags: ["daily", "` + strings.Join(tt.wantTags, `", "`) + `"]`
+			}
+			if !strings.Contains(string(content), want) {
+				t.Errorf("expected %s, got:\n%s", want, content)
+			}
+		})
+	}
+}
+
+func TestExpandCreatePlaceholders(t *testing.T) {
+	journalDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-16.md"), []byte("# Log\n"), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+
+	date := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{command: "new {date}", want: "new 2025-01-20"},
+		{command: "{year}/{month}/{day}", want: "2025/01/20"},
+		{command: "--weekday {weekday}", want: "--weekday Monday"},
+		{command: "--week {isoweek}", want: "--week 04"},
+		{command: "--prev {prev_date}", want: "--prev 2025-01-16"},
+		{command: "touch {date}.md {date}.bak", want: "touch 2025-01-20.md 2025-01-20.bak"},
+		{command: "{unknown}", want: "{unknown}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			got := expandCreatePlaceholders(tt.command, date, notes.NoteTypeJournal, []string{journalDir})
+			if got != tt.want {
+				t.Errorf("expandCreatePlaceholders(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+
+	// No previous note leaves {prev_date} empty
+	got := expandCreatePlaceholders("--prev={prev_date}", date, notes.NoteTypeJournal, []string{t.TempDir()})
+	if got != "--prev=" {
+		t.Errorf("expandCreatePlaceholders() without a previous note = %q, want %q", got, "--prev=")
+	}
+}
+
+func TestGenerateJournal_CreateTimeout(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg = &config.Config{
+		Journal: config.JournalConfig{
+			Dir:              tempDir,
+			WorkDoneSections: []string{"work completed"},
+			Create:           config.CreateCommand{Cmd: "sleep 5", TimeoutSeconds: 1},
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Suppress output for test
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	os.Stdout, _ = os.Open(os.DevNull)
+	os.Stderr, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+	start := time.Now()
+	err := runGenerateJournal(nil, []string{"2025-01-20"})
+	if err == nil || !strings.Contains(err.Error(), "create command failed") {
+		t.Fatalf("expected create command failure, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 4*time.Second {
+		t.Errorf("create command was not stopped by its timeout, took %v", elapsed)
+	}
+}
+
+func TestRunPostCreate(t *testing.T) {
+	cfg = config.DefaultConfig()
+	dir := t.TempDir()
+	notePath := filepath.Join(dir, "2025-01-20.md")
+	outPath := filepath.Join(dir, "hook.out")
+	date := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	// Suppress output for test
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	tests := []struct {
+		name    string
+		hook    config.CreateCommand
+		want    string
+		wantErr string
+	}{
+		{name: "no hook"},
+		{
+			name: "placeholders",
+			hook: config.CreateCommand{Cmd: "echo '{date} {path} {weekday}' > " + outPath},
+			want: "2025-01-20 " + notePath + " Monday\n",
+		},
+		{
+			name:    "failure",
+			hook:    config.CreateCommand{Cmd: "echo 'not a repo' >&2; exit 3"},
+			wantErr: "post-create command failed: exit status 3: not a repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(outPath)
+
+			err := runPostCreate(tt.hook, notePath, date, notes.NoteTypeJournal, []string{dir})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("runPostCreate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runPostCreate() error = %v", err)
+			}
+
+			got, err := os.ReadFile(outPath)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("expected no hook output, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read hook output: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("hook output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateJournal_PostCreateFailureWarns(t *testing.T) {
+	tempDir := t.TempDir()
+	targetFile := filepath.Join(tempDir, "2025-01-20.md")
+	cfg = &config.Config{
+		Journal: config.JournalConfig{
+			Dir:              tempDir,
+			WorkDoneSections: []string{"work completed"},
+			Create:           config.CreateCommand{Cmd: "echo '# Test Journal' > " + targetFile},
+			PostCreate:       config.CreateCommand{Cmd: "exit 1"},
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Suppress output for test
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	os.Stdout, _ = os.Open(os.DevNull)
+	os.Stderr, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+	if err := runGenerateJournal(nil, []string{"2025-01-20"}); err != nil {
+		t.Fatalf("expected a failed post-create command not to fail generate-journal, got: %v", err)
+	}
+	if _, err := os.Stat(targetFile); err != nil {
+		t.Errorf("expected journal to be kept: %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	journalGoalsPending bool
+	goalDoneUndo        bool
+)
+
+var journalGoalsCmd = &cobra.Command{
+	Use:   "journal-goals [date]",
+	Short: "Print the goals of the day from a journal entry",
+	Long: `Print the daily goals (journal.day_goals_section, default "Goals of the Day")
+from the journal entry for the specified date, keeping each goal's checkbox
+state ([ ] or [x]).
+
+If no date is provided, uses today's date.
+Date format: YYYY-MM-DD
+
+If the exact date is not found, searches backwards within the configured
+search window (default: 30 days) to find the most recent entry.
+
+Use --pending to only print unfinished goals: unchecked items and plain
+bullets.
+
+Examples:
+  za journal-goals                    # Today's goals
+  za journal-goals 2025-01-15        # Goals for a specific date
+  za journal-goals --pending         # Only goals still to do`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runJournalGoals,
+}
+
+var goalDoneCmd = &cobra.Command{
+	Use:   "goal-done <date> <goal text>",
+	Short: "Check off a goal of the day in a journal entry",
+	Long: `Mark a goal in the daily goals section of the journal entry for the given
+date as done ([x]), rewriting only that goal's line.
+
+The goal is matched by its text, ignoring case and extra whitespace. A plain
+bullet goal gains a checkbox. Use --undo to uncheck a goal instead.
+
+Date format: YYYY-MM-DD. The journal must exist for exactly that date.
+
+Examples:
+  za goal-done 2025-01-15 "Write docs"
+  za goal-done 2025-01-15 "Write docs" --undo`,
+	Args: cobra.ExactArgs(2),
+	RunE: runGoalDone,
+}
+
+func init() {
+	rootCmd.AddCommand(journalGoalsCmd)
+	journalGoalsCmd.Flags().BoolVar(&journalGoalsPending, "pending", false, "Only print unfinished goals")
+
+	rootCmd.AddCommand(goalDoneCmd)
+	goalDoneCmd.Flags().BoolVar(&goalDoneUndo, "undo", false, "Uncheck the goal instead")
+}
+
+func runJournalGoals(cmd *cobra.Command, args []string) error {
+	// Parse date argument
+	targetDate, err := parseDateArg(args)
+	if err != nil {
+		return err
+	}
+
+	// Get journal directories
+	journalDirs, err := cfg.JournalDirs()
+	if err != nil {
+		return fmt.Errorf("failed to get journal directory: %w", err)
+	}
+
+	// Find journal file
+	journalPath, err := notes.FindNoteByDateMulti(
+		targetDate,
+		notes.NoteTypeJournal,
+		journalDirs,
+		cfg.SearchWindowDays,
+		finderOptions(notes.NoteTypeJournal)...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to find journal entry: %w", err)
+	}
+
+	// Parse journal file
+	parser := markdown.NewParser()
+	doc, err := parser.ParseFile(journalPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse journal: %w", err)
+	}
+
+	goalsSection := doc.FindSectionByHeading(cfg.Journal.DayGoalsHeading())
+	if goalsSection == nil {
+		fmt.Fprintf(os.Stderr, "No %s section found in %s\n", cfg.Journal.DayGoalsHeading(), journalPath)
+		return nil
+	}
+
+	items := markdown.ParseGoalItems(goalsSection.Content)
+	if journalGoalsPending {
+		items = markdown.FilterUnfinishedGoals(items)
+	}
+
+	if len(items) == 0 {
+		fmt.Fprintf(os.Stderr, "No goals found in %s\n", journalPath)
+		return nil
+	}
+
+	fmt.Println(markdown.FormatGoalItems(items, markdown.WithBullet(cfg.Bullet())))
+
+	return nil
+}
+
+func runGoalDone(cmd *cobra.Command, args []string) error {
+	targetDate, err := parseDateArg(args[:1])
+	if err != nil {
+		return err
+	}
+	goalText := args[1]
+
+	journalDirs, err := cfg.JournalDirs()
+	if err != nil {
+		return fmt.Errorf("failed to get journal directory: %w", err)
+	}
+
+	journalPath, err := notes.FindNoteByDateMulti(
+		targetDate,
+		notes.NoteTypeJournal,
+		journalDirs,
+		cfg.SearchWindowDays,
+		finderOptions(notes.NoteTypeJournal)...,
+	)
+	if err != nil {
+		return fmt.Errorf("no journal found for %s: %w", targetDate.Format(notes.DateFormat), err)
+	}
+
+	// Only ever edit the journal for the requested day
+	foundDate, err := notes.ParseDateFromFilename(journalPath, finderOptions(notes.NoteTypeJournal)...)
+	if err != nil {
+		return fmt.Errorf("failed to parse date from journal filename: %w", err)
+	}
+	targetY, targetM, targetD := targetDate.Date()
+	foundY, foundM, foundD := foundDate.Date()
+	if targetY != foundY || targetM != foundM || targetD != foundD {
+		return fmt.Errorf("no journal found for exact date %s (found %s)",
+			targetDate.Format(notes.DateFormat), foundDate.Format(notes.DateFormat))
+	}
+
+	content, err := os.ReadFile(journalPath)
+	if err != nil {
+		return fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	checked := !goalDoneUndo
+	newContent, changed, err := setGoalInSection(string(util.NormalizeNewlines(content)), cfg.Journal.DayGoalsHeading(), goalText, checked)
+	if err != nil {
+		return fmt.Errorf("%s: %w", journalPath, err)
+	}
+
+	state := "done"
+	if !checked {
+		state = "not done"
+	}
+	if !changed {
+		fmt.Printf("Goal %q is already marked %s\n", goalText, state)
+		return nil
+	}
+
+	if err := util.RewriteFile(journalPath, []byte(newContent)); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	fmt.Printf("✓ Marked %q %s in %s\n", goalText, state, journalPath)
+	return nil
+}
+
+// setGoalInSection checks or unchecks the first goal matching goalText in the
+// section with the given heading, leaving every other line untouched. It
+// reports whether the goal's line changed.
+func setGoalInSection(fileContent, sectionHeading, goalText string, checked bool) (string, bool, error) {
+	lines := strings.Split(fileContent, "\n")
+
+	start, end, found := sectionLineRange(lines, sectionHeading)
+	if !found {
+		return fileContent, false, fmt.Errorf("section '%s' not found", sectionHeading)
+	}
+
+	want := normalizeGoalText(goalText)
+	for i := start; i < end; i++ {
+		items := markdown.ParseGoalItems(lines[i])
+		if len(items) != 1 || normalizeGoalText(items[0].Text) != want {
+			continue
+		}
+
+		newLine, ok := markdown.SetGoalLineChecked(lines[i], checked)
+		if !ok || newLine == lines[i] {
+			return fileContent, false, nil
+		}
+		lines[i] = newLine
+		return strings.Join(lines, "\n"), true, nil
+	}
+
+	return fileContent, false, fmt.Errorf("goal %q not found in section '%s'", goalText, sectionHeading)
+}
+
+// sectionLineRange returns the range of lines [start, end) holding the
+// content of the section with the given heading (case-insensitive), up to the
+// next heading of the same or a higher level
+func sectionLineRange(lines []string, sectionHeading string) (start, end int, found bool) {
+	sectionLevel := 0
+	for i, line := range lines {
+		level, text := headingLine(line)
+		if sectionLevel == 0 {
+			if level > 0 && strings.EqualFold(text, strings.TrimSpace(sectionHeading)) {
+				sectionLevel = level
+				start = i + 1
+			}
+			continue
+		}
+		if level > 0 && level <= sectionLevel {
+			return start, i, true
+		}
+	}
+
+	if sectionLevel == 0 {
+		return 0, 0, false
+	}
+	return start, len(lines), true
+}
+
+// headingLine returns the level and text of an ATX heading line, or a level
+// of 0 if the line isn't a heading
+func headingLine(line string) (int, string) {
+	trimmed := strings.TrimSpace(line)
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(trimmed) && trimmed[level] != ' ' && trimmed[level] != '\t') {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(trimmed[level:])
+}
+
+// normalizeGoalText lowercases goal text and collapses runs of whitespace, so
+// goals can be matched without retyping them exactly
+func normalizeGoalText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
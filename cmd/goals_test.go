@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestJournalGoals(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	content := `# Daily Log 2025-01-15
+
+## Goals of the Day
+
+* [x] Fix CI
+* [ ] Write docs
+* Plan Q2
+
+## Work Completed
+
+* [ ] Not a goal
+`
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-15.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		pending bool
+		want    string
+	}{
+		{
+			name: "all goals",
+			want: "- [x] Fix CI\n- [ ] Write docs\n- Plan Q2\n",
+		},
+		{
+			name:    "pending only",
+			pending: true,
+			want:    "- [ ] Write docs\n- Plan Q2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = config.DefaultConfig()
+			cfg.Journal.Dir = journalDir
+
+			journalGoalsPending = tt.pending
+			defer func() { journalGoalsPending = false }()
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runJournalGoals(nil, []string{"2025-01-15"})
+
+			w.Close()
+			os.Stdout = oldStdout
+			outputBytes, _ := io.ReadAll(r)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(outputBytes) != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, outputBytes)
+			}
+		})
+	}
+}
+
+func TestSetGoalInSection(t *testing.T) {
+	content := `# Daily Log 2025-01-15
+
+## Goals of the Day
+
+- [ ] Write docs
+*  [x]  Fix   CI
+- Plan Q2
+  - [ ] Nested task
+
+### Notes
+
+- [ ] Write docs
+
+## Work Completed
+
+- [ ] Not a goal
+`
+
+	tests := []struct {
+		name        string
+		goal        string
+		checked     bool
+		wantLine    string
+		wantChanged bool
+		wantErr     bool
+	}{
+		{name: "check goal", goal: "Write docs", checked: true, wantLine: "- [x] Write docs", wantChanged: true},
+		{name: "normalized match", goal: "  write   DOCS ", checked: true, wantLine: "- [x] Write docs", wantChanged: true},
+		{name: "uncheck goal", goal: "fix ci", checked: false, wantLine: "*  [ ]  Fix   CI", wantChanged: true},
+		{name: "plain bullet gains checkbox", goal: "Plan Q2", checked: true, wantLine: "- [x] Plan Q2", wantChanged: true},
+		{name: "nested goal", goal: "Nested task", checked: true, wantLine: "  - [x] Nested task", wantChanged: true},
+		{name: "already done", goal: "Fix CI", checked: true},
+		{name: "goal in other section", goal: "Not a goal", checked: true, wantErr: true},
+		{name: "unknown goal", goal: "Nope", checked: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed, err := setGoalInSection(content, "Goals of the Day", tt.goal, tt.checked)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !changed {
+				if got != content {
+					t.Errorf("expected content to be unchanged, got:\n%s", got)
+				}
+				return
+			}
+
+			// Exactly one line differs, and it's the goal's line
+			oldLines := strings.Split(content, "\n")
+			newLines := strings.Split(got, "\n")
+			if len(oldLines) != len(newLines) {
+				t.Fatalf("expected %d lines, got %d", len(oldLines), len(newLines))
+			}
+			var diffs []string
+			for i := range oldLines {
+				if oldLines[i] != newLines[i] {
+					diffs = append(diffs, newLines[i])
+				}
+			}
+			if len(diffs) != 1 || diffs[0] != tt.wantLine {
+				t.Errorf("expected only %q to change, got changed lines %q", tt.wantLine, diffs)
+			}
+		})
+	}
+}
+
+func TestSetGoalInSection_MissingSection(t *testing.T) {
+	_, _, err := setGoalInSection("# Daily Log\n\n- [ ] Task\n", "Goals of the Day", "Task", true)
+	if err == nil || !strings.Contains(err.Error(), "section 'Goals of the Day' not found") {
+		t.Errorf("expected section not found error, got %v", err)
+	}
+}
+
+func TestRunGoalDone(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	journalPath := filepath.Join(journalDir, "2025-01-15.md")
+	content := "# Daily Log 2025-01-15\n\n## Goals of the Day\n\n- [ ] Write docs\n"
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+
+	// Suppress output for test
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	if err := runGoalDone(nil, []string{"2025-01-15", "write docs"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := os.ReadFile(journalPath)
+	if !strings.Contains(string(got), "- [x] Write docs\n") {
+		t.Errorf("expected goal to be checked, got:\n%s", got)
+	}
+
+	goalDoneUndo = true
+	defer func() { goalDoneUndo = false }()
+	if err := runGoalDone(nil, []string{"2025-01-15", "write docs"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ = os.ReadFile(journalPath)
+	if string(got) != content {
+		t.Errorf("expected --undo to restore the original content, got:\n%s", got)
+	}
+
+	// A later date must not edit an earlier journal
+	if err := runGoalDone(nil, []string{"2025-01-16", "write docs"}); err == nil {
+		t.Error("expected error for a date without a journal")
+	}
+}
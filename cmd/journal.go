@@ -6,58 +6,72 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rdark/za/internal/config"
 	"github.com/rdark/za/internal/markdown"
 	"github.com/rdark/za/internal/notes"
 	"github.com/spf13/cobra"
 )
 
+// journalSectionNames are the --section headings, which replace
+// journal.work_done_sections for one run
+var journalSectionNames []string
+
 var journalWorkDoneCmd = &cobra.Command{
 	Use:   "journal-work-done [date]",
 	Short: "Extract work completed from journal entries",
 	Long: `Extract work completed sections from a journal entry for the specified date.
 
 If no date is provided, uses today's date.
-Date format: YYYY-MM-DD
+Date format: YYYY-MM-DD, or YYYY-MM to extract from every journal in that month.
 
 If the exact date is not found, searches backwards within the configured
-search window (default: 30 days) to find the most recent entry.
+search window (default: 30 days) to find the most recent entry. With
+skip_draft_notes set, notes marked as drafts are skipped the same way.
 
 The command extracts sections matching the configured work_done_sections
-(default: "Work Completed", "Worked On").`,
+(default: "Work Completed", "Worked On"). Sections are output in document
+order unless journal.work_done_order is set to "config". Subsections of a
+work done section (e.g. "### Project A" under "## Work Completed") are included.
+Use --section (repeatable) to extract other sections instead, e.g.
+--section Meetings.
+
+Output is normalized: bullets use "-", blank lines are collapsed and trailing
+whitespace is trimmed. Use --json to print [{"heading": ..., "content": ...}]
+instead; a month of notes also includes each section's "date".`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runJournalWorkDone,
 }
 
 func init() {
 	rootCmd.AddCommand(journalWorkDoneCmd)
+	journalWorkDoneCmd.Flags().BoolVar(&workDoneJSON, "json", false, "Print sections as JSON")
+	journalWorkDoneCmd.Flags().StringArrayVar(&journalSectionNames, "section", nil, "Extract this section instead of the configured work done sections (repeatable)")
 }
 
 func runJournalWorkDone(cmd *cobra.Command, args []string) error {
 	// Parse date argument
-	var targetDate time.Time
-	var err error
-
-	if len(args) > 0 {
-		targetDate, err = time.Parse(notes.DateFormat, args[0])
-		if err != nil {
-			return fmt.Errorf("invalid date format (expected YYYY-MM-DD): %w", err)
-		}
-	} else {
-		targetDate = time.Now()
+	start, end, isMonth, err := parseDateRangeArg(args)
+	if err != nil {
+		return err
 	}
 
-	// Get journal directory
-	journalDir, err := cfg.JournalDir()
+	// Get journal directories
+	journalDirs, err := cfg.JournalDirs()
 	if err != nil {
 		return fmt.Errorf("failed to get journal directory: %w", err)
 	}
 
+	if isMonth {
+		return journalWorkDoneForRange(start, end, journalDirs)
+	}
+
 	// Find journal file
-	journalPath, err := notes.FindNoteByDate(
-		targetDate,
+	journalPath, err := notes.FindNoteByDateMulti(
+		start,
 		notes.NoteTypeJournal,
-		journalDir,
+		journalDirs,
 		cfg.SearchWindowDays,
+		summaryFinderOptions(notes.NoteTypeJournal)...,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to find journal entry: %w", err)
@@ -70,21 +84,139 @@ func runJournalWorkDone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse journal: %w", err)
 	}
 
-	// Extract work done sections
-	sections := doc.FindSectionsByHeadings(cfg.Journal.WorkDoneSections)
+	// Extract work done sections, including any subsections (e.g. per project)
+	sections := findWorkDoneSections(doc, markdown.WithSubsections())
 
 	if len(sections) == 0 {
 		fmt.Fprintf(os.Stderr, "No work done sections found in %s\n", journalPath)
-		fmt.Fprintf(os.Stderr, "Looking for sections: %v\n", cfg.Journal.WorkDoneSections)
+		fmt.Fprintf(os.Stderr, "Looking for sections: %v\n", workDoneHeadings())
+		if workDoneJSON {
+			return printWorkDoneJSON(nil)
+		}
 		return nil
 	}
 
 	// Output the extracted sections
+	if workDoneJSON {
+		return printWorkDoneJSON(workDoneEntries(sections, ""))
+	}
+	printSections(sections, 1)
+
+	return nil
+}
+
+// journalWorkDoneForRange outputs the work done sections of every journal
+// between start and end (inclusive), grouped under a heading per date
+func journalWorkDoneForRange(start, end time.Time, journalDirs []string) error {
+	journalPaths, err := notes.FindNotesInRangeMulti(start, end, notes.NoteTypeJournal, journalDirs, summaryFinderOptions(notes.NoteTypeJournal)...)
+	if err != nil {
+		return fmt.Errorf("failed to find journal entries: %w", err)
+	}
+
+	if len(journalPaths) == 0 {
+		fmt.Fprintf(os.Stderr, "No journal entries found between %s and %s\n",
+			start.Format(notes.DateFormat), end.Format(notes.DateFormat))
+		if workDoneJSON {
+			return printWorkDoneJSON(nil)
+		}
+		return nil
+	}
+
+	var entries []workDoneEntry
+	parser := markdown.NewParser()
+	for _, journalPath := range journalPaths {
+		doc, err := parser.ParseFile(journalPath)
+		if err != nil {
+			return fmt.Errorf("failed to parse journal %s: %w", journalPath, err)
+		}
+
+		sections := findWorkDoneSections(doc, markdown.WithSubsections())
+		if len(sections) == 0 {
+			continue
+		}
+
+		date, err := notes.ParseDateFromFilename(journalPath, finderOptions(notes.NoteTypeJournal)...)
+		if err != nil {
+			return fmt.Errorf("failed to parse date from journal filename: %w", err)
+		}
+
+		if workDoneJSON {
+			entries = append(entries, workDoneEntries(sections, date.Format(notes.DateFormat))...)
+			continue
+		}
+
+		fmt.Printf("# %s\n\n", date.Format(notes.DateFormat))
+		printSections(sections, 2)
+	}
+
+	if workDoneJSON {
+		return printWorkDoneJSON(entries)
+	}
+	return nil
+}
+
+// printSections outputs sections as normalized markdown with headings at the
+// given level
+func printSections(sections []markdown.Section, level int) {
+	prefix := strings.Repeat("#", level)
 	for _, section := range sections {
-		fmt.Printf("# %s\n\n", section.Heading.Text)
-		fmt.Print(strings.TrimSpace(section.Content))
+		fmt.Printf("%s %s\n\n", prefix, section.Heading.Text)
+		fmt.Print(section.Render())
 		fmt.Printf("\n\n")
 	}
+}
 
-	return nil
+// findWorkDoneSections finds the work done sections (see workDoneHeadings)
+// in a journal, ordered according to journal.work_done_order, with
+// journal.skip_text lines removed
+func findWorkDoneSections(doc *markdown.Document, opts ...markdown.SectionOption) []markdown.Section {
+	headings := workDoneHeadings()
+	sections := doc.FindSectionsByHeadings(headings, opts...)
+	if cfg.Journal.WorkDoneOrder == config.WorkDoneOrderConfig {
+		sections = markdown.SortSectionsByHeadingOrder(sections, headings)
+	}
+	return skipTextInSections(sections, cfg.Journal.SkipText)
+}
+
+// workDoneHeadings returns the headings of the sections to extract: the
+// --section names if any were given, otherwise journal.work_done_sections
+func workDoneHeadings() []string {
+	if len(journalSectionNames) > 0 {
+		return journalSectionNames
+	}
+	return cfg.Journal.WorkDoneSections
+}
+
+// skipTextInSections removes every line of the sections' content that
+// matches skip text (see matchesSkipText)
+func skipTextInSections(sections []markdown.Section, patterns []string) []markdown.Section {
+	if len(patterns) == 0 {
+		return sections
+	}
+
+	filtered := make([]markdown.Section, len(sections))
+	for i, section := range sections {
+		var kept []string
+		for _, line := range strings.Split(section.Content, "\n") {
+			if !matchesSkipText(line, patterns) {
+				kept = append(kept, line)
+			}
+		}
+		section.Content = strings.TrimSpace(strings.Join(kept, "\n"))
+		filtered[i] = section
+	}
+	return filtered
+}
+
+// matchesSkipText reports whether text contains any of the skip_text
+// patterns, ignoring case. Blank patterns are ignored.
+func matchesSkipText(text string, patterns []string) bool {
+	text = strings.ToLower(text)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" && strings.Contains(text, pattern) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/markdown"
+)
+
+func TestFindWorkDoneSections_Order(t *testing.T) {
+	content := `# Daily Log
+
+# Worked On
+
+* Investigating flaky test
+
+# Work Completed
+
+* Shipped feature X
+`
+	parser := markdown.NewParser()
+	doc, err := parser.Parse("journal/2025-01-06.md", []byte(content))
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{config.WorkDoneOrderDocument, []string{"Worked On", "Work Completed"}},
+		{config.WorkDoneOrderConfig, []string{"Work Completed", "Worked On"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			cfg = config.DefaultConfig()
+			cfg.Journal.WorkDoneSections = []string{"work completed", "worked on"}
+			cfg.Journal.WorkDoneOrder = tt.order
+
+			sections := findWorkDoneSections(doc)
+			if len(sections) != len(tt.want) {
+				t.Fatalf("expected %d sections, got %d", len(tt.want), len(sections))
+			}
+			for i, want := range tt.want {
+				if sections[i].Heading.Text != want {
+					t.Errorf("section %d = %q, want %q", i, sections[i].Heading.Text, want)
+				}
+			}
+		})
+	}
+}
+
+func TestJournalWorkDone_Today(t *testing.T) {
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = "../testdata/journal"
+
+	oldNow := now
+	defer func() { now = oldNow }()
+
+	tests := []struct {
+		name    string
+		today   time.Time
+		want    string
+		notWant string
+	}{
+		{
+			name:    "note for today",
+			today:   time.Date(2025, 1, 7, 9, 30, 0, 0, time.Local),
+			want:    "Added refresh token rotation mechanism",
+			notWant: "Completed production deployment checklist",
+		},
+		{
+			name:    "weekend falls back to Friday",
+			today:   time.Date(2025, 1, 12, 18, 0, 0, 0, time.Local),
+			want:    "Completed production deployment checklist",
+			notWant: "Added refresh token rotation mechanism",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = func() time.Time { return tt.today }
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runJournalWorkDone(nil, nil)
+
+			w.Close()
+			os.Stdout = oldStdout
+			outputBytes, _ := io.ReadAll(r)
+			output := string(outputBytes)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, output)
+			}
+			if strings.Contains(output, tt.notWant) {
+				t.Errorf("output should not contain %q:\n%s", tt.notWant, output)
+			}
+		})
+	}
+}
+
+func TestJournalWorkDone_Section(t *testing.T) {
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = "../testdata/journal"
+
+	journalSectionNames = []string{"meetings"}
+	defer func() { journalSectionNames = nil }()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runJournalWorkDone(nil, []string{"2025-01-06"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+	output := string(outputBytes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(output, "# Meetings\n") {
+		t.Errorf("expected the Meetings section first, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Discussed authentication refactoring timeline") {
+		t.Errorf("expected meeting notes in output, got:\n%s", output)
+	}
+	if strings.Contains(output, "# Work Completed") {
+		t.Errorf("configured work done sections should be replaced, got:\n%s", output)
+	}
+}
+
+func TestJournalWorkDone_Month(t *testing.T) {
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = "../testdata/journal"
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runJournalWorkDone(nil, []string{"2025-01"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+	output := string(outputBytes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every January journal with work done sections is included
+	for _, date := range []string{"2025-01-06", "2025-01-07", "2025-01-08", "2025-01-10", "2025-01-13", "2025-01-14"} {
+		if !strings.Contains(output, "# "+date+"\n") {
+			t.Errorf("expected output to include %s, got:\n%s", date, output)
+		}
+	}
+
+	// Notes outside the month are excluded
+	for _, date := range []string{"2024-12-20", "2025-02-03"} {
+		if strings.Contains(output, "# "+date+"\n") {
+			t.Errorf("did not expect output to include %s", date)
+		}
+	}
+
+	if !strings.Contains(output, "## Work Completed") {
+		t.Errorf("expected sections to be nested under each date, got:\n%s", output)
+	}
+}
+
+func TestJournalWorkDone_Subsections(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	content := `# Daily Log 2025-01-15
+
+## Work Completed
+
+### Project A
+
+* Shipped feature X
+
+### Project B
+
+* Fixed bug Y
+
+## Notes
+
+* Not work
+`
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-15.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runJournalWorkDone(nil, []string{"2025-01-15"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Work Completed\n\n### Project A\n\n- Shipped feature X\n\n### Project B\n\n- Fixed bug Y\n\n"
+	if string(outputBytes) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, outputBytes)
+	}
+}
+
+func TestJournalWorkDone_SkipText(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	content := `# Daily Log 2025-01-15
+
+## Work Completed
+
+* Shipped feature X
+* [Yesterday](2025-01-14) | [Tomorrow](2025-01-16)
+* Lunch break
+* Fixed bug Y
+`
+	if err := os.WriteFile(filepath.Join(journalDir, "2025-01-15.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Journal.SkipText = []string{"[Yesterday]", "lunch"}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runJournalWorkDone(nil, []string{"2025-01-15"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Work Completed\n\n- Shipped feature X\n- Fixed bug Y\n\n"
+	if string(outputBytes) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, outputBytes)
+	}
+}
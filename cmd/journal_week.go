@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var journalWeekCmd = &cobra.Command{
+	Use:   "journal-week [date]",
+	Short: "Summarize the work done in a week of journal entries",
+	Long: `Extract the work done sections from every journal entry in the week
+(Monday to Sunday) containing the specified date, grouped under a heading per
+day. Days without a journal entry or without work done are skipped.
+
+If no date is provided, uses today's date.
+Date format: YYYY-MM-DD
+
+Sections are found and ordered as for journal-work-done, and --json prints
+them as [{"date": ..., "heading": ..., "content": ...}].
+
+Examples:
+  za journal-week                    # This week
+  za journal-week 2025-01-15        # The week of 13-19 January 2025`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runJournalWeek,
+}
+
+func init() {
+	rootCmd.AddCommand(journalWeekCmd)
+	journalWeekCmd.Flags().BoolVar(&workDoneJSON, "json", false, "Print sections as JSON")
+}
+
+func runJournalWeek(cmd *cobra.Command, args []string) error {
+	// Parse date argument
+	targetDate, err := parseDateArg(args)
+	if err != nil {
+		return err
+	}
+
+	// Get journal directories
+	journalDirs, err := cfg.JournalDirs()
+	if err != nil {
+		return fmt.Errorf("failed to get journal directory: %w", err)
+	}
+
+	start, end := weekRange(targetDate)
+	return journalWorkDoneForRange(start, end, journalDirs)
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestJournalWeek(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	files := map[string]string{
+		"2025-01-12.md": "# Daily Log\n\n## Work Completed\n\n* Previous week\n", // Sunday before
+		"2025-01-13.md": "# Daily Log\n\n## Work Completed\n\n* Monday work\n",
+		"2025-01-14.md": "# Daily Log\n\n## Notes\n\n* No work done\n",
+		"2025-01-16.md": "# Daily Log\n\n## Worked On\n\n* Thursday work\n",
+		"2025-01-20.md": "# Daily Log\n\n## Work Completed\n\n* Next week\n", // Monday after
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write journal: %v", err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runJournalWeek(nil, []string{"2025-01-15"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# 2025-01-13\n\n## Work Completed\n\n- Monday work\n\n" +
+		"# 2025-01-16\n\n## Worked On\n\n- Thursday work\n\n"
+	if string(outputBytes) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, outputBytes)
+	}
+}
+
+func TestJournalWeek_SkipDrafts(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	files := map[string]string{
+		"2025-01-13.md": "# Daily Log\n\n## Work Completed\n\n* Monday work\n",
+		"2025-01-14.md": "---\ndraft: true\n---\n# Daily Log\n\n## Work Completed\n\n* Half written\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write journal: %v", err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.SkipDraftNotes = true
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runJournalWeek(nil, []string{"2025-01-15"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# 2025-01-13\n\n## Work Completed\n\n- Monday work\n\n"
+	if string(outputBytes) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, outputBytes)
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listNoteType string
+	listFrom     string
+	listTo       string
+)
+
+var listNotesCmd = &cobra.Command{
+	Use:   "list-notes",
+	Short: "List notes and missing weekdays in a date range",
+	Long: `List every note of a type in a date range, marking weekdays without a note.
+
+This command is read-only. Weekends without a note are not reported.
+
+The range defaults to the search window (search_window_days, default: 30)
+ending today. Date format: YYYY-MM-DD
+
+Examples:
+  za list-notes                                  # Journals in the search window
+  za list-notes --type standup                   # Standups in the search window
+  za list-notes --from 2025-01-01 --to 2025-01-31`,
+	Args: cobra.NoArgs,
+	RunE: runListNotes,
+}
+
+func init() {
+	rootCmd.AddCommand(listNotesCmd)
+	listNotesCmd.Flags().StringVar(&listNoteType, "type", string(notes.NoteTypeJournal), "Note type to list (journal or standup)")
+	listNotesCmd.Flags().StringVar(&listFrom, "from", "", "Start date (default: search window before --to)")
+	listNotesCmd.Flags().StringVar(&listTo, "to", "", "End date (default: today)")
+}
+
+func runListNotes(cmd *cobra.Command, args []string) error {
+	noteType := notes.NoteType(listNoteType)
+	if !noteType.IsValid() {
+		return fmt.Errorf("invalid note type: %q (expected journal or standup)", listNoteType)
+	}
+
+	start, end, err := parseListRange(listFrom, listTo, cfg.SearchWindowDays)
+	if err != nil {
+		return err
+	}
+
+	var dirs []string
+	if noteType == notes.NoteTypeJournal {
+		dirs, err = cfg.JournalDirs()
+	} else {
+		var dir string
+		dir, err = cfg.StandupDir()
+		dirs = []string{dir}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s directory: %w", noteType, err)
+	}
+
+	paths, err := notes.FindNotesInRangeMulti(start, end, noteType, dirs, finderOptions(noteType)...)
+	if err != nil {
+		return fmt.Errorf("failed to list %s notes: %w", noteType, err)
+	}
+
+	found := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		date, err := notes.ParseDateFromFilename(path, finderOptions(noteType)...)
+		if err != nil {
+			return fmt.Errorf("failed to parse date from %s: %w", path, err)
+		}
+		found[date.Format(notes.DateFormat)] = true
+	}
+
+	lines, missing := listNoteLines(start, end, found, cfg.IsHoliday)
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	fmt.Printf("\n%d %s note(s), %d missing weekday(s)\n", len(paths), noteType, missing)
+
+	return nil
+}
+
+// parseListRange parses the --from and --to flags. An empty to means today and
+// an empty from means searchWindowDays before to.
+func parseListRange(from, to string, searchWindowDays int) (start, end time.Time, err error) {
+	if to == "" {
+		// Parse today's date so both ends of the range share a location
+		to = today().Format(notes.DateFormat)
+	}
+	if end, err = time.Parse(notes.DateFormat, to); err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --to date (expected YYYY-MM-DD): %w", err)
+	}
+
+	start = end.AddDate(0, 0, -searchWindowDays)
+	if from != "" {
+		if start, err = time.Parse(notes.DateFormat, from); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from date (expected YYYY-MM-DD): %w", err)
+		}
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--to %s is before --from %s",
+			end.Format(notes.DateFormat), start.Format(notes.DateFormat))
+	}
+
+	return start, end, nil
+}
+
+// listNoteLines returns one line per found date and per weekday without a
+// note between start and end (inclusive), along with the number of missing
+// weekdays. Holidays without a note are marked as such, not as missing.
+func listNoteLines(start, end time.Time, found map[string]bool, isHoliday func(time.Time) bool) ([]string, int) {
+	var lines []string
+	missing := 0
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		dateStr := date.Format(notes.DateFormat)
+		switch {
+		case found[dateStr]:
+			lines = append(lines, dateStr)
+		case isHoliday(date):
+			lines = append(lines, dateStr+" (holiday)")
+		case util.IsWeekday(date):
+			lines = append(lines, dateStr+" (missing)")
+			missing++
+		}
+	}
+	return lines, missing
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestListNoteLines(t *testing.T) {
+	// Thursday 2025-01-09 to Tuesday 2025-01-14
+	start := time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	found := map[string]bool{
+		"2025-01-09": true,
+		"2025-01-11": true, // Saturday note is still listed
+		"2025-01-14": true,
+	}
+
+	lines, missing := listNoteLines(start, end, found, (&config.Config{}).IsHoliday)
+
+	want := []string{
+		"2025-01-09",
+		"2025-01-10 (missing)",
+		"2025-01-11",
+		"2025-01-13 (missing)",
+		"2025-01-14",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("listNoteLines() = %v, want %v", lines, want)
+	}
+	if missing != 2 {
+		t.Errorf("listNoteLines() missing = %d, want 2", missing)
+	}
+}
+
+func TestListNoteLines_Holidays(t *testing.T) {
+	// Wednesday 2024-12-24 to Friday 2024-12-27
+	start := time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC)
+	found := map[string]bool{
+		"2024-12-24": true,
+		"2024-12-26": true, // A note on a holiday is listed as usual
+	}
+	holidays := &config.Config{Holidays: []string{"2024-12-25", "2024-12-26"}}
+
+	lines, missing := listNoteLines(start, end, found, holidays.IsHoliday)
+
+	want := []string{
+		"2024-12-24",
+		"2024-12-25 (holiday)",
+		"2024-12-26",
+		"2024-12-27 (missing)",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("listNoteLines() = %v, want %v", lines, want)
+	}
+	if missing != 1 {
+		t.Errorf("listNoteLines() missing = %d, want 1", missing)
+	}
+}
+
+func TestParseListRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{
+			name:      "explicit range",
+			from:      "2025-01-01",
+			to:        "2025-01-31",
+			wantStart: "2025-01-01",
+			wantEnd:   "2025-01-31",
+		},
+		{
+			name:      "from defaults to search window",
+			to:        "2025-01-31",
+			wantStart: "2025-01-01",
+			wantEnd:   "2025-01-31",
+		},
+		{
+			name:    "invalid from",
+			from:    "January",
+			to:      "2025-01-31",
+			wantErr: true,
+		},
+		{
+			name:    "to before from",
+			from:    "2025-02-01",
+			to:      "2025-01-31",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parseListRange(tt.from, tt.to, 30)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseListRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := start.Format("2006-01-02"); got != tt.wantStart {
+				t.Errorf("start = %s, want %s", got, tt.wantStart)
+			}
+			if got := end.Format("2006-01-02"); got != tt.wantEnd {
+				t.Errorf("end = %s, want %s", got, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/rdark/za/internal/links"
+)
+
+// currentDayLinkTitle is the link text conventionally used to link to the
+// other note for the same day, which isn't a configurable link title
+const currentDayLinkTitle = "Today"
+
+// navigationLinkTitles returns the configured link titles for both note
+// types, lowercased, that mark a bullet as a navigation link
+func navigationLinkTitles() map[string]bool {
+	titleLists := [][]string{
+		cfg.Journal.LinkPreviousTitles,
+		cfg.Journal.LinkNextTitles,
+		cfg.Journal.LinkPreviousWeekTitles,
+		cfg.Journal.LinkNextWeekTitles,
+		cfg.Standup.LinkPreviousTitles,
+		cfg.Standup.LinkNextTitles,
+		cfg.Standup.LinkPreviousWeekTitles,
+		cfg.Standup.LinkNextWeekTitles,
+		{currentDayLinkTitle},
+	}
+
+	titles := make(map[string]bool)
+	for _, list := range titleLists {
+		for _, title := range list {
+			if title = strings.ToLower(strings.TrimSpace(title)); title != "" {
+				titles[title] = true
+			}
+		}
+	}
+	return titles
+}
+
+// isNavigationLink reports whether a line is a bullet linking to another
+// note, e.g. "* [Yesterday](2025-01-14)" or "- [[2025-01-15|Standup]]". The
+// link text must be one of the navigationLinkTitles or a cross-reference.
+func isNavigationLink(line string, titles map[string]bool) bool {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "* ") && !strings.HasPrefix(trimmed, "- ") {
+		return false
+	}
+
+	text, ok := bulletLinkText(strings.TrimSpace(trimmed[2:]))
+	if !ok {
+		return false
+	}
+
+	text = strings.ToLower(strings.TrimSpace(text))
+	return titles[text] || links.IsCrossReferenceText(text)
+}
+
+// bulletLinkText returns the text of the link that a bullet item starts with.
+// Wiki links use their alias if they have one, otherwise their target.
+func bulletLinkText(item string) (string, bool) {
+	if strings.HasPrefix(item, "[[") {
+		end := strings.Index(item, "]]")
+		if end == -1 {
+			return "", false
+		}
+		inner := item[2:end]
+		if i := strings.Index(inner, "|"); i != -1 {
+			return inner[i+1:], true
+		}
+		return inner, true
+	}
+
+	if strings.HasPrefix(item, "[") {
+		end := strings.Index(item, "](")
+		if end == -1 {
+			return "", false
+		}
+		return item[1:end], true
+	}
+
+	return "", false
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestIsNavigationLink(t *testing.T) {
+	cfg = &config.Config{
+		Journal: config.JournalConfig{
+			LinkPreviousTitles:     []string{"Prev"},
+			LinkNextTitles:         []string{"Tomorrow"},
+			LinkPreviousWeekTitles: []string{"Last Week"},
+		},
+		Standup: config.StandupConfig{
+			LinkPreviousTitles: []string{"Yesterday"},
+		},
+	}
+	titles := navigationLinkTitles()
+
+	tests := []struct {
+		name string
+		line string
+		want bool
+	}{
+		{"configured journal title", "* [Prev](2025-01-14)", true},
+		{"configured standup title", "- [Yesterday](../journal/2025-01-14)", true},
+		{"case-insensitive title", "* [tomorrow](2025-01-16)", true},
+		{"week title", "* [Last Week](2025-01-08)", true},
+		{"today", "* [Today](../journal/2025-01-15)", true},
+		{"cross-reference", "* [Daily Log](../journal/2025-01-15)", true},
+		{"cross-reference standup", "* [Standup](../standup/2025-01-15)", true},
+		{"wiki link alias", "* [[2025-01-14|Prev]]", true},
+		{"indented", "  * [Prev](2025-01-14)", true},
+		{"unconfigured title", "* [Previous](2025-01-14)", false},
+		{"link with trailing text", "* [Next steps](https://example.com) for the launch", false},
+		{"plain item", "* Fixed a bug", false},
+		{"link not at start", "* Reviewed [Prev](2025-01-14)", false},
+		{"not a bullet", "[Prev](2025-01-14)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNavigationLink(tt.line, titles); got != tt.want {
+				t.Errorf("isNavigationLink(%q) = %v, want %v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rdark/za/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prsOpen    bool
+	prsMerged  bool
+	prsCreated bool
+)
+
+var prsCmd = &cobra.Command{
+	Use:   "prs [date]",
+	Short: "Print your recent pull requests as markdown",
+	Long: `Print your pull requests in the configured GitHub organization as markdown
+bullet points, ready to paste.
+
+Requires the GitHub CLI (gh) and github.enabled: true in .za.yaml.
+
+Modes:
+  --created   PRs created the day before the date (default)
+  --merged    PRs merged the day before the date
+  --open      PRs opened within github.lookback_days (default: 7) of the date
+              that are still unreviewed
+
+If no date is provided, uses today's date.
+Date format: YYYY-MM-DD
+
+Examples:
+  za prs                          # PRs created yesterday
+  za prs --merged                 # PRs merged yesterday
+  za prs --open                   # Open PRs still waiting for review
+  za prs --created 2025-01-15     # PRs created on 2025-01-14`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPRs,
+}
+
+func init() {
+	rootCmd.AddCommand(prsCmd)
+	prsCmd.Flags().BoolVar(&prsOpen, "open", false, "List open PRs that are still unreviewed")
+	prsCmd.Flags().BoolVar(&prsMerged, "merged", false, "List PRs merged the day before the date")
+	prsCmd.Flags().BoolVar(&prsCreated, "created", false, "List PRs created the day before the date (default)")
+	prsCmd.MarkFlagsMutuallyExclusive("open", "merged", "created")
+}
+
+func runPRs(cmd *cobra.Command, args []string) error {
+	targetDate, err := parseDateArg(args)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.GitHub.Enabled {
+		return fmt.Errorf("GitHub integration is disabled (set github.enabled: true and github.org in .za.yaml)")
+	}
+
+	client, err := newPRClient(cfg.GitHub)
+	if err != nil {
+		return fmt.Errorf("GitHub integration unavailable: %w (install and authenticate the GitHub CLI: https://cli.github.com/)", err)
+	}
+
+	var prs []github.PullRequest
+	var prefix string
+	switch {
+	case prsOpen:
+		prs, err = client.GetPRsOpenAndUnreviewed(targetDate)
+		prs = github.FilterUnreviewed(prs)
+		prefix = github.PrefixNeedsReview
+	case prsMerged:
+		prs, err = client.GetPRsMergedYesterday(targetDate)
+		prefix = github.PrefixMerged
+	default:
+		prs, err = client.GetPRsCreatedYesterday(targetDate)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull requests: %w", err)
+	}
+
+	if len(prs) == 0 {
+		fmt.Println("No pull requests found")
+		return nil
+	}
+
+	fmt.Print(github.FormatPRsAsBulletPoints(prs, cfg.Bullet(), prefix))
+	return nil
+}
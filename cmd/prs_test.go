@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/github"
+)
+
+func TestRunPRs_Disabled(t *testing.T) {
+	cfg = &config.Config{GitHub: config.GitHubConfig{Enabled: false}}
+
+	err := runPRs(nil, []string{"2025-01-21"})
+	if err == nil || !strings.Contains(err.Error(), "GitHub integration is disabled") {
+		t.Errorf("runPRs() error = %v, want disabled error", err)
+	}
+}
+
+func TestRunPRs_Unavailable(t *testing.T) {
+	cfg = &config.Config{GitHub: config.GitHubConfig{Enabled: true, Org: "acme"}}
+
+	oldClient := newPRClient
+	newPRClient = func(ghCfg config.GitHubConfig) (prClient, error) { return nil, errors.New("gh CLI not available") }
+	defer func() { newPRClient = oldClient }()
+
+	err := runPRs(nil, []string{"2025-01-21"})
+	if err == nil || !strings.Contains(err.Error(), "gh CLI not available") {
+		t.Errorf("runPRs() error = %v, want unavailable error", err)
+	}
+}
+
+func TestRunPRs_Modes(t *testing.T) {
+	client := &stubPRClient{
+		created: []github.PullRequest{{Number: 1, Title: "Created", URL: "https://github.com/acme/app/pull/1", Repo: "acme/app"}},
+		merged:  []github.PullRequest{{Number: 2, Title: "Merged", URL: "https://github.com/acme/app/pull/2", Repo: "acme/app"}},
+		open:    []github.PullRequest{{Number: 3, Title: "Open", URL: "https://github.com/acme/app/pull/3", Repo: "acme/app"}},
+	}
+
+	tests := []struct {
+		name   string
+		open   bool
+		merged bool
+		want   string
+	}{
+		{"created by default", false, false, "- [app#1](https://github.com/acme/app/pull/1): Created\n"},
+		{"merged", false, true, "- merged: [app#2](https://github.com/acme/app/pull/2): Merged\n"},
+		{"open", true, false, "- needs-review: [app#3](https://github.com/acme/app/pull/3): Open\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = &config.Config{GitHub: config.GitHubConfig{Enabled: true, Org: "acme"}}
+
+			oldClient := newPRClient
+			newPRClient = func(ghCfg config.GitHubConfig) (prClient, error) { return client, nil }
+			defer func() { newPRClient = oldClient }()
+
+			prsOpen, prsMerged = tt.open, tt.merged
+			defer func() { prsOpen, prsMerged = false, false }()
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runPRs(nil, []string{"2025-01-21"})
+
+			w.Close()
+			os.Stdout = oldStdout
+			outputBytes, _ := io.ReadAll(r)
+
+			if err != nil {
+				t.Fatalf("runPRs() error = %v", err)
+			}
+			if string(outputBytes) != tt.want {
+				t.Errorf("runPRs() output = %q, want %q", outputBytes, tt.want)
+			}
+		})
+	}
+}
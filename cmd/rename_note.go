@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rdark/za/internal/links"
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
+	"github.com/spf13/cobra"
+)
+
+var renameNoteCmd = &cobra.Command{
+	Use:   "rename-note <note> <new-date>",
+	Short: "Move a note to a new date and fix the links to it",
+	Long: `Rename a journal or standup note to the filename for a new date, then
+rewrite every link to it in the journal and standup directories to match.
+
+Use it to correct a misdated or misnamed note, e.g. 2025-01-6.md. The note
+stays in its directory. It refuses to rename a note onto a date that already
+has a note of the same type.
+
+Date format: YYYY-MM-DD
+
+Examples:
+  za rename-note journal/2025-01-6.md 2025-01-06
+  za rename-note standup/2025-01-14.md 2025-01-15`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRenameNote,
+}
+
+func init() {
+	rootCmd.AddCommand(renameNoteCmd)
+}
+
+func runRenameNote(cmd *cobra.Command, args []string) error {
+	oldPath := args[0]
+	if info, err := os.Stat(oldPath); err != nil {
+		return fmt.Errorf("cannot rename %s: %w", oldPath, err)
+	} else if info.IsDir() {
+		return fmt.Errorf("cannot rename %s: is a directory", oldPath)
+	}
+
+	newDate, err := parseDateArg(args[1:])
+	if err != nil {
+		return err
+	}
+
+	noteType, err := determineNoteType(oldPath)
+	if err != nil {
+		return err
+	}
+
+	journalDirs, err := cfg.JournalDirs()
+	if err != nil {
+		return fmt.Errorf("failed to get journal directories: %w", err)
+	}
+	standupDir, err := cfg.StandupDir()
+	if err != nil {
+		return fmt.Errorf("failed to get standup directory: %w", err)
+	}
+	typeDirs := journalDirs
+	if noteType == notes.NoteTypeStandup {
+		typeDirs = []string{standupDir}
+	}
+
+	// Refuse to replace, or duplicate, the note for the new date
+	newPath := filepath.Join(filepath.Dir(oldPath), notes.GenerateFilename(newDate, finderOptions(noteType)...))
+	existing, err := notes.FindNotesInRangeMulti(newDate, newDate, noteType, typeDirs, finderOptions(noteType)...)
+	if err != nil {
+		return fmt.Errorf("failed to check for an existing note: %w", err)
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		existing = append(existing, newPath)
+	}
+	if len(existing) > 0 {
+		return fmt.Errorf("a %s note for %s already exists: %s", noteType, newDate.Format(notes.DateFormat), existing[0])
+	}
+
+	oldName := strings.TrimSuffix(filepath.Base(oldPath), ".md")
+	newName := strings.TrimSuffix(filepath.Base(newPath), ".md")
+	backlinks, err := links.FindBacklinksByName(oldName, noteType, append(journalDirs, standupDir)...)
+	if err != nil {
+		return fmt.Errorf("failed to find links to %s: %w", oldPath, err)
+	}
+
+	// Rewrite every backlink in memory first, so that nothing is renamed or
+	// written unless all of them can be updated
+	rewrites, err := planBacklinkRenames(backlinks, oldName, newName)
+	if err != nil {
+		return err
+	}
+	for i := range rewrites {
+		// Links in the renamed note itself are written to its new path
+		if sameFile(rewrites[i].path, oldPath) {
+			rewrites[i].path = newPath
+		}
+	}
+
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("failed to rename note: %w", err)
+	}
+	fmt.Printf("✓ Renamed %s to %s\n", oldPath, newPath)
+
+	updated := 0
+	for _, rewrite := range rewrites {
+		if err := util.RewriteFile(rewrite.path, rewrite.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rewrite.path, err)
+		}
+		fmt.Printf("  %s\n", rewrite.path)
+		updated += rewrite.links
+	}
+	fmt.Printf("✓ Updated %d link(s) in %d note(s)\n", updated, len(rewrites))
+	return nil
+}
+
+// backlinkRewrite is the new content of a note whose links to a renamed note
+// have been updated
+type backlinkRewrite struct {
+	path    string
+	content []byte
+	links   int
+}
+
+// planBacklinkRenames points each backlink at the note named newName instead
+// of oldName, keeping any directory, .md extension and fragment, and returns
+// the new content of each note without writing anything. Reference-style
+// links, whose destination is defined elsewhere, are skipped with a warning.
+func planBacklinkRenames(backlinks []links.Backlink, oldName, newName string) ([]backlinkRewrite, error) {
+	byPath := make(map[string][]markdown.Link)
+	var paths []string
+	for _, backlink := range backlinks {
+		if _, ok := byPath[backlink.Path]; !ok {
+			paths = append(paths, backlink.Path)
+		}
+		byPath[backlink.Path] = append(byPath[backlink.Path], backlink.Link)
+	}
+
+	parser := markdown.NewParser()
+	var rewrites []backlinkRewrite
+	for _, path := range paths {
+		// Backlinks were found in this same, unchanged content, so their
+		// positions still apply
+		doc, err := parser.ParseFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		var replacements []markdown.DestinationReplacement
+		for _, link := range byPath[path] {
+			if doc.IsReferenceLink(link) {
+				fmt.Fprintf(os.Stderr, "⚠ %s:%d: %s is a reference-style link, left unchanged\n",
+					path, link.Line, link.Format(link.Destination))
+				continue
+			}
+			dir, base := splitLinkPath(link.Path())
+			dest := dir + newName + strings.TrimPrefix(base, oldName) + link.Fragment()
+			replacements = append(replacements, markdown.DestinationReplacement{Link: link, Destination: dest})
+		}
+		if len(replacements) == 0 {
+			continue
+		}
+
+		content, err := doc.ReplaceLinkDestinations(replacements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update links in %s: %w", path, err)
+		}
+		rewrites = append(rewrites, backlinkRewrite{path: path, content: content, links: len(replacements)})
+	}
+	return rewrites, nil
+}
+
+// sameFile reports whether paths a and b name the same existing file
+func sameFile(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// splitLinkPath splits a link path after its last "/", into the directory
+// (with trailing "/") and the file name
+func splitLinkPath(linkPath string) (string, string) {
+	i := strings.LastIndex(linkPath, "/")
+	return linkPath[:i+1], linkPath[i+1:]
+}
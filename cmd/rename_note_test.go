@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/links"
+	"github.com/rdark/za/internal/markdown"
+)
+
+func TestRenameNote(t *testing.T) {
+	root := t.TempDir()
+	journalDir := filepath.Join(root, "journal")
+	standupDir := filepath.Join(root, "standup")
+
+	files := map[string]string{
+		"journal/2025-01-6.md":  "# Log 2025-01-06\n\n[Tomorrow](2025-01-07)\n",
+		"journal/2025-01-07.md": "# Log\n\n* [Yesterday](2025-01-6 \"Monday\")\n* [Older](2025-01-16)\n",
+		"standup/2025-01-06.md": "# Standup\n\n* [Journal](../journal/2025-01-6.md#goals)\n* [Yesterday](2025-01-6)\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	oldStdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	defer func() { os.Stdout = oldStdout }()
+
+	if err := runRenameNote(nil, []string{filepath.Join(journalDir, "2025-01-6.md"), "2025-01-06"}); err != nil {
+		t.Fatalf("runRenameNote() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(journalDir, "2025-01-6.md")); !os.IsNotExist(err) {
+		t.Errorf("expected old note to be gone, got err = %v", err)
+	}
+
+	want := map[string]string{
+		"journal/2025-01-06.md": files["journal/2025-01-6.md"],
+		"journal/2025-01-07.md": "# Log\n\n* [Yesterday](2025-01-06 \"Monday\")\n* [Older](2025-01-16)\n",
+		// The standup's Yesterday link points to a standup, so is left alone
+		"standup/2025-01-06.md": "# Standup\n\n* [Journal](../journal/2025-01-06.md#goals)\n* [Yesterday](2025-01-6)\n",
+	}
+	for name, content := range want {
+		got, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if string(got) != content {
+			t.Errorf("%s =\n%s\nwant:\n%s", name, got, content)
+		}
+	}
+}
+
+func TestRenameNote_TargetExists(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	for _, name := range []string{"2025-01-6.md", "2025-01-06.md"} {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte("# Log\n"), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(filepath.Dir(journalDir), "standup")
+
+	err := runRenameNote(nil, []string{filepath.Join(journalDir, "2025-01-6.md"), "2025-01-06"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("runRenameNote() error = %v, want already exists", err)
+	}
+	if _, err := os.Stat(filepath.Join(journalDir, "2025-01-6.md")); err != nil {
+		t.Errorf("expected note to be left in place: %v", err)
+	}
+}
+
+func TestRenameNote_ReferenceBacklink(t *testing.T) {
+	root := t.TempDir()
+	journalDir := filepath.Join(root, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	// A reference-style link can't be rewritten in place; it is left for the
+	// user while the inline link is updated
+	files := map[string]string{
+		"2025-01-6.md":  "# Log\n",
+		"2025-01-07.md": "# Log\n\n* [that day][d]\n* [Yesterday](2025-01-6)\n\n[d]: 2025-01-6\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(root, "standup")
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	os.Stdout, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stderr, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stdout, os.Stderr = oldStdout, oldStderr }()
+
+	if err := runRenameNote(nil, []string{filepath.Join(journalDir, "2025-01-6.md"), "2025-01-06"}); err != nil {
+		t.Fatalf("runRenameNote() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(journalDir, "2025-01-06.md")); err != nil {
+		t.Errorf("expected renamed note, got err = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(journalDir, "2025-01-07.md"))
+	if err != nil {
+		t.Fatalf("failed to read note: %v", err)
+	}
+	want := "# Log\n\n* [that day][d]\n* [Yesterday](2025-01-06)\n\n[d]: 2025-01-6\n"
+	if string(got) != want {
+		t.Errorf("2025-01-07.md =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPlanBacklinkRenames_FailureWritesNothing(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	good := filepath.Join(journalDir, "2025-01-07.md")
+	bad := filepath.Join(journalDir, "2025-01-08.md")
+	files := map[string]string{
+		good: "# Log\n\n[Yesterday](2025-01-6)\n",
+		bad:  "# Log\n\nNo links here\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	doc, err := markdown.NewParser().ParseFile(good)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	// The second backlink no longer matches its note's content
+	backlinks := []links.Backlink{
+		{Path: good, Link: doc.ExtractLinks()[0]},
+		{Path: bad, Link: markdown.Link{Text: "Yesterday", Destination: "2025-01-6", Line: 3}},
+	}
+	if _, err := planBacklinkRenames(backlinks, "2025-01-6", "2025-01-06"); err == nil {
+		t.Fatal("planBacklinkRenames() should fail when a link can't be updated")
+	}
+
+	for path, content := range files {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if string(got) != content {
+			t.Errorf("%s was modified:\n%s", path, got)
+		}
+	}
+}
@@ -3,13 +3,17 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
 	"github.com/spf13/cobra"
 )
 
 var (
 	cfgFile string
+	verbose bool
 	cfg     *config.Config
 	version string
 	commit  string
@@ -38,6 +42,8 @@ func init() {
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .za.yaml)")
+	rootCmd.PersistentFlags().BoolVar(&util.BackupOnRewrite, "backup", false, "save a <file>.bak copy of each note before modifying it")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "explain decisions, e.g. how fix-links resolved each link")
 
 	// Add version command
 	rootCmd.AddCommand(versionCmd)
@@ -68,6 +74,10 @@ func initConfig() {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
+	if len(cfg.Source.UnknownKeys) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠ Ignoring unknown config keys in %s: %s\n",
+			cfg.Source.File, strings.Join(cfg.Source.UnknownKeys, ", "))
+	}
 }
 
 // GetConfig returns the loaded configuration
@@ -75,6 +85,26 @@ func GetConfig() *config.Config {
 	return cfg
 }
 
+// finderOptions returns the notes finder options for a note type derived from
+// the loaded configuration
+func finderOptions(noteType notes.NoteType) []notes.Option {
+	return []notes.Option{
+		notes.WithSkipEmptyNotes(cfg.SkipEmptyNotes),
+		notes.WithFilenameFormat(cfg.FilenameFormatFor(string(noteType))),
+		notes.WithPathLayout(cfg.PathLayoutFor(string(noteType))),
+	}
+}
+
+// summaryFinderOptions is like finderOptions, but also skips draft notes if
+// skip_draft_notes is set, for commands that summarise notes
+func summaryFinderOptions(noteType notes.NoteType) []notes.Option {
+	opts := finderOptions(noteType)
+	if cfg.SkipDraftNotes {
+		opts = append(opts, notes.WithSkipDrafts(cfg.DraftFieldName()))
+	}
+	return opts
+}
+
 // SetVersionInfo sets the version information for the application
 func SetVersionInfo(v, c, d string) {
 	version = v
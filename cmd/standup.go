@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/rdark/za/internal/markdown"
@@ -17,33 +16,30 @@ var standupWorkDoneCmd = &cobra.Command{
 	Long: `Extract work done section from a standup entry for the specified date.
 
 If no date is provided, uses today's date.
-Date format: YYYY-MM-DD
+Date format: YYYY-MM-DD, or YYYY-MM to extract from every standup in that month.
 
 If the exact date is not found, searches backwards within the configured
-search window (default: 30 days) to find the most recent entry.
+search window (default: 30 days) to find the most recent entry. With
+skip_draft_notes set, notes marked as drafts are skipped the same way.
 
 The command extracts the section matching the configured work_done_section
-(default: "Worked on yesterday").`,
+(default: "Worked on yesterday"). Use --json to print
+[{"heading": ..., "content": ...}] instead; a month of notes also includes
+each section's "date".`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStandupWorkDone,
 }
 
 func init() {
 	rootCmd.AddCommand(standupWorkDoneCmd)
+	standupWorkDoneCmd.Flags().BoolVar(&workDoneJSON, "json", false, "Print the section as JSON")
 }
 
 func runStandupWorkDone(cmd *cobra.Command, args []string) error {
 	// Parse date argument
-	var targetDate time.Time
-	var err error
-
-	if len(args) > 0 {
-		targetDate, err = time.Parse(notes.DateFormat, args[0])
-		if err != nil {
-			return fmt.Errorf("invalid date format (expected YYYY-MM-DD): %w", err)
-		}
-	} else {
-		targetDate = time.Now()
+	start, end, isMonth, err := parseDateRangeArg(args)
+	if err != nil {
+		return err
 	}
 
 	// Get standup directory
@@ -52,12 +48,17 @@ func runStandupWorkDone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get standup directory: %w", err)
 	}
 
+	if isMonth {
+		return standupWorkDoneForRange(start, end, standupDir)
+	}
+
 	// Find standup file
 	standupPath, err := notes.FindNoteByDate(
-		targetDate,
+		start,
 		notes.NoteTypeStandup,
 		standupDir,
 		cfg.SearchWindowDays,
+		summaryFinderOptions(notes.NoteTypeStandup)...,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to find standup entry: %w", err)
@@ -71,18 +72,83 @@ func runStandupWorkDone(cmd *cobra.Command, args []string) error {
 	}
 
 	// Extract work done section
-	section := doc.FindSectionByHeading(cfg.Standup.WorkDoneSection)
+	section := findStandupWorkDoneSection(doc)
 
 	if section == nil {
 		fmt.Fprintf(os.Stderr, "No work done section found in %s\n", standupPath)
 		fmt.Fprintf(os.Stderr, "Looking for section: %q\n", cfg.Standup.WorkDoneSection)
+		if workDoneJSON {
+			return printWorkDoneJSON(nil)
+		}
 		return nil
 	}
 
 	// Output the extracted section
-	fmt.Printf("# %s\n\n", section.Heading.Text)
-	fmt.Print(strings.TrimSpace(section.Content))
-	fmt.Printf("\n\n")
+	if workDoneJSON {
+		return printWorkDoneJSON(workDoneEntries([]markdown.Section{*section}, ""))
+	}
+	printSections([]markdown.Section{*section}, 1)
 
 	return nil
 }
+
+// standupWorkDoneForRange outputs the work done section of every standup
+// between start and end (inclusive), grouped under a heading per date
+func standupWorkDoneForRange(start, end time.Time, standupDir string) error {
+	standupPaths, err := notes.FindNotesInRange(start, end, notes.NoteTypeStandup, standupDir, summaryFinderOptions(notes.NoteTypeStandup)...)
+	if err != nil {
+		return fmt.Errorf("failed to find standup entries: %w", err)
+	}
+
+	if len(standupPaths) == 0 {
+		fmt.Fprintf(os.Stderr, "No standup entries found between %s and %s\n",
+			start.Format(notes.DateFormat), end.Format(notes.DateFormat))
+		if workDoneJSON {
+			return printWorkDoneJSON(nil)
+		}
+		return nil
+	}
+
+	var entries []workDoneEntry
+	parser := markdown.NewParser()
+	for _, standupPath := range standupPaths {
+		doc, err := parser.ParseFile(standupPath)
+		if err != nil {
+			return fmt.Errorf("failed to parse standup %s: %w", standupPath, err)
+		}
+
+		section := findStandupWorkDoneSection(doc)
+		if section == nil {
+			continue
+		}
+
+		date, err := notes.ParseDateFromFilename(standupPath, finderOptions(notes.NoteTypeStandup)...)
+		if err != nil {
+			return fmt.Errorf("failed to parse date from standup filename: %w", err)
+		}
+
+		if workDoneJSON {
+			entries = append(entries, workDoneEntries([]markdown.Section{*section}, date.Format(notes.DateFormat))...)
+			continue
+		}
+
+		fmt.Printf("# %s\n\n", date.Format(notes.DateFormat))
+		printSections([]markdown.Section{*section}, 2)
+	}
+
+	if workDoneJSON {
+		return printWorkDoneJSON(entries)
+	}
+	return nil
+}
+
+// findStandupWorkDoneSection finds the configured work done section in a
+// standup, with standup.skip_text lines removed, or nil if there isn't one
+func findStandupWorkDoneSection(doc *markdown.Document) *markdown.Section {
+	section := doc.FindSectionByHeading(cfg.Standup.WorkDoneSection)
+	if section == nil {
+		return nil
+	}
+	filtered := skipTextInSections([]markdown.Section{*section}, cfg.Standup.SkipText)[0]
+	return &filtered
+}
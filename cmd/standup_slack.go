@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
-	"time"
 
 	"github.com/rdark/za/internal/markdown"
 	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
 	"github.com/spf13/cobra"
 )
 
@@ -17,31 +19,78 @@ var standupSlackCmd = &cobra.Command{
 in a format suitable for pasting into Slack.
 
 This command reads from the standup file and extracts:
-- Work completed yesterday from "Worked on Yesterday" section
-- Planned work for today from "Working on Today" section
+- Work completed yesterday from the standup.work_done_section section
+- Planned work for today from the standup.work_today_section section
+  ("Working on Today" by default)
+
+Sections listed in standup.extra_sections (e.g. "Blockers") are printed after
+next:, each under its lowercased heading (e.g. "blockers:"). Missing or empty
+extra sections are omitted.
+
+Navigation links such as "* [Yesterday](...)" are skipped. A bullet counts as a
+navigation link when its link text is one of the configured link titles, or
+"Today", or a cross-reference such as "Standup" or "Daily Log".
+
+Items can be prefixed with an emoji per section by configuring
+standup.slack_emoji, e.g. {"Worked on Yesterday": "✅", "Working on Today": "🔜"}.
+
+Use --json to print {"previous": [...], "next": [...]} for bots and webhooks
+instead. Items are printed without emoji, and empty sections are empty arrays.
+Non-empty extra sections are included under "extra", keyed by label.
+
+Use --clipboard to also copy the update to the system clipboard, using pbcopy,
+xclip or clip. If none is installed, a warning is shown and the update is only
+printed.
 
 Examples:
   za standup-slack                    # Generate update for today
-  za standup-slack 2025-01-15        # Generate update for specific date`,
+  za standup-slack 2025-01-15        # Generate update for specific date
+  za standup-slack --json            # JSON output for automation
+  za standup-slack --clipboard       # Print and copy to the clipboard`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runStandupSlack,
 }
 
+var (
+	standupSlackJSON      bool
+	standupSlackClipboard bool
+)
+
+// copyToClipboard copies the standup-slack output; tests replace it to avoid
+// touching the real clipboard
+var copyToClipboard = util.CopyToClipboard
+
 func init() {
 	rootCmd.AddCommand(standupSlackCmd)
+	standupSlackCmd.Flags().BoolVar(&standupSlackJSON, "json", false, "Print the update as JSON")
+	standupSlackCmd.Flags().BoolVar(&standupSlackClipboard, "clipboard", false, "Also copy the update to the system clipboard")
+}
+
+// standupSlackUpdate is the JSON form of the standup-slack output
+type standupSlackUpdate struct {
+	Previous []string `json:"previous"`
+	Next     []string `json:"next"`
+
+	// Extra holds the non-empty standup.extra_sections, keyed by label
+	Extra map[string][]string `json:"extra,omitempty"`
+}
+
+// slackSection is a non-empty extra section printed by standup-slack
+type slackSection struct {
+	Heading string
+	Items   []string
+}
+
+// Label returns the label a section is printed under, e.g. "blockers"
+func (s slackSection) Label() string {
+	return strings.ToLower(strings.TrimSpace(s.Heading))
 }
 
 func runStandupSlack(cmd *cobra.Command, args []string) error {
 	// Parse target date (today)
-	var targetDate time.Time
-	var err error
-	if len(args) > 0 {
-		targetDate, err = time.Parse(notes.DateFormat, args[0])
-		if err != nil {
-			return fmt.Errorf("invalid date format, expected YYYY-MM-DD: %w", err)
-		}
-	} else {
-		targetDate = time.Now()
+	targetDate, err := parseDateArg(args)
+	if err != nil {
+		return err
 	}
 
 	standupDir, err := cfg.StandupDir()
@@ -50,13 +99,13 @@ func runStandupSlack(cmd *cobra.Command, args []string) error {
 	}
 
 	// Find today's standup
-	standupPath, err := notes.FindNoteByDate(targetDate, notes.NoteTypeStandup, standupDir, cfg.SearchWindowDays)
+	standupPath, err := notes.FindNoteByDate(targetDate, notes.NoteTypeStandup, standupDir, cfg.SearchWindowDays, finderOptions(notes.NoteTypeStandup)...)
 	if err != nil {
 		return fmt.Errorf("no standup found for %s: %w", targetDate.Format(notes.DateFormat), err)
 	}
 
 	// Verify this is actually today's standup
-	foundDate, err := notes.ParseDateFromFilename(standupPath)
+	foundDate, err := notes.ParseDateFromFilename(standupPath, finderOptions(notes.NoteTypeStandup)...)
 	if err != nil {
 		return fmt.Errorf("failed to parse date from standup filename: %w", err)
 	}
@@ -75,88 +124,129 @@ func runStandupSlack(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse standup file: %w", err)
 	}
 
-	// Extract yesterday's work from "Worked on Yesterday" section
-	var yesterdayItems []string
-	yesterdaySection := standupDoc.FindSectionByHeading(cfg.Standup.WorkDoneSection)
-	if yesterdaySection != nil && strings.TrimSpace(yesterdaySection.Content) != "" {
-		lines := strings.Split(yesterdaySection.Content, "\n")
-		for _, line := range lines {
-			trimmed := strings.TrimSpace(line)
-			if trimmed == "" {
-				continue
-			}
-			// Skip navigation links (Yesterday, Today, Tomorrow, Standup, Daily)
-			if strings.HasPrefix(trimmed, "* [Yesterday") || strings.HasPrefix(trimmed, "* [Today") ||
-				strings.HasPrefix(trimmed, "* [Tomorrow") || strings.HasPrefix(trimmed, "* [Standup") ||
-				strings.HasPrefix(trimmed, "* [Daily") ||
-				strings.HasPrefix(trimmed, "- [Yesterday") || strings.HasPrefix(trimmed, "- [Today") ||
-				strings.HasPrefix(trimmed, "- [Tomorrow") || strings.HasPrefix(trimmed, "- [Standup") ||
-				strings.HasPrefix(trimmed, "- [Daily") {
-				continue
-			}
-			// Extract bullet points
-			var item string
-			if strings.HasPrefix(trimmed, "* ") {
-				item = strings.TrimSpace(strings.TrimPrefix(trimmed, "* "))
-			} else if strings.HasPrefix(trimmed, "- ") {
-				item = strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
-			}
-			if item != "" {
-				yesterdayItems = append(yesterdayItems, item)
-			}
+	// Extract yesterday's work and today's goals, skipping navigation links
+	titles := navigationLinkTitles()
+	yesterdayItems := slackSectionItems(standupDoc, cfg.Standup.WorkDoneSection, titles)
+	todayItems := slackSectionItems(standupDoc, cfg.Standup.WorkTodaySection, titles)
+
+	// Extract any extra sections, omitting those that are missing or empty
+	var extraSections []slackSection
+	for _, heading := range cfg.Standup.ExtraSections {
+		if items := slackSectionItems(standupDoc, heading, titles); len(items) > 0 {
+			extraSections = append(extraSections, slackSection{Heading: heading, Items: items})
 		}
 	}
 
-	// Extract today's goals from "Working on Today" section
-	var todayItems []string
-	todaySection := standupDoc.FindSectionByHeading("Working on Today")
-	if todaySection != nil && strings.TrimSpace(todaySection.Content) != "" {
-		lines := strings.Split(todaySection.Content, "\n")
-		for _, line := range lines {
-			trimmed := strings.TrimSpace(line)
-			if trimmed == "" {
-				continue
-			}
-			// Skip navigation links (Yesterday, Today, Tomorrow, Standup, Daily)
-			if strings.HasPrefix(trimmed, "* [Yesterday") || strings.HasPrefix(trimmed, "* [Today") ||
-				strings.HasPrefix(trimmed, "* [Tomorrow") || strings.HasPrefix(trimmed, "* [Standup") ||
-				strings.HasPrefix(trimmed, "* [Daily") ||
-				strings.HasPrefix(trimmed, "- [Yesterday") || strings.HasPrefix(trimmed, "- [Today") ||
-				strings.HasPrefix(trimmed, "- [Tomorrow") || strings.HasPrefix(trimmed, "- [Standup") ||
-				strings.HasPrefix(trimmed, "- [Daily") {
-				continue
-			}
-			// Extract bullet points
-			var item string
-			if strings.HasPrefix(trimmed, "* ") {
-				item = strings.TrimSpace(strings.TrimPrefix(trimmed, "* "))
-			} else if strings.HasPrefix(trimmed, "- ") {
-				item = strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
-			}
-			if item != "" {
-				todayItems = append(todayItems, item)
-			}
+	var output string
+	if standupSlackJSON {
+		output, err = formatStandupSlackJSON(yesterdayItems, todayItems, extraSections)
+		if err != nil {
+			return err
+		}
+	} else {
+		output = formatStandupSlack(yesterdayItems, todayItems, extraSections)
+	}
+
+	fmt.Print(output)
+
+	if standupSlackClipboard {
+		if err := copyToClipboard(output); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ Could not copy to clipboard: %v\n", err)
+		} else {
+			fmt.Fprintln(os.Stderr, "✓ Copied to clipboard")
 		}
 	}
 
-	// Print the update in Slack format (no blank lines)
-	fmt.Print("previous:\n")
+	return nil
+}
+
+// formatStandupSlack formats the update in Slack format (no blank lines)
+func formatStandupSlack(yesterdayItems, todayItems []string, extraSections []slackSection) string {
+	var sb strings.Builder
+
+	sb.WriteString("previous:\n")
 	if len(yesterdayItems) > 0 {
 		for _, item := range yesterdayItems {
-			fmt.Printf("* %s\n", item)
+			fmt.Fprintf(&sb, "* %s\n", formatSlackItem(item, cfg.Standup.WorkDoneSection))
 		}
 	} else {
-		fmt.Print("* No work recorded\n")
+		sb.WriteString("* No work recorded\n")
 	}
 
-	fmt.Print("next:\n")
+	sb.WriteString("next:\n")
 	if len(todayItems) > 0 {
 		for _, item := range todayItems {
-			fmt.Printf("* %s\n", item)
+			fmt.Fprintf(&sb, "* %s\n", formatSlackItem(item, cfg.Standup.WorkTodaySection))
 		}
 	} else {
-		fmt.Print("* No goals set\n")
+		sb.WriteString("* No goals set\n")
 	}
 
-	return nil
+	for _, section := range extraSections {
+		fmt.Fprintf(&sb, "%s:\n", section.Label())
+		for _, item := range section.Items {
+			fmt.Fprintf(&sb, "* %s\n", formatSlackItem(item, section.Heading))
+		}
+	}
+
+	return sb.String()
+}
+
+// formatStandupSlackJSON formats the update as a standupSlackUpdate
+func formatStandupSlackJSON(yesterdayItems, todayItems []string, extraSections []slackSection) (string, error) {
+	update := standupSlackUpdate{Previous: yesterdayItems, Next: todayItems}
+	if len(extraSections) > 0 {
+		update.Extra = make(map[string][]string, len(extraSections))
+		for _, section := range extraSections {
+			update.Extra[section.Label()] = section.Items
+		}
+	}
+	if update.Previous == nil {
+		update.Previous = []string{}
+	}
+	if update.Next == nil {
+		update.Next = []string{}
+	}
+
+	data, err := json.Marshal(update)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// slackSectionItems returns the bullet items in a standup section, without
+// their bullets. Navigation links (see isNavigationLink) are skipped.
+func slackSectionItems(doc *markdown.Document, heading string, titles map[string]bool) []string {
+	section := doc.FindSectionByHeading(heading)
+	if section == nil {
+		return nil
+	}
+
+	var items []string
+	for _, line := range strings.Split(section.Content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || isNavigationLink(trimmed, titles) {
+			continue
+		}
+		// Extract bullet points
+		var item string
+		if strings.HasPrefix(trimmed, "* ") {
+			item = strings.TrimSpace(strings.TrimPrefix(trimmed, "* "))
+		} else if strings.HasPrefix(trimmed, "- ") {
+			item = strings.TrimSpace(strings.TrimPrefix(trimmed, "- "))
+		}
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// formatSlackItem prefixes an item with the emoji configured for its section, if any
+func formatSlackItem(item, sectionHeading string) string {
+	if emoji := cfg.Standup.SlackEmojiFor(sectionHeading); emoji != "" {
+		return emoji + " " + item
+	}
+	return item
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/rdark/za/internal/config"
 	"github.com/rdark/za/internal/notes"
+	"github.com/rdark/za/internal/util"
 )
 
 func TestStandupSlack_WithBothDays(t *testing.T) {
@@ -53,8 +54,10 @@ title: Standup 2025-01-21
 	// Configure
 	cfg = &config.Config{
 		Standup: config.StandupConfig{
-			Dir:             standupDir,
-			WorkDoneSection: "Worked on Yesterday",
+			Dir:                standupDir,
+			WorkDoneSection:    "Worked on Yesterday",
+			WorkTodaySection:   "Working on Today",
+			LinkPreviousTitles: []string{"Yesterday"},
 		},
 		SearchWindowDays: 30,
 	}
@@ -129,6 +132,62 @@ title: Standup 2025-01-21
 	}
 }
 
+func TestStandupSlack_Timezone(t *testing.T) {
+	standupDir := filepath.Join(t.TempDir(), "standup")
+	if err := os.MkdirAll(standupDir, 0755); err != nil {
+		t.Fatalf("failed to create standup dir: %v", err)
+	}
+	content := "# Standup 2025-01-08\n\n## Worked on Yesterday\n\n* Fixed login bug\n\n## Working on Today\n\n* Review code changes\n"
+	if err := os.WriteFile(filepath.Join(standupDir, "2025-01-08.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write standup: %v", err)
+	}
+
+	oldNow := now
+	defer func() { now = oldNow }()
+
+	// Late evening on the 7th in UTC is the morning of the 8th in Sydney
+	now = func() time.Time { return time.Date(2025, 1, 7, 22, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		timezone string
+		wantErr  bool
+	}{
+		{"Australia/Sydney", false},
+		{"UTC", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timezone, func(t *testing.T) {
+			cfg = config.DefaultConfig()
+			cfg.Standup.Dir = standupDir
+			cfg.Timezone = tt.timezone
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runStandupSlack(nil, nil)
+
+			w.Close()
+			os.Stdout = oldStdout
+			outputBytes, _ := io.ReadAll(r)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "2025-01-07") {
+					t.Errorf("runStandupSlack() error = %v, want no standup for 2025-01-07", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(string(outputBytes), "Fixed login bug") {
+				t.Errorf("expected the 2025-01-08 standup, got:\n%s", outputBytes)
+			}
+		})
+	}
+}
+
 func TestStandupSlack_NoYesterdayWork(t *testing.T) {
 	tempDir := t.TempDir()
 	standupDir := filepath.Join(tempDir, "standup")
@@ -162,8 +221,10 @@ title: Standup 2025-01-21
 
 	cfg = &config.Config{
 		Standup: config.StandupConfig{
-			Dir:             standupDir,
-			WorkDoneSection: "Worked on Yesterday",
+			Dir:                standupDir,
+			WorkDoneSection:    "Worked on Yesterday",
+			WorkTodaySection:   "Working on Today",
+			LinkPreviousTitles: []string{"Yesterday"},
 		},
 		SearchWindowDays: 30,
 	}
@@ -235,8 +296,10 @@ title: Standup 2025-01-21
 
 	cfg = &config.Config{
 		Standup: config.StandupConfig{
-			Dir:             standupDir,
-			WorkDoneSection: "Worked on Yesterday",
+			Dir:                standupDir,
+			WorkDoneSection:    "Worked on Yesterday",
+			WorkTodaySection:   "Working on Today",
+			LinkPreviousTitles: []string{"Yesterday"},
 		},
 		SearchWindowDays: 30,
 	}
@@ -266,3 +329,405 @@ title: Standup 2025-01-21
 		t.Error("expected output to indicate no goals set")
 	}
 }
+
+func TestStandupSlack_WithEmoji(t *testing.T) {
+	tempDir := t.TempDir()
+	standupDir := filepath.Join(tempDir, "standup")
+
+	if err := os.MkdirAll(standupDir, 0755); err != nil {
+		t.Fatalf("failed to create standup dir: %v", err)
+	}
+
+	today := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	standupPath := filepath.Join(standupDir, today.Format(notes.DateFormat)+".md")
+	standupContent := `# Standup 2025-01-21
+
+## Worked on Yesterday
+
+* Fixed a bug
+
+## Working on Today
+
+* Review code changes
+`
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	cfg = &config.Config{
+		Standup: config.StandupConfig{
+			Dir:                standupDir,
+			WorkDoneSection:    "Worked on Yesterday",
+			WorkTodaySection:   "Working on Today",
+			LinkPreviousTitles: []string{"Yesterday"},
+			SlackEmoji: map[string]string{
+				"worked on yesterday": "✅",
+				"Working on Today":    "🔜",
+			},
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runStandupSlack(nil, []string{today.Format(notes.DateFormat)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+	output := string(outputBytes)
+
+	if !strings.Contains(output, "* ✅ Fixed a bug\n") {
+		t.Errorf("expected yesterday's item to be prefixed with ✅, got:\n%s", output)
+	}
+	if !strings.Contains(output, "* 🔜 Review code changes\n") {
+		t.Errorf("expected today's item to be prefixed with 🔜, got:\n%s", output)
+	}
+}
+
+func TestStandupSlack_JSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "both sections",
+			content: `# Standup 2025-01-21
+
+## Worked on Yesterday
+
+* [Yesterday](../journal/2025-01-20)
+* Fixed a bug
+- Deployed to staging
+
+## Working on Today
+
+* Review code changes
+`,
+			want: `{"previous":["Fixed a bug","Deployed to staging"],"next":["Review code changes"]}`,
+		},
+		{
+			name: "empty sections",
+			content: `# Standup 2025-01-21
+
+## Worked on Yesterday
+
+## Working on Today
+`,
+			want: `{"previous":[],"next":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standupDir := t.TempDir()
+			today := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+			standupPath := filepath.Join(standupDir, today.Format(notes.DateFormat)+".md")
+			if err := os.WriteFile(standupPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create standup: %v", err)
+			}
+
+			cfg = &config.Config{
+				Standup: config.StandupConfig{
+					Dir:                standupDir,
+					WorkDoneSection:    "Worked on Yesterday",
+					WorkTodaySection:   "Working on Today",
+					LinkPreviousTitles: []string{"Yesterday"},
+					SlackEmoji:         map[string]string{"Working on Today": "🔜"},
+				},
+				SearchWindowDays: 30,
+			}
+
+			standupSlackJSON = true
+			defer func() { standupSlackJSON = false }()
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runStandupSlack(nil, []string{today.Format(notes.DateFormat)})
+
+			w.Close()
+			os.Stdout = oldStdout
+			outputBytes, _ := io.ReadAll(r)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.TrimSpace(string(outputBytes)); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandupSlack_RenamedSections(t *testing.T) {
+	standupDir := t.TempDir()
+
+	today := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	standupPath := filepath.Join(standupDir, today.Format(notes.DateFormat)+".md")
+	standupContent := `# Standup 2025-01-21
+
+## Done
+
+* Fixed a bug
+
+## Working on Today
+
+* Not from the configured section
+
+## Plan
+
+* Review code changes
+`
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	cfg = &config.Config{
+		Standup: config.StandupConfig{
+			Dir:              standupDir,
+			WorkDoneSection:  "Done",
+			WorkTodaySection: "Plan",
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runStandupSlack(nil, []string{today.Format(notes.DateFormat)})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+	output := string(outputBytes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "previous:\n* Fixed a bug\nnext:\n* Review code changes\n"
+	if output != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output)
+	}
+}
+
+func TestStandupSlack_CustomNavigationTitles(t *testing.T) {
+	standupDir := t.TempDir()
+
+	today := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	standupPath := filepath.Join(standupDir, today.Format(notes.DateFormat)+".md")
+	standupContent := `# Standup 2025-01-21
+
+## Worked on Yesterday
+
+* [Prev](2025-01-20)
+* [Daily Log](../journal/2025-01-20)
+* Fixed a bug
+
+## Working on Today
+
+- [Upcoming](2025-01-22)
+* Review code changes
+`
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	cfg = &config.Config{
+		Standup: config.StandupConfig{
+			Dir:                standupDir,
+			WorkDoneSection:    "Worked on Yesterday",
+			WorkTodaySection:   "Working on Today",
+			LinkPreviousTitles: []string{"Prev"},
+			LinkNextTitles:     []string{"Upcoming"},
+		},
+		SearchWindowDays: 30,
+	}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runStandupSlack(nil, []string{today.Format(notes.DateFormat)})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+	output := string(outputBytes)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "previous:\n* Fixed a bug\nnext:\n* Review code changes\n"
+	if output != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output)
+	}
+}
+
+func TestStandupSlack_Clipboard(t *testing.T) {
+	standupDir := t.TempDir()
+
+	today := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	standupPath := filepath.Join(standupDir, today.Format(notes.DateFormat)+".md")
+	standupContent := `# Standup 2025-01-21
+
+## Worked on Yesterday
+
+* Fixed a bug
+
+## Working on Today
+
+* Review code changes
+`
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	cfg = &config.Config{
+		Standup: config.StandupConfig{
+			Dir:              standupDir,
+			WorkDoneSection:  "Worked on Yesterday",
+			WorkTodaySection: "Working on Today",
+		},
+		SearchWindowDays: 30,
+	}
+
+	standupSlackClipboard = true
+	oldCopy := copyToClipboard
+	defer func() {
+		standupSlackClipboard = false
+		copyToClipboard = oldCopy
+	}()
+
+	expected := "previous:\n* Fixed a bug\nnext:\n* Review code changes\n"
+
+	tests := []struct {
+		name    string
+		copyErr error
+	}{
+		{"copied", nil},
+		{"no clipboard tool", util.ErrNoClipboard},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var copied string
+			copyToClipboard = func(text string) error {
+				copied = text
+				return tt.copyErr
+			}
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runStandupSlack(nil, []string{today.Format(notes.DateFormat)})
+
+			w.Close()
+			os.Stdout = oldStdout
+			outputBytes, _ := io.ReadAll(r)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(outputBytes) != expected {
+				t.Errorf("expected stdout:\n%s\ngot:\n%s", expected, outputBytes)
+			}
+			if copied != expected {
+				t.Errorf("expected clipboard:\n%s\ngot:\n%s", expected, copied)
+			}
+		})
+	}
+}
+
+func TestStandupSlack_ExtraSections(t *testing.T) {
+	standupDir := t.TempDir()
+
+	today := time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC)
+	standupPath := filepath.Join(standupDir, today.Format(notes.DateFormat)+".md")
+	standupContent := `# Standup 2025-01-21
+
+## Worked on Yesterday
+
+* Fixed a bug
+
+## Working on Today
+
+* Review code changes
+
+## Blockers
+
+* Waiting on API access
+
+## Meetings
+`
+	if err := os.WriteFile(standupPath, []byte(standupContent), 0644); err != nil {
+		t.Fatalf("failed to create standup: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		json bool
+		want string
+	}{
+		{
+			name: "text",
+			want: "previous:\n* Fixed a bug\nnext:\n* Review code changes\nblockers:\n* Waiting on API access\n",
+		},
+		{
+			name: "json",
+			json: true,
+			want: `{"previous":["Fixed a bug"],"next":["Review code changes"],"extra":{"blockers":["Waiting on API access"]}}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = &config.Config{
+				Standup: config.StandupConfig{
+					Dir:              standupDir,
+					WorkDoneSection:  "Worked on Yesterday",
+					WorkTodaySection: "Working on Today",
+					ExtraSections:    []string{"Blockers", "Meetings", "Missing"},
+				},
+				SearchWindowDays: 30,
+			}
+
+			standupSlackJSON = tt.json
+			defer func() { standupSlackJSON = false }()
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runStandupSlack(nil, []string{today.Format(notes.DateFormat)})
+
+			w.Close()
+			os.Stdout = oldStdout
+			outputBytes, _ := io.ReadAll(r)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(outputBytes) != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, outputBytes)
+			}
+		})
+	}
+}
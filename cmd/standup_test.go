@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestStandupWorkDone_SkipText(t *testing.T) {
+	standupDir := filepath.Join(t.TempDir(), "standup")
+	if err := os.MkdirAll(standupDir, 0755); err != nil {
+		t.Fatalf("failed to create standup dir: %v", err)
+	}
+
+	content := `# Standup 2025-01-15
+
+## Worked on yesterday
+
+* Reviewed PRs
+* TODO: fill in
+* Paired on deploy
+
+## Working on Today
+
+* More work
+`
+	if err := os.WriteFile(filepath.Join(standupDir, "2025-01-15.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write standup: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Standup.Dir = standupDir
+	cfg.Standup.SkipText = []string{"TODO:"}
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runStandupWorkDone(nil, []string{"2025-01-15"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Worked on yesterday\n\n- Reviewed PRs\n- Paired on deploy\n\n"
+	if string(outputBytes) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, outputBytes)
+	}
+}
+
+func TestStandupWorkDone_SkipDrafts(t *testing.T) {
+	standupDir := filepath.Join(t.TempDir(), "standup")
+	if err := os.MkdirAll(standupDir, 0755); err != nil {
+		t.Fatalf("failed to create standup dir: %v", err)
+	}
+
+	files := map[string]string{
+		"2025-01-14.md": "# Standup 2025-01-14\n\n## Worked on yesterday\n\n* Reviewed PRs\n",
+		"2025-01-15.md": "---\nwip: true\n---\n# Standup 2025-01-15\n\n## Worked on yesterday\n\n* Not done yet\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(standupDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write standup: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		skip     bool
+		expected string
+	}{
+		{"drafts included", false, "# Worked on yesterday\n\n- Not done yet\n\n"},
+		{"draft falls back to previous standup", true, "# Worked on yesterday\n\n- Reviewed PRs\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = config.DefaultConfig()
+			cfg.Standup.Dir = standupDir
+			cfg.SkipDraftNotes = tt.skip
+			cfg.DraftField = "wip"
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := runStandupWorkDone(nil, []string{"2025-01-15"})
+
+			w.Close()
+			os.Stdout = oldStdout
+			outputBytes, _ := io.ReadAll(r)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(outputBytes) != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, outputBytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+var statsNoteType string
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [date]",
+	Short: "Print word, link and task counts for a note",
+	Long: `Print statistics for the note on the specified date: words, non-blank
+lines, headings, links and the share of checkbox tasks that are done.
+Frontmatter is ignored.
+
+If no date is provided, uses today's date.
+Date format: YYYY-MM-DD
+
+If the exact date is not found, searches backwards within the configured
+search window (default: 30 days) to find the most recent note.
+
+Examples:
+  za stats                          # Today's journal
+  za stats 2025-01-15               # Journal for a specific date
+  za stats --type standup           # Today's standup`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsNoteType, "type", string(notes.NoteTypeJournal), "Note type (journal or standup)")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	noteType := notes.NoteType(statsNoteType)
+	if !noteType.IsValid() {
+		return fmt.Errorf("invalid note type: %q (expected journal or standup)", statsNoteType)
+	}
+
+	targetDate, err := parseDateArg(args)
+	if err != nil {
+		return err
+	}
+
+	notePath, err := findNoteForStats(targetDate, noteType)
+	if err != nil {
+		return err
+	}
+
+	parser := markdown.NewParser()
+	doc, err := parser.ParseFile(notePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", noteType, err)
+	}
+
+	fmt.Print(formatStats(notePath, doc.Stats()))
+	return nil
+}
+
+// findNoteForStats finds the note of the given type on or before date
+func findNoteForStats(date time.Time, noteType notes.NoteType) (string, error) {
+	if noteType == notes.NoteTypeJournal {
+		journalDirs, err := cfg.JournalDirs()
+		if err != nil {
+			return "", fmt.Errorf("failed to get journal directory: %w", err)
+		}
+		notePath, err := notes.FindNoteByDateMulti(date, noteType, journalDirs, cfg.SearchWindowDays, finderOptions(noteType)...)
+		if err != nil {
+			return "", fmt.Errorf("failed to find journal entry: %w", err)
+		}
+		return notePath, nil
+	}
+
+	standupDir, err := cfg.StandupDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get standup directory: %w", err)
+	}
+	notePath, err := notes.FindNoteByDate(date, noteType, standupDir, cfg.SearchWindowDays, finderOptions(noteType)...)
+	if err != nil {
+		return "", fmt.Errorf("failed to find standup note: %w", err)
+	}
+	return notePath, nil
+}
+
+// formatStats renders a note's statistics as one "name: value" line each
+func formatStats(notePath string, stats markdown.DocumentStats) string {
+	var sb strings.Builder
+	sb.WriteString(notePath + "\n")
+	fmt.Fprintf(&sb, "words:    %d\n", stats.Words)
+	fmt.Fprintf(&sb, "lines:    %d\n", stats.Lines)
+	fmt.Fprintf(&sb, "headings: %d\n", stats.Headings)
+	fmt.Fprintf(&sb, "links:    %d\n", stats.Links)
+	fmt.Fprintf(&sb, "tasks:    %d/%d done (%.0f%%)\n", stats.CompletedTasks, stats.Tasks, stats.CompletionRatio()*100)
+	return sb.String()
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestStats(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	content := `---
+title: Daily Log 2025-01-15
+tags: [journal]
+---
+# Daily Log 2025-01-15
+
+## Goals of the Day
+
+* [x] Fix CI
+* [ ] Write docs
+
+See [Yesterday](2025-01-14).
+`
+	journalPath := filepath.Join(journalDir, "2025-01-15.md")
+	if err := os.WriteFile(journalPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runStats(nil, []string{"2025-01-15"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := journalPath + `
+words:    13
+lines:    5
+headings: 2
+links:    1
+tasks:    1/2 done (50%)
+`
+	if string(outputBytes) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, outputBytes)
+	}
+}
+
+func TestStats_InvalidType(t *testing.T) {
+	statsNoteType = "diary"
+	defer func() { statsNoteType = "journal" }()
+
+	if err := runStats(nil, nil); err == nil {
+		t.Error("expected error for invalid note type")
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+var tagsNoteType string
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List the frontmatter tags used across notes",
+	Long: `List every tag in the frontmatter "tags" field of the notes of one type,
+with the number of notes using it, most used first.
+
+Notes in subdirectories are included, so nested path layouts work. Notes
+without tags are skipped.
+
+Examples:
+  za tags                    # Tags used in journal entries
+  za tags --type standup     # Tags used in standup notes`,
+	Args: cobra.NoArgs,
+	RunE: runTags,
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	tagsCmd.Flags().StringVar(&tagsNoteType, "type", string(notes.NoteTypeJournal), "Note type (journal or standup)")
+}
+
+func runTags(cmd *cobra.Command, args []string) error {
+	noteType := notes.NoteType(tagsNoteType)
+	if !noteType.IsValid() {
+		return fmt.Errorf("invalid note type: %q (expected journal or standup)", tagsNoteType)
+	}
+
+	var dirs []string
+	var err error
+	if noteType == notes.NoteTypeJournal {
+		dirs, err = cfg.JournalDirs()
+	} else {
+		var dir string
+		dir, err = cfg.StandupDir()
+		dirs = []string{dir}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s directory: %w", noteType, err)
+	}
+
+	var paths []string
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		files, err := markdownFiles(dir, true)
+		if err != nil {
+			return err
+		}
+		for _, filePath := range files {
+			if _, err := notes.ParseDateFromFilename(filePath, finderOptions(noteType)...); err == nil {
+				paths = append(paths, filePath)
+			}
+		}
+	}
+
+	counts, err := countTags(paths)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(formatTagCounts(counts))
+	return nil
+}
+
+// countTags returns the number of notes using each frontmatter tag
+func countTags(paths []string) (map[string]int, error) {
+	counts := make(map[string]int)
+	parser := markdown.NewParser()
+	for _, filePath := range paths {
+		doc, err := parser.ParseFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
+		}
+
+		tags, ok := doc.GetMetadataStringSlice("tags")
+		if !ok {
+			continue
+		}
+
+		// Count a tag repeated within a note once
+		seen := make(map[string]bool, len(tags))
+		for _, tag := range tags {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			counts[tag]++
+		}
+	}
+	return counts, nil
+}
+
+// formatTagCounts renders tag counts as "count\ttag" lines, most used first
+// and then alphabetically
+func formatTagCounts(counts map[string]int) string {
+	tags := make([]string, 0, len(counts))
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+
+	var sb strings.Builder
+	for _, tag := range tags {
+		fmt.Fprintf(&sb, "%d\t%s\n", counts[tag], tag)
+	}
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestTags(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(filepath.Join(journalDir, "2025"), 0755); err != nil {
+		t.Fatalf("failed to create journal dir: %v", err)
+	}
+
+	files := map[string]string{
+		"2025-01-13.md":      "---\ntags: [\"journal\", \"acme\"]\n---\n# Log\n",
+		"2025-01-14.md":      "---\ntags:\n  - journal\n  - planning\n  - journal\n---\n# Log\n",
+		"2025-01-15.md":      "---\ntitle: No tags\n---\n# Log\n",
+		"2025-01-16.md":      "# No frontmatter\n",
+		"2025/2025-01-17.md": "---\ntags: [\"journal\", \"acme\"]\n---\n# Log\n",
+		"README.md":          "---\ntags: [\"not-a-note\"]\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	cfg = config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+
+	// Capture stdout
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runTags(nil, nil)
+
+	w.Close()
+	os.Stdout = oldStdout
+	outputBytes, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "3\tjournal\n2\tacme\n1\tplanning\n"
+	if string(outputBytes) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, outputBytes)
+	}
+}
+
+func TestTags_InvalidType(t *testing.T) {
+	tagsNoteType = "diary"
+	defer func() { tagsNoteType = "journal" }()
+
+	if err := runTags(nil, nil); err == nil {
+		t.Error("expected error for invalid note type")
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rdark/za/internal/markdown"
+)
+
+// workDoneJSON is the --json flag shared by journal-work-done and
+// standup-work-done
+var workDoneJSON bool
+
+// workDoneEntry is the JSON form of an extracted work done section
+type workDoneEntry struct {
+	// Date is the note's date, only set when extracting from a month of notes
+	Date    string `json:"date,omitempty"`
+	Heading string `json:"heading"`
+	Content string `json:"content"`
+}
+
+// workDoneEntries converts sections to JSON entries, tagged with date if set
+func workDoneEntries(sections []markdown.Section, date string) []workDoneEntry {
+	entries := make([]workDoneEntry, 0, len(sections))
+	for _, section := range sections {
+		entries = append(entries, workDoneEntry{
+			Date:    date,
+			Heading: section.Heading.Text,
+			Content: section.Render(),
+		})
+	}
+	return entries
+}
+
+// printWorkDoneJSON prints entries as a JSON array, "[]" if there are none
+func printWorkDoneJSON(entries []workDoneEntry) error {
+	if entries == nil {
+		entries = []workDoneEntry{}
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rdark/za/internal/config"
+)
+
+func TestWorkDoneJSON(t *testing.T) {
+	root := t.TempDir()
+	journalDir := filepath.Join(root, "journal")
+	standupDir := filepath.Join(root, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	files := map[string]string{
+		filepath.Join(journalDir, "2025-01-14.md"): "# Daily Log\n\n## Work Completed\n\n* Fixed bug Y\n",
+		filepath.Join(journalDir, "2025-01-15.md"): "# Daily Log\n\n## Work Completed\n\n* Shipped \"X\"\n\n## Worked On\n\n+ Planning\n",
+		filepath.Join(standupDir, "2025-01-15.md"): "# Standup\n\n## Worked on yesterday\n\n* Reviews\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{
+			name: "journal",
+			run:  func() error { return runJournalWorkDone(nil, []string{"2025-01-15"}) },
+			want: `[{"heading":"Work Completed","content":"- Shipped \"X\""},{"heading":"Worked On","content":"- Planning"}]` + "\n",
+		},
+		{
+			name: "journal month",
+			run:  func() error { return runJournalWorkDone(nil, []string{"2025-01"}) },
+			want: `[{"date":"2025-01-14","heading":"Work Completed","content":"- Fixed bug Y"},` +
+				`{"date":"2025-01-15","heading":"Work Completed","content":"- Shipped \"X\""},` +
+				`{"date":"2025-01-15","heading":"Worked On","content":"- Planning"}]` + "\n",
+		},
+		{
+			name: "journal month without notes",
+			run:  func() error { return runJournalWorkDone(nil, []string{"2024-06"}) },
+			want: "[]\n",
+		},
+		{
+			name: "standup",
+			run:  func() error { return runStandupWorkDone(nil, []string{"2025-01-15"}) },
+			want: `[{"heading":"Worked on yesterday","content":"- Reviews"}]` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = config.DefaultConfig()
+			cfg.Journal.Dir = journalDir
+			cfg.Standup.Dir = standupDir
+
+			workDoneJSON = true
+			defer func() { workDoneJSON = false }()
+
+			// Capture stdout, silencing the stderr notes about missing entries
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+			devNull, _ := os.Open(os.DevNull)
+			os.Stderr = devNull
+
+			err := tt.run()
+
+			w.Close()
+			devNull.Close()
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+			outputBytes, _ := io.ReadAll(r)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(outputBytes) != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, outputBytes)
+			}
+		})
+	}
+}
@@ -3,7 +3,13 @@ package config
 import (
 	"fmt"
 	"os"
+	"os/user"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -13,39 +19,182 @@ type Config struct {
 	Journal          JournalConfig `mapstructure:"journal"`
 	Standup          StandupConfig `mapstructure:"standup"`
 	GitHub           GitHubConfig  `mapstructure:"github"`
+	VaultRoot        string        `mapstructure:"vault_root"`
+	FilenameFormat   string        `mapstructure:"filename_format"`
 	SearchWindowDays int           `mapstructure:"search_window_days"`
+	SkipEmptyNotes   bool          `mapstructure:"skip_empty_notes"`
 	CompanyTag       string        `mapstructure:"company_tag"`
+
+	// SearchWindowBackwardDays and SearchWindowForwardDays limit how far
+	// link resolution searches for earlier and later notes. Zero means
+	// SearchWindowDays.
+	SearchWindowBackwardDays int `mapstructure:"search_window_backward_days"`
+	SearchWindowForwardDays  int `mapstructure:"search_window_forward_days"`
+
+	// CompanyTags lists several company tags to add, e.g. when splitting
+	// time across clients. A single string is also accepted. When set, it
+	// replaces CompanyTag.
+	CompanyTags []string `mapstructure:"company_tags"`
+
+	// CompanyTagDays are the days of the week (e.g. "Mon" or "Monday") on
+	// which generated notes get the company tag. Empty means every day.
+	CompanyTagDays []string `mapstructure:"company_tag_days"`
+
+	// Holidays are dates (YYYY-MM-DD) expected to have no notes. They are
+	// only used for reporting, e.g. to explain a gap skipped by a link.
+	Holidays []string `mapstructure:"holidays"`
+
+	// BulletStyle is the list marker ("-" or "*") used for items za writes
+	// into notes. Empty means DefaultBulletStyle.
+	BulletStyle string `mapstructure:"bullet_style"`
+
+	// SkipDraftNotes leaves notes whose DraftField frontmatter field is true
+	// out of work-done summaries
+	SkipDraftNotes bool `mapstructure:"skip_draft_notes"`
+
+	// DraftField is the frontmatter field that marks a draft note. Empty
+	// means DefaultDraftField.
+	DraftField string `mapstructure:"draft_field"`
+
+	// Timezone is the IANA time zone (e.g. "Australia/Sydney") that decides
+	// which day is today. Empty means the system's local zone.
+	Timezone string `mapstructure:"timezone"`
+
+	// Source records where Load found each value; it is not read from the
+	// config file
+	Source Source `mapstructure:"-"`
 }
 
+// Work done section ordering modes
+const (
+	// WorkDoneOrderDocument returns work done sections in the order they appear in the note
+	WorkDoneOrderDocument = "document"
+
+	// WorkDoneOrderConfig returns work done sections in the order of work_done_sections
+	WorkDoneOrderConfig = "config"
+)
+
+// DefaultFilenameFormat is the Go time layout used for note filenames
+// (without the .md extension) when none is configured
+const DefaultFilenameFormat = "2006-01-02"
+
+// DefaultDuePattern matches goal due-date annotations like "(by Wed)" or
+// "(by 2025-01-15)"; the first capture group is the due value
+const DefaultDuePattern = `\(by\s+([^)]+)\)`
+
+// Default journal goals section headings
+const (
+	DefaultDayGoalsSection  = "Goals of the Day"
+	DefaultWeekGoalsSection = "Goals of the Week"
+)
+
+// DefaultBulletStyle is the list marker used for generated items
+const DefaultBulletStyle = "-"
+
+// DefaultDraftField is the frontmatter field that marks a draft note
+const DefaultDraftField = "draft"
+
 // JournalConfig contains configuration for journal notes
 type JournalConfig struct {
 	Dir                string        `mapstructure:"dir"`
+	FilenameFormat     string        `mapstructure:"filename_format"`
+	PathLayout         string        `mapstructure:"path_layout"`
 	WorkDoneSections   []string      `mapstructure:"work_done_sections"`
+	WorkDoneOrder      string        `mapstructure:"work_done_order"`
 	SkipText           []string      `mapstructure:"skip_text"`
 	LinkPreviousTitles []string      `mapstructure:"link_previous_titles"`
 	LinkNextTitles     []string      `mapstructure:"link_next_titles"`
+	DuePattern         string        `mapstructure:"due_pattern"`
 	Create             CreateCommand `mapstructure:"create"`
+
+	// Templates are named alternatives to Create, selected with
+	// generate-journal --template. Names are case-insensitive.
+	Templates map[string]CreateCommand `mapstructure:"templates"`
+
+	// PostCreate is run after generate-journal has created and updated a
+	// note, e.g. to commit it
+	PostCreate CreateCommand `mapstructure:"post_create"`
+
+	// LinkPreviousWeekTitles and LinkNextWeekTitles are link titles that
+	// point to the note about a week before or after, e.g. "Last Week"
+	LinkPreviousWeekTitles []string `mapstructure:"link_previous_week_titles"`
+	LinkNextWeekTitles     []string `mapstructure:"link_next_week_titles"`
+
+	// Dirs lists additional journal directories (e.g. work and personal) to
+	// search after Dir, in priority order. New journals are created in Dir, or
+	// the first of Dirs if Dir is empty. Dir has no default when Dirs is set.
+	Dirs []string `mapstructure:"dirs"`
+
+	// DayGoalsSection and WeekGoalsSection are the headings of the daily and
+	// weekly goals sections. Empty means DefaultDayGoalsSection and
+	// DefaultWeekGoalsSection.
+	DayGoalsSection  string `mapstructure:"day_goals_section"`
+	WeekGoalsSection string `mapstructure:"week_goals_section"`
+
+	// EnsureEmptyGoalsSection controls whether generate-journal adds an empty
+	// daily goals section when there are no unfinished goals to copy.
+	// Nil means the default (true).
+	EnsureEmptyGoalsSection *bool `mapstructure:"ensure_empty_goals_section"`
 }
 
 // StandupConfig contains configuration for standup notes
 type StandupConfig struct {
 	Dir                string        `mapstructure:"dir"`
+	FilenameFormat     string        `mapstructure:"filename_format"`
+	PathLayout         string        `mapstructure:"path_layout"`
 	WorkDoneSection    string        `mapstructure:"work_done_section"`
+	WorkTodaySection   string        `mapstructure:"work_today_section"`
 	SkipText           []string      `mapstructure:"skip_text"`
 	LinkPreviousTitles []string      `mapstructure:"link_previous_titles"`
 	LinkNextTitles     []string      `mapstructure:"link_next_titles"`
 	Create             CreateCommand `mapstructure:"create"`
+
+	// PostCreate works as in JournalConfig, for generate-standup
+	PostCreate CreateCommand `mapstructure:"post_create"`
+
+	// SlackEmoji maps a section heading (case-insensitive) to an emoji that
+	// standup-slack prefixes to each item from that section
+	SlackEmoji map[string]string `mapstructure:"slack_emoji"`
+
+	// ExtraSections are additional section headings (e.g. "Blockers") that
+	// standup-slack prints after today's work, each under its own label
+	ExtraSections []string `mapstructure:"extra_sections"`
+
+	// LinkPreviousWeekTitles and LinkNextWeekTitles work as in JournalConfig
+	LinkPreviousWeekTitles []string `mapstructure:"link_previous_week_titles"`
+	LinkNextWeekTitles     []string `mapstructure:"link_next_week_titles"`
 }
 
+// DefaultCreateTimeoutSeconds is how long a create command may run when no
+// timeout is configured
+const DefaultCreateTimeoutSeconds = 30
+
 // CreateCommand contains the command to create new notes
 type CreateCommand struct {
 	Cmd string `mapstructure:"cmd"`
+
+	// TimeoutSeconds is how long the command may run before it is killed.
+	// Zero means DefaultCreateTimeoutSeconds, or in journal.templates the
+	// journal.create timeout.
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+}
+
+// Timeout returns how long the command may run, falling back to
+// DefaultCreateTimeoutSeconds if no timeout is configured
+func (c CreateCommand) Timeout() time.Duration {
+	if c.TimeoutSeconds <= 0 {
+		return DefaultCreateTimeoutSeconds * time.Second
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
 }
 
 // GitHubConfig contains configuration for GitHub integration
 type GitHubConfig struct {
 	Enabled bool   `mapstructure:"enabled"`
 	Org     string `mapstructure:"org"`
+
+	// LookbackDays is how many days back to look for open, unreviewed PRs
+	LookbackDays int `mapstructure:"lookback_days"`
 }
 
 // DefaultConfig returns a configuration with sensible defaults
@@ -53,26 +202,61 @@ func DefaultConfig() *Config {
 	return &Config{
 		Journal: JournalConfig{
 			Dir:                "./journal",
+			FilenameFormat:     "",
+			PathLayout:         "",
 			WorkDoneSections:   []string{"work completed", "worked on"},
+			WorkDoneOrder:      WorkDoneOrderDocument,
 			SkipText:           []string{},
-			LinkPreviousTitles: []string{"Yesterday", "Previous", "Last Week"},
-			LinkNextTitles:     []string{"Tomorrow", "Next", "Next Week"},
-			Create:             CreateCommand{Cmd: ""},
+			LinkPreviousTitles: []string{"Yesterday", "Previous"},
+			LinkNextTitles:     []string{"Tomorrow", "Next"},
+			DuePattern:         DefaultDuePattern,
+			Create:             CreateCommand{Cmd: "", TimeoutSeconds: DefaultCreateTimeoutSeconds},
+			Templates:          map[string]CreateCommand{},
+			PostCreate:         CreateCommand{Cmd: "", TimeoutSeconds: DefaultCreateTimeoutSeconds},
+
+			LinkPreviousWeekTitles: []string{"Last Week"},
+			LinkNextWeekTitles:     []string{"Next Week"},
+			Dirs:                   []string{},
+
+			DayGoalsSection:  DefaultDayGoalsSection,
+			WeekGoalsSection: DefaultWeekGoalsSection,
+
+			EnsureEmptyGoalsSection: boolPtr(true),
 		},
 		Standup: StandupConfig{
 			Dir:                "./standup",
+			FilenameFormat:     "",
+			PathLayout:         "",
 			WorkDoneSection:    "Worked on yesterday",
+			WorkTodaySection:   "Working on Today",
 			SkipText:           []string{},
-			LinkPreviousTitles: []string{"Yesterday", "Previous", "Last Week"},
-			LinkNextTitles:     []string{"Tomorrow", "Next", "Next Week"},
-			Create:             CreateCommand{Cmd: ""},
+			LinkPreviousTitles: []string{"Yesterday", "Previous"},
+			LinkNextTitles:     []string{"Tomorrow", "Next"},
+			Create:             CreateCommand{Cmd: "", TimeoutSeconds: DefaultCreateTimeoutSeconds},
+			PostCreate:         CreateCommand{Cmd: "", TimeoutSeconds: DefaultCreateTimeoutSeconds},
+			SlackEmoji:         map[string]string{},
+			ExtraSections:      []string{},
+
+			LinkPreviousWeekTitles: []string{"Last Week"},
+			LinkNextWeekTitles:     []string{"Next Week"},
 		},
 		GitHub: GitHubConfig{
-			Enabled: false,
-			Org:     "",
+			Enabled:      false,
+			Org:          "",
+			LookbackDays: 7,
 		},
+		VaultRoot:        "",
+		FilenameFormat:   DefaultFilenameFormat,
 		SearchWindowDays: 30,
+		SkipEmptyNotes:   false,
 		CompanyTag:       "acme",
+		CompanyTags:      []string{},
+		CompanyTagDays:   []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+		Holidays:         []string{},
+		BulletStyle:      DefaultBulletStyle,
+		SkipDraftNotes:   false,
+		DraftField:       DefaultDraftField,
+		Timezone:         "",
 	}
 }
 
@@ -84,21 +268,37 @@ func Load(configPath string) (*Config, error) {
 	// Set defaults
 	setDefaults(v)
 
-	// Set environment variable prefix
+	// Set environment variable prefix, binding nested keys such as
+	// journal.dir to ZA_JOURNAL_DIR
 	v.SetEnvPrefix("ZA")
+	v.SetEnvKeyReplacer(envKeyReplacer)
 	v.AutomaticEnv()
 
 	// Load from config file if provided
+	var parentDirs []string
 	if configPath != "" {
 		v.SetConfigFile(configPath)
 	} else {
-		// Look for .za.yaml in current directory and home directory
+		// Look for .za.yaml in current directory, its parents, and home directory
 		v.SetConfigName(".za")
 		v.SetConfigType("yaml")
 		v.AddConfigPath(".")
 
+		home, homeErr := os.UserHomeDir()
+
+		// Add parent directories (up to, but not including, home)
+		if cwd, err := os.Getwd(); err == nil {
+			for dir := filepath.Dir(cwd); dir != cwd; cwd, dir = dir, filepath.Dir(dir) {
+				if homeErr == nil && dir == home {
+					break
+				}
+				parentDirs = append(parentDirs, dir)
+				v.AddConfigPath(dir)
+			}
+		}
+
 		// Add home directory
-		if home, err := os.UserHomeDir(); err == nil {
+		if homeErr == nil {
 			v.AddConfigPath(home)
 		}
 	}
@@ -111,11 +311,26 @@ func Load(configPath string) (*Config, error) {
 		// Config file not found is OK, we'll use defaults
 	}
 
+	// journal.dir only defaults to ./journal without journal.dirs, so that
+	// journal.dirs alone lists every journal directory
+	if settingSource(v, "journal.dir") == SourceDefault && len(v.GetStringSlice("journal.dirs")) > 0 {
+		v.SetDefault("journal.dir", "")
+	}
+
+	// Unquoted YAML dates are read as times rather than strings
+	normalizeDateList(v, "holidays")
+
 	// Unmarshal into config struct
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	cfg.Source = loadSource(v)
+
+	// Resolve the vault root
+	if err := cfg.resolveVaultRoot(v.ConfigFileUsed(), parentDirs); err != nil {
+		return nil, err
+	}
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
@@ -130,30 +345,72 @@ func setDefaults(v *viper.Viper) {
 	defaults := DefaultConfig()
 
 	v.SetDefault("journal.dir", defaults.Journal.Dir)
+	v.SetDefault("journal.dirs", defaults.Journal.Dirs)
+	v.SetDefault("journal.filename_format", defaults.Journal.FilenameFormat)
+	v.SetDefault("journal.path_layout", defaults.Journal.PathLayout)
 	v.SetDefault("journal.work_done_sections", defaults.Journal.WorkDoneSections)
+	v.SetDefault("journal.work_done_order", defaults.Journal.WorkDoneOrder)
 	v.SetDefault("journal.skip_text", defaults.Journal.SkipText)
 	v.SetDefault("journal.link_previous_titles", defaults.Journal.LinkPreviousTitles)
 	v.SetDefault("journal.link_next_titles", defaults.Journal.LinkNextTitles)
+	v.SetDefault("journal.link_previous_week_titles", defaults.Journal.LinkPreviousWeekTitles)
+	v.SetDefault("journal.link_next_week_titles", defaults.Journal.LinkNextWeekTitles)
+	v.SetDefault("journal.due_pattern", defaults.Journal.DuePattern)
 	v.SetDefault("journal.create.cmd", defaults.Journal.Create.Cmd)
+	v.SetDefault("journal.create.timeout_seconds", defaults.Journal.Create.TimeoutSeconds)
+	v.SetDefault("journal.templates", defaults.Journal.Templates)
+	v.SetDefault("journal.post_create.cmd", defaults.Journal.PostCreate.Cmd)
+	v.SetDefault("journal.post_create.timeout_seconds", defaults.Journal.PostCreate.TimeoutSeconds)
+	v.SetDefault("journal.day_goals_section", defaults.Journal.DayGoalsSection)
+	v.SetDefault("journal.week_goals_section", defaults.Journal.WeekGoalsSection)
+	v.SetDefault("journal.ensure_empty_goals_section", *defaults.Journal.EnsureEmptyGoalsSection)
 
 	v.SetDefault("standup.dir", defaults.Standup.Dir)
+	v.SetDefault("standup.filename_format", defaults.Standup.FilenameFormat)
+	v.SetDefault("standup.path_layout", defaults.Standup.PathLayout)
 	v.SetDefault("standup.work_done_section", defaults.Standup.WorkDoneSection)
+	v.SetDefault("standup.work_today_section", defaults.Standup.WorkTodaySection)
 	v.SetDefault("standup.skip_text", defaults.Standup.SkipText)
 	v.SetDefault("standup.link_previous_titles", defaults.Standup.LinkPreviousTitles)
 	v.SetDefault("standup.link_next_titles", defaults.Standup.LinkNextTitles)
+	v.SetDefault("standup.link_previous_week_titles", defaults.Standup.LinkPreviousWeekTitles)
+	v.SetDefault("standup.link_next_week_titles", defaults.Standup.LinkNextWeekTitles)
 	v.SetDefault("standup.create.cmd", defaults.Standup.Create.Cmd)
+	v.SetDefault("standup.create.timeout_seconds", defaults.Standup.Create.TimeoutSeconds)
+	v.SetDefault("standup.post_create.cmd", defaults.Standup.PostCreate.Cmd)
+	v.SetDefault("standup.post_create.timeout_seconds", defaults.Standup.PostCreate.TimeoutSeconds)
+	v.SetDefault("standup.slack_emoji", defaults.Standup.SlackEmoji)
+	v.SetDefault("standup.extra_sections", defaults.Standup.ExtraSections)
 
 	v.SetDefault("github.enabled", defaults.GitHub.Enabled)
 	v.SetDefault("github.org", defaults.GitHub.Org)
+	v.SetDefault("github.lookback_days", defaults.GitHub.LookbackDays)
 
+	v.SetDefault("vault_root", defaults.VaultRoot)
+	v.SetDefault("filename_format", defaults.FilenameFormat)
 	v.SetDefault("search_window_days", defaults.SearchWindowDays)
+	v.SetDefault("search_window_backward_days", defaults.SearchWindowBackwardDays)
+	v.SetDefault("search_window_forward_days", defaults.SearchWindowForwardDays)
+	v.SetDefault("skip_empty_notes", defaults.SkipEmptyNotes)
 	v.SetDefault("company_tag", defaults.CompanyTag)
+	v.SetDefault("company_tags", defaults.CompanyTags)
+	v.SetDefault("company_tag_days", defaults.CompanyTagDays)
+	v.SetDefault("holidays", defaults.Holidays)
+	v.SetDefault("bullet_style", defaults.BulletStyle)
+	v.SetDefault("skip_draft_notes", defaults.SkipDraftNotes)
+	v.SetDefault("draft_field", defaults.DraftField)
+	v.SetDefault("timezone", defaults.Timezone)
 }
 
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
-	if c.Journal.Dir == "" {
-		return fmt.Errorf("journal.dir is required")
+	if c.Journal.Dir == "" && len(c.Journal.Dirs) == 0 {
+		return fmt.Errorf("journal.dir is required (or set journal.dirs)")
+	}
+	for i, dir := range c.Journal.Dirs {
+		if dir == "" {
+			return fmt.Errorf("journal.dirs[%d] must not be empty", i)
+		}
 	}
 	if c.Standup.Dir == "" {
 		return fmt.Errorf("standup.dir is required")
@@ -161,29 +418,538 @@ func (c *Config) Validate() error {
 	if c.SearchWindowDays <= 0 {
 		return fmt.Errorf("search_window_days must be positive, got %d", c.SearchWindowDays)
 	}
+	if c.SearchWindowBackwardDays < 0 {
+		return fmt.Errorf("search_window_backward_days must be positive, got %d", c.SearchWindowBackwardDays)
+	}
+	if c.SearchWindowForwardDays < 0 {
+		return fmt.Errorf("search_window_forward_days must be positive, got %d", c.SearchWindowForwardDays)
+	}
 	if len(c.Journal.WorkDoneSections) == 0 {
 		return fmt.Errorf("journal.work_done_sections must have at least one section")
 	}
+	switch c.Journal.WorkDoneOrder {
+	case "", WorkDoneOrderDocument, WorkDoneOrderConfig:
+	default:
+		return fmt.Errorf("journal.work_done_order must be %q or %q, got %q",
+			WorkDoneOrderDocument, WorkDoneOrderConfig, c.Journal.WorkDoneOrder)
+	}
+	if c.Journal.DuePattern != "" {
+		re, err := regexp.Compile(c.Journal.DuePattern)
+		if err != nil {
+			return fmt.Errorf("journal.due_pattern is not a valid regular expression: %w", err)
+		}
+		if re.NumSubexp() < 1 {
+			return fmt.Errorf("journal.due_pattern must have a capture group for the due value")
+		}
+	}
+	for _, t := range []struct {
+		key     string
+		timeout int
+	}{
+		{"journal.create.timeout_seconds", c.Journal.Create.TimeoutSeconds},
+		{"standup.create.timeout_seconds", c.Standup.Create.TimeoutSeconds},
+		{"journal.post_create.timeout_seconds", c.Journal.PostCreate.TimeoutSeconds},
+		{"standup.post_create.timeout_seconds", c.Standup.PostCreate.TimeoutSeconds},
+	} {
+		if t.timeout < 0 {
+			return fmt.Errorf("%s must be positive, got %d", t.key, t.timeout)
+		}
+	}
+	for name, tmpl := range c.Journal.Templates {
+		if tmpl.Cmd == "" {
+			return fmt.Errorf("journal.templates.%s.cmd must not be empty", name)
+		}
+		if tmpl.TimeoutSeconds < 0 {
+			return fmt.Errorf("journal.templates.%s.timeout_seconds must be positive, got %d", name, tmpl.TimeoutSeconds)
+		}
+	}
+	for _, f := range []struct{ key, layout string }{
+		{"filename_format", c.FilenameFormat},
+		{"journal.filename_format", c.Journal.FilenameFormat},
+		{"standup.filename_format", c.Standup.FilenameFormat},
+	} {
+		if err := validateFilenameFormat(f.layout); err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+	}
+	for _, l := range []struct{ key, layout string }{
+		{"journal.path_layout", c.Journal.PathLayout},
+		{"standup.path_layout", c.Standup.PathLayout},
+	} {
+		if err := validatePathLayout(l.layout); err != nil {
+			return fmt.Errorf("%s: %w", l.key, err)
+		}
+	}
+	for _, day := range c.CompanyTagDays {
+		if _, err := parseWeekday(day); err != nil {
+			return fmt.Errorf("company_tag_days: %w", err)
+		}
+	}
+	for _, holiday := range c.Holidays {
+		if _, err := time.Parse(holidayFormat, strings.TrimSpace(holiday)); err != nil {
+			return fmt.Errorf("holidays: %q is not a date (expected YYYY-MM-DD)", holiday)
+		}
+	}
+	switch c.BulletStyle {
+	case "", "-", "*":
+	default:
+		return fmt.Errorf("bullet_style must be \"-\" or \"*\", got %q", c.BulletStyle)
+	}
+	if _, err := time.LoadLocation(c.Timezone); err != nil {
+		return fmt.Errorf("timezone: %q is not a known time zone (expected e.g. \"Europe/London\")", c.Timezone)
+	}
 	if c.GitHub.Enabled && c.GitHub.Org == "" {
 		return fmt.Errorf("github.org is required when github.enabled is true")
 	}
+	if c.GitHub.Enabled && c.GitHub.LookbackDays < 0 {
+		return fmt.Errorf("github.lookback_days must not be negative, got %d", c.GitHub.LookbackDays)
+	}
 	return nil
 }
 
-// ExpandPath expands relative paths to absolute paths
+// validateFilenameFormat checks that a filename format is a Go time layout
+// that round-trips a full date. An empty format is valid (the default is used).
+func validateFilenameFormat(layout string) error {
+	if layout == "" {
+		return nil
+	}
+	if strings.ContainsAny(layout, `/\`) {
+		return fmt.Errorf("filename format %q must not contain path separators", layout)
+	}
+
+	reference := time.Date(2025, time.November, 23, 0, 0, 0, 0, time.UTC)
+	parsed, err := time.Parse(layout, reference.Format(layout))
+	if err != nil || !parsed.Equal(reference) {
+		return fmt.Errorf("filename format %q must contain a year, month and day layout (e.g. %q)",
+			layout, DefaultFilenameFormat)
+	}
+	return nil
+}
+
+// validatePathLayout checks that a subdirectory layout only uses the {year},
+// {month} and {day} placeholders and stays inside the notes directory.
+// An empty layout is valid (notes live directly in the directory).
+func validatePathLayout(layout string) error {
+	if layout == "" {
+		return nil
+	}
+	if filepath.IsAbs(layout) {
+		return fmt.Errorf("path layout %q must be relative", layout)
+	}
+
+	stripped := strings.NewReplacer("{year}", "", "{month}", "", "{day}", "").Replace(layout)
+	if strings.ContainsAny(stripped, "{}") {
+		return fmt.Errorf("path layout %q has an unknown placeholder (expected {year}, {month} or {day})", layout)
+	}
+	for _, part := range strings.Split(filepath.ToSlash(layout), "/") {
+		if part == ".." {
+			return fmt.Errorf("path layout %q must not contain ..", layout)
+		}
+	}
+	return nil
+}
+
+// PathLayoutFor returns the subdirectory layout for a note type ("journal" or
+// "standup"), or an empty string for the flat layout
+func (c *Config) PathLayoutFor(noteType string) string {
+	switch noteType {
+	case "journal":
+		return c.Journal.PathLayout
+	case "standup":
+		return c.Standup.PathLayout
+	}
+	return ""
+}
+
+// FilenameFormatFor returns the filename format for a note type ("journal" or
+// "standup"): the per-type override if set, then filename_format, then
+// DefaultFilenameFormat
+func (c *Config) FilenameFormatFor(noteType string) string {
+	switch noteType {
+	case "journal":
+		if c.Journal.FilenameFormat != "" {
+			return c.Journal.FilenameFormat
+		}
+	case "standup":
+		if c.Standup.FilenameFormat != "" {
+			return c.Standup.FilenameFormat
+		}
+	}
+	if c.FilenameFormat != "" {
+		return c.FilenameFormat
+	}
+	return DefaultFilenameFormat
+}
+
+// FilenameFormats returns the distinct filename formats of all note types,
+// for recognising links to notes of any type
+func (c *Config) FilenameFormats() []string {
+	var formats []string
+	for _, noteType := range []string{"journal", "standup"} {
+		if format := c.FilenameFormatFor(noteType); !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+// DayGoalsHeading returns the daily goals section heading, falling back to
+// DefaultDayGoalsSection if none is configured
+func (c *JournalConfig) DayGoalsHeading() string {
+	if c.DayGoalsSection == "" {
+		return DefaultDayGoalsSection
+	}
+	return c.DayGoalsSection
+}
+
+// WeekGoalsHeading returns the weekly goals section heading, falling back to
+// DefaultWeekGoalsSection if none is configured
+func (c *JournalConfig) WeekGoalsHeading() string {
+	if c.WeekGoalsSection == "" {
+		return DefaultWeekGoalsSection
+	}
+	return c.WeekGoalsSection
+}
+
+// ShouldEnsureEmptyGoalsSection reports whether an empty daily goals
+// section should be added when there are no goals to copy (default true)
+func (c *JournalConfig) ShouldEnsureEmptyGoalsSection() bool {
+	if c.EnsureEmptyGoalsSection == nil {
+		return true
+	}
+	return *c.EnsureEmptyGoalsSection
+}
+
+// DueRegexp returns the compiled due-date annotation pattern, falling back to
+// DefaultDuePattern if none is configured
+func (c *JournalConfig) DueRegexp() (*regexp.Regexp, error) {
+	pattern := c.DuePattern
+	if pattern == "" {
+		pattern = DefaultDuePattern
+	}
+	return regexp.Compile(pattern)
+}
+
+// BackwardWindowDays returns how many days link resolution searches back for
+// an earlier note, falling back to SearchWindowDays
+func (c *Config) BackwardWindowDays() int {
+	if c.SearchWindowBackwardDays > 0 {
+		return c.SearchWindowBackwardDays
+	}
+	return c.SearchWindowDays
+}
+
+// ForwardWindowDays returns how many days link resolution searches ahead for
+// a later note, falling back to SearchWindowDays
+func (c *Config) ForwardWindowDays() int {
+	if c.SearchWindowForwardDays > 0 {
+		return c.SearchWindowForwardDays
+	}
+	return c.SearchWindowDays
+}
+
+// CreateCommandFor returns the create command for the named template, or
+// Create if name is empty. Template names are matched ignoring case.
+func (c *JournalConfig) CreateCommandFor(name string) (CreateCommand, error) {
+	if name == "" {
+		return c.Create, nil
+	}
+
+	names := make([]string, 0, len(c.Templates))
+	for templateName, tmpl := range c.Templates {
+		if strings.EqualFold(templateName, name) {
+			if tmpl.TimeoutSeconds == 0 {
+				tmpl.TimeoutSeconds = c.Create.TimeoutSeconds
+			}
+			return tmpl, nil
+		}
+		names = append(names, templateName)
+	}
+	if len(names) == 0 {
+		return CreateCommand{}, fmt.Errorf("unknown journal template %q (no journal.templates configured)", name)
+	}
+	sort.Strings(names)
+	return CreateCommand{}, fmt.Errorf("unknown journal template %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// CompanyTagNames returns the company names to tag generated notes with:
+// CompanyTags if set, otherwise CompanyTag (if set)
+func (c *Config) CompanyTagNames() []string {
+	var names []string
+	for _, name := range c.CompanyTags {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 && strings.TrimSpace(c.CompanyTag) != "" {
+		names = append(names, strings.TrimSpace(c.CompanyTag))
+	}
+	return names
+}
+
+// CompanyTagOn reports whether generated notes for date should get the
+// company tag: on any of CompanyTagDays, or every day if none are set
+func (c *Config) CompanyTagOn(date time.Time) bool {
+	if len(c.CompanyTagDays) == 0 {
+		return true
+	}
+	for _, day := range c.CompanyTagDays {
+		if weekday, err := parseWeekday(day); err == nil && weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// Bullet returns the list marker for generated items, falling back to
+// DefaultBulletStyle if none is configured
+func (c *Config) Bullet() string {
+	if c.BulletStyle == "" {
+		return DefaultBulletStyle
+	}
+	return c.BulletStyle
+}
+
+// DraftFieldName returns the frontmatter field that marks a draft note,
+// falling back to DefaultDraftField if none is configured
+func (c *Config) DraftFieldName() string {
+	if c.DraftField == "" {
+		return DefaultDraftField
+	}
+	return c.DraftField
+}
+
+// Location returns the configured Timezone, falling back to the local zone if
+// none is configured or it can't be loaded
+func (c *Config) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// holidayFormat is the layout of the dates in Holidays
+const holidayFormat = "2006-01-02"
+
+// normalizeDateList rewrites the dates in the config file list at key as
+// YYYY-MM-DD strings
+func normalizeDateList(v *viper.Viper, key string) {
+	if !v.InConfig(key) {
+		return
+	}
+	raw, ok := v.Get(key).([]any)
+	if !ok {
+		return
+	}
+
+	dates := make([]string, 0, len(raw))
+	for _, value := range raw {
+		if t, ok := value.(time.Time); ok {
+			dates = append(dates, t.Format(holidayFormat))
+		} else {
+			dates = append(dates, fmt.Sprint(value))
+		}
+	}
+	v.Set(key, dates)
+}
+
+// IsHoliday reports whether date is one of Holidays
+func (c *Config) IsHoliday(date time.Time) bool {
+	day := date.Format(holidayFormat)
+	for _, holiday := range c.Holidays {
+		if strings.TrimSpace(holiday) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWeekday parses a day of the week as a full or three-letter English
+// name, ignoring case
+func parseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || name == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day of the week %q (expected e.g. \"Mon\" or \"Monday\")", name)
+}
+
+// SlackEmojiFor returns the configured standup-slack emoji for a section
+// heading, or an empty string if none is configured
+func (c *StandupConfig) SlackEmojiFor(heading string) string {
+	for section, emoji := range c.SlackEmoji {
+		if strings.EqualFold(strings.TrimSpace(section), strings.TrimSpace(heading)) {
+			return emoji
+		}
+	}
+	return ""
+}
+
+// resolveVaultRoot makes the vault root absolute. A relative vault_root is
+// resolved against the directory of the config file. If vault_root is unset and
+// the config file was found in a parent of the working directory, that
+// directory becomes the vault root.
+func (c *Config) resolveVaultRoot(configFile string, parentDirs []string) error {
+	configDir := ""
+	if configFile != "" {
+		absConfig, err := filepath.Abs(configFile)
+		if err != nil {
+			return fmt.Errorf("failed to resolve config file path: %w", err)
+		}
+		configDir = filepath.Dir(absConfig)
+	}
+
+	if c.VaultRoot == "" {
+		for _, dir := range parentDirs {
+			if dir == configDir {
+				c.VaultRoot = configDir
+				break
+			}
+		}
+		return nil
+	}
+
+	if !filepath.IsAbs(c.VaultRoot) && configDir != "" {
+		c.VaultRoot = filepath.Join(configDir, c.VaultRoot)
+	}
+
+	root, err := filepath.Abs(c.VaultRoot)
+	if err != nil {
+		return fmt.Errorf("failed to resolve vault_root: %w", err)
+	}
+	c.VaultRoot = root
+	return nil
+}
+
+// ExpandPath expands relative paths to absolute paths.
+// Environment variables ($VAR or ${VAR}) and a leading ~ or ~user are
+// expanded first.
+// Relative paths are resolved against the vault root if one is configured,
+// otherwise against the current working directory.
 func (c *Config) ExpandPath(path string) (string, error) {
+	path, err := expandHome(os.ExpandEnv(path))
+	if err != nil {
+		return "", err
+	}
 	if filepath.IsAbs(path) {
-		return path, nil
+		return filepath.Clean(path), nil
+	}
+	if c.VaultRoot != "" {
+		return filepath.Abs(filepath.Join(c.VaultRoot, path))
 	}
 	return filepath.Abs(path)
 }
 
-// JournalDir returns the absolute path to the journal directory
+// expandHome replaces a leading ~ with the current user's home directory, or
+// ~user with that user's
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+
+	name, rest, _ := strings.Cut(path[1:], "/")
+	var home string
+	if name == "" {
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand ~ in %q: %w", path, err)
+		}
+		home = dir
+	} else {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to expand ~%s in %q: %w", name, path, err)
+		}
+		home = u.HomeDir
+	}
+	return filepath.Join(home, rest), nil
+}
+
+// JournalDir returns the absolute path to the primary journal directory, where
+// new journals are created
 func (c *Config) JournalDir() (string, error) {
+	if c.Journal.Dir == "" && len(c.Journal.Dirs) > 0 {
+		return c.ExpandPath(c.Journal.Dirs[0])
+	}
 	return c.ExpandPath(c.Journal.Dir)
 }
 
+// JournalDirs returns the absolute paths of all journal directories to search,
+// in priority order: journal.dir followed by journal.dirs
+func (c *Config) JournalDirs() ([]string, error) {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, dir := range append([]string{c.Journal.Dir}, c.Journal.Dirs...) {
+		if dir == "" {
+			continue
+		}
+		expanded, err := c.ExpandPath(dir)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[expanded] {
+			seen[expanded] = true
+			dirs = append(dirs, expanded)
+		}
+	}
+	return dirs, nil
+}
+
 // StandupDir returns the absolute path to the standup directory
 func (c *Config) StandupDir() (string, error) {
 	return c.ExpandPath(c.Standup.Dir)
 }
+
+// NoteTypeForPath returns the note type, "journal" or "standup", whose
+// configured directory contains path, or "" if neither does. If both do, e.g.
+// a standup directory inside a journal directory, the innermost one wins.
+func (c *Config) NoteTypeForPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	abs = evalSymlinks(abs)
+
+	noteType, depth := "", -1
+	check := func(dir, dirType string) {
+		dir = evalSymlinks(dir)
+		rel, err := filepath.Rel(dir, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return
+		}
+		if len(dir) > depth {
+			noteType, depth = dirType, len(dir)
+		}
+	}
+
+	if dirs, err := c.JournalDirs(); err == nil {
+		for _, dir := range dirs {
+			check(dir, "journal")
+		}
+	}
+	if dir, err := c.StandupDir(); err == nil {
+		check(dir, "standup")
+	}
+	return noteType
+}
+
+// evalSymlinks returns path with symlinks evaluated, or path unchanged if it
+// can't be evaluated (e.g. it doesn't exist)
+func evalSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// boolPtr returns a pointer to b
+func boolPtr(b bool) *bool {
+	return &b
+}
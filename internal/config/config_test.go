@@ -2,8 +2,12 @@ package config
 
 import (
 	"os"
+	"os/user"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -16,8 +20,11 @@ func TestDefaultConfig(t *testing.T) {
 	if len(cfg.Journal.WorkDoneSections) != 2 {
 		t.Errorf("expected 2 work done sections, got %d", len(cfg.Journal.WorkDoneSections))
 	}
-	if len(cfg.Journal.LinkPreviousTitles) != 3 {
-		t.Errorf("expected 3 previous link titles, got %d", len(cfg.Journal.LinkPreviousTitles))
+	if len(cfg.Journal.LinkPreviousTitles) != 2 {
+		t.Errorf("expected 2 previous link titles, got %d", len(cfg.Journal.LinkPreviousTitles))
+	}
+	if len(cfg.Journal.LinkPreviousWeekTitles) != 1 || cfg.Journal.LinkPreviousWeekTitles[0] != "Last Week" {
+		t.Errorf("expected previous week titles [Last Week], got %v", cfg.Journal.LinkPreviousWeekTitles)
 	}
 
 	// Test standup defaults
@@ -27,11 +34,26 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.Standup.WorkDoneSection != "Worked on yesterday" {
 		t.Errorf("expected work done section 'Worked on yesterday', got %s", cfg.Standup.WorkDoneSection)
 	}
+	if cfg.Standup.WorkTodaySection != "Working on Today" {
+		t.Errorf("expected work today section 'Working on Today', got %s", cfg.Standup.WorkTodaySection)
+	}
+	if cfg.Standup.Create.TimeoutSeconds != DefaultCreateTimeoutSeconds {
+		t.Errorf("expected standup create timeout %d, got %d", DefaultCreateTimeoutSeconds, cfg.Standup.Create.TimeoutSeconds)
+	}
+	if len(cfg.Standup.ExtraSections) != 0 {
+		t.Errorf("expected no extra sections, got %v", cfg.Standup.ExtraSections)
+	}
 
 	// Test general defaults
 	if cfg.SearchWindowDays != 30 {
 		t.Errorf("expected search window 30 days, got %d", cfg.SearchWindowDays)
 	}
+	if len(cfg.CompanyTagDays) != 5 || cfg.CompanyTagDays[0] != "Mon" || cfg.CompanyTagDays[4] != "Fri" {
+		t.Errorf("expected company tag days Mon-Fri, got %v", cfg.CompanyTagDays)
+	}
+	if cfg.BulletStyle != "-" {
+		t.Errorf("expected bullet style '-', got %q", cfg.BulletStyle)
+	}
 }
 
 func TestConfigValidation(t *testing.T) {
@@ -106,6 +128,295 @@ func TestConfigValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "journal.work_done_sections must have at least one section",
 		},
+		{
+			name: "invalid work done order",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+					WorkDoneOrder:    "alphabetical",
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "journal.work_done_order must be",
+		},
+		{
+			name: "filename format without date",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				FilenameFormat:   "daily",
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "filename_format: filename format \"daily\" must contain a year, month and day",
+		},
+		{
+			name: "filename format missing day",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+					FilenameFormat:   "2006-01",
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "journal.filename_format:",
+		},
+		{
+			name: "filename format with path separator",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir:            "./standup",
+					FilenameFormat: "2006/01/02",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "standup.filename_format: filename format \"2006/01/02\" must not contain path separators",
+		},
+		{
+			name: "path layout with unknown placeholder",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+					PathLayout:       "{year}/{week}",
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "journal.path_layout: path layout \"{year}/{week}\" has an unknown placeholder",
+		},
+		{
+			name: "path layout escaping directory",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir:        "./standup",
+					PathLayout: "../{year}",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "standup.path_layout: path layout \"../{year}\" must not contain ..",
+		},
+		{
+			name: "valid filename formats",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+					FilenameFormat:   "2006-01-02-daily",
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				FilenameFormat:   "20060102",
+				SearchWindowDays: 30,
+			},
+			wantErr: false,
+		},
+		{
+			name: "journal dirs without dir",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dirs:             []string{"./work", "./personal"},
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty journal dirs entry",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					Dirs:             []string{""},
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "journal.dirs[0] must not be empty",
+		},
+		{
+			name: "negative github lookback days",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				GitHub: GitHubConfig{
+					Enabled:      true,
+					Org:          "my-org",
+					LookbackDays: -1,
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "github.lookback_days must not be negative",
+		},
+		{
+			name: "invalid company tag day",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+				CompanyTagDays:   []string{"Mon", "Funday"},
+			},
+			wantErr: true,
+			errMsg:  "company_tag_days: unknown day of the week",
+		},
+		{
+			name: "journal template without a command",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+					Templates:        map[string]CreateCommand{"travel": {}},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "journal.templates.travel.cmd must not be empty",
+		},
+		{
+			name: "negative forward search window",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays:        30,
+				SearchWindowForwardDays: -1,
+			},
+			wantErr: true,
+			errMsg:  "search_window_forward_days must be positive",
+		},
+		{
+			name: "invalid holiday",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+				Holidays:         []string{"2025-12-25", "25/12/2025"},
+			},
+			wantErr: true,
+			errMsg:  `holidays: "25/12/2025" is not a date`,
+		},
+		{
+			name: "invalid bullet style",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+				BulletStyle:      "+",
+			},
+			wantErr: true,
+			errMsg:  `bullet_style must be "-" or "*", got "+"`,
+		},
+		{
+			name: "unknown timezone",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+				Timezone:         "Mars/Olympus_Mons",
+			},
+			wantErr: true,
+			errMsg:  `timezone: "Mars/Olympus_Mons" is not a known time zone`,
+		},
+		{
+			name: "negative create timeout",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+				},
+				Standup: StandupConfig{
+					Dir:    "./standup",
+					Create: CreateCommand{Cmd: "true", TimeoutSeconds: -5},
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: true,
+			errMsg:  "standup.create.timeout_seconds must be positive",
+		},
+		{
+			name: "valid path layout",
+			cfg: &Config{
+				Journal: JournalConfig{
+					Dir:              "./journal",
+					WorkDoneSections: []string{"work completed"},
+					PathLayout:       "{year}/{month}",
+				},
+				Standup: StandupConfig{
+					Dir: "./standup",
+				},
+				SearchWindowDays: 30,
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -153,6 +464,11 @@ standup:
   link_next_titles:
     - "Next Day"
 
+github:
+  enabled: true
+  org: my-org
+  lookback_days: 14
+
 search_window_days: 45
 `
 
@@ -184,6 +500,9 @@ search_window_days: 45
 	if len(cfg.Journal.SkipText) != 1 || cfg.Journal.SkipText[0] != "skip this" {
 		t.Errorf("expected skip_text ['skip this'], got %v", cfg.Journal.SkipText)
 	}
+	if cfg.GitHub.LookbackDays != 14 {
+		t.Errorf("expected github lookback days 14, got %d", cfg.GitHub.LookbackDays)
+	}
 }
 
 func TestLoadConfigDefaults(t *testing.T) {
@@ -211,6 +530,9 @@ func TestLoadConfigDefaults(t *testing.T) {
 	if cfg.SearchWindowDays != defaults.SearchWindowDays {
 		t.Errorf("expected default search window, got %d", cfg.SearchWindowDays)
 	}
+	if cfg.GitHub.LookbackDays != 7 {
+		t.Errorf("expected default github lookback days 7, got %d", cfg.GitHub.LookbackDays)
+	}
 }
 
 func TestLoadConfigInvalidYAML(t *testing.T) {
@@ -233,21 +555,184 @@ journal:
 	}
 }
 
-func TestLoadConfigInvalidValues(t *testing.T) {
-	tmpDir := t.TempDir()
-	configPath := filepath.Join(tmpDir, ".za.yaml")
+func TestLoadConfigCompanyTagDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		wantDays []string
+	}{
+		{name: "default", yaml: "", wantDays: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}},
+		{name: "custom", yaml: "company_tag_days: [\"Mon\", \"Tue\"]\n", wantDays: []string{"Mon", "Tue"}},
+		{name: "empty means every day", yaml: "company_tag_days: []\n", wantDays: []string{}},
+	}
 
-	// Config with invalid values (negative search window)
-	configContent := `
-journal:
-  dir: /tmp/journal
-  work_done_sections:
-    - "work"
-standup:
-  dir: /tmp/standup
-search_window_days: -5
-`
-	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".za.yaml")
+			if err := os.WriteFile(configPath, []byte("journal:\n  dir: /tmp/journal\n"+tt.yaml), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cfg, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if strings.Join(cfg.CompanyTagDays, ",") != strings.Join(tt.wantDays, ",") {
+				t.Errorf("CompanyTagDays = %v, want %v", cfg.CompanyTagDays, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestLoadConfigCreateTimeout(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".za.yaml")
+	content := `
+journal:
+  dir: /tmp/journal
+  create:
+    cmd: "new {date}"
+    timeout_seconds: 120
+  templates:
+    travel:
+      cmd: "travel {date}"
+    quick:
+      cmd: "quick {date}"
+      timeout_seconds: 5
+standup:
+  create:
+    cmd: "new {date}"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := cfg.Journal.Create.Timeout(); got != 120*time.Second {
+		t.Errorf("journal create Timeout() = %v, want 2m0s", got)
+	}
+	if got := cfg.Standup.Create.Timeout(); got != DefaultCreateTimeoutSeconds*time.Second {
+		t.Errorf("standup create Timeout() = %v, want the default", got)
+	}
+
+	for name, want := range map[string]time.Duration{"travel": 120 * time.Second, "quick": 5 * time.Second} {
+		tmpl, err := cfg.Journal.CreateCommandFor(name)
+		if err != nil {
+			t.Fatalf("CreateCommandFor(%q) error = %v", name, err)
+		}
+		if got := tmpl.Timeout(); got != want {
+			t.Errorf("template %q Timeout() = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestLoadConfigEnvOverrides(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".za.yaml")
+	content := "journal:\n  dir: /tmp/file-journal\nsearch_window_days: 45\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("ZA_SEARCH_WINDOW_DAYS", "14")
+	t.Setenv("ZA_JOURNAL_DIR", "/tmp/env-journal")
+	t.Setenv("ZA_STANDUP_WORK_DONE_SECTION", "Done")
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.SearchWindowDays != 14 {
+		t.Errorf("SearchWindowDays = %d, want 14 from ZA_SEARCH_WINDOW_DAYS", cfg.SearchWindowDays)
+	}
+	if cfg.Journal.Dir != "/tmp/env-journal" {
+		t.Errorf("Journal.Dir = %q, want %q from ZA_JOURNAL_DIR", cfg.Journal.Dir, "/tmp/env-journal")
+	}
+	if cfg.Standup.WorkDoneSection != "Done" {
+		t.Errorf("Standup.WorkDoneSection = %q, want %q from ZA_STANDUP_WORK_DONE_SECTION", cfg.Standup.WorkDoneSection, "Done")
+	}
+}
+
+func TestLoadConfigHolidays(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".za.yaml")
+	content := "journal:\n  dir: /tmp/journal\nholidays:\n  - 2025-12-25\n  - \"2025-12-26\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2025, 12, 26, 9, 30, 0, 0, time.UTC), true},
+		{time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := cfg.IsHoliday(tt.date); got != tt.want {
+			t.Errorf("IsHoliday(%s) = %v, want %v", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigCompanyTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		yaml      string
+		wantNames []string
+	}{
+		{name: "default company_tag", yaml: "", wantNames: []string{"acme"}},
+		{name: "single company_tag", yaml: "company_tag: globex\n", wantNames: []string{"globex"}},
+		{name: "company_tags as a string", yaml: "company_tags: globex\n", wantNames: []string{"globex"}},
+		{
+			name:      "company_tags list replaces company_tag",
+			yaml:      "company_tag: acme\ncompany_tags: [\"globex\", \"initech\"]\n",
+			wantNames: []string{"globex", "initech"},
+		},
+		{name: "disabled", yaml: "company_tag: \"\"\n", wantNames: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".za.yaml")
+			if err := os.WriteFile(configPath, []byte("journal:\n  dir: /tmp/journal\n"+tt.yaml), 0644); err != nil {
+				t.Fatalf("failed to write test config: %v", err)
+			}
+
+			cfg, err := Load(configPath)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.CompanyTagNames(); strings.Join(got, ",") != strings.Join(tt.wantNames, ",") {
+				t.Errorf("CompanyTagNames() = %v, want %v", got, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestLoadConfigInvalidValues(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".za.yaml")
+
+	// Config with invalid values (negative search window)
+	configContent := `
+journal:
+  dir: /tmp/journal
+  work_done_sections:
+    - "work"
+standup:
+  dir: /tmp/standup
+search_window_days: -5
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
 		t.Fatalf("failed to write test config: %v", err)
 	}
 
@@ -291,6 +776,453 @@ func TestExpandPath(t *testing.T) {
 	}
 }
 
+func TestExpandPathEnvAndHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("ZA_TEST_COMPANY", "acme")
+
+	cfg := DefaultConfig()
+	cfg.VaultRoot = "/vault"
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"tilde", "~", home},
+		{"tilde path", "~/notes/journal", filepath.Join(home, "notes/journal")},
+		{"env var", "/notes/$ZA_TEST_COMPANY/journal", "/notes/acme/journal"},
+		{"braced env var", "${ZA_TEST_COMPANY}/journal", "/vault/acme/journal"},
+		{"tilde and env var", "~/$ZA_TEST_COMPANY/journal", filepath.Join(home, "acme/journal")},
+		{"unset env var", "/notes/$ZA_TEST_UNSET/journal", "/notes/journal"},
+		{"tilde not at start", "./~notes", "/vault/~notes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.ExpandPath(tt.path)
+			if err != nil {
+				t.Fatalf("ExpandPath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExpandPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	cfg.Journal.Dir = "~/$ZA_TEST_COMPANY/journal"
+	cfg.Standup.Dir = "$HOME/standup"
+	if got, err := cfg.JournalDir(); err != nil || got != filepath.Join(home, "acme/journal") {
+		t.Errorf("JournalDir() = %v, %v", got, err)
+	}
+	if got, err := cfg.StandupDir(); err != nil || got != filepath.Join(home, "standup") {
+		t.Errorf("StandupDir() = %v, %v", got, err)
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	current, err := user.Current()
+	if err != nil {
+		t.Skipf("cannot look up current user: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"tilde", "~", home},
+		{"tilde path", "~/x", filepath.Join(home, "x")},
+		{"user tilde", "~" + current.Username, current.HomeDir},
+		{"user tilde path", "~" + current.Username + "/notes/journal", filepath.Join(current.HomeDir, "notes/journal")},
+		{"absolute path", "/tmp/notes", "/tmp/notes"},
+		{"relative path", "notes/~", "notes/~"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandHome(tt.path)
+			if err != nil {
+				t.Fatalf("expandHome(%q) error = %v", tt.path, err)
+			}
+			if got != tt.want {
+				t.Errorf("expandHome(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := expandHome("~za-no-such-user/notes"); err == nil {
+		t.Error("expected error for an unknown user")
+	}
+}
+
+func TestExpandPathWithVaultRoot(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.VaultRoot = "/vault"
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"relative path", "journal", "/vault/journal"},
+		{"nested relative path", "./notes/standup", "/vault/notes/standup"},
+		{"parent relative path", "../shared/journal", "/shared/journal"},
+		{"absolute path", "/tmp/test", "/tmp/test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.ExpandPath(tt.path)
+			if err != nil {
+				t.Fatalf("ExpandPath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ExpandPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigVaultRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+
+	configPath := filepath.Join(configDir, ".za.yaml")
+	content := "vault_root: ../vault\njournal:\n  dir: journal\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	wantRoot := filepath.Join(tmpDir, "vault")
+	if cfg.VaultRoot != wantRoot {
+		t.Errorf("VaultRoot = %v, want %v", cfg.VaultRoot, wantRoot)
+	}
+
+	journalDir, err := cfg.JournalDir()
+	if err != nil {
+		t.Fatalf("JournalDir() error = %v", err)
+	}
+	if want := filepath.Join(wantRoot, "journal"); journalDir != want {
+		t.Errorf("JournalDir() = %v, want %v", journalDir, want)
+	}
+}
+
+func TestLoadConfigVaultRootFromParent(t *testing.T) {
+	// Resolve symlinks so paths compare equal with os.Getwd (e.g. macOS /private/var)
+	tmpDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to resolve temp dir: %v", err)
+	}
+
+	configPath := filepath.Join(tmpDir, ".za.yaml")
+	content := "journal:\n  dir: notes/journal\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	nested := filepath.Join(tmpDir, "notes", "journal")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("failed to create nested dir: %v", err)
+	}
+
+	oldDir, _ := os.Getwd()
+	defer func() { _ = os.Chdir(oldDir) }()
+	if err := os.Chdir(nested); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.VaultRoot != tmpDir {
+		t.Errorf("VaultRoot = %v, want %v", cfg.VaultRoot, tmpDir)
+	}
+
+	journalDir, err := cfg.JournalDir()
+	if err != nil {
+		t.Fatalf("JournalDir() error = %v", err)
+	}
+	if journalDir != nested {
+		t.Errorf("JournalDir() = %v, want %v", journalDir, nested)
+	}
+}
+
+func TestFilenameFormatFor(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.FilenameFormatFor("journal"); got != DefaultFilenameFormat {
+		t.Errorf("FilenameFormatFor() with nothing set = %q, want %q", got, DefaultFilenameFormat)
+	}
+
+	cfg.FilenameFormat = "20060102"
+	cfg.Standup.FilenameFormat = "2006-01-02-standup"
+	if got := cfg.FilenameFormatFor("journal"); got != "20060102" {
+		t.Errorf("FilenameFormatFor(journal) = %q, want %q", got, "20060102")
+	}
+	if got := cfg.FilenameFormatFor("standup"); got != "2006-01-02-standup" {
+		t.Errorf("FilenameFormatFor(standup) = %q, want %q", got, "2006-01-02-standup")
+	}
+}
+
+func TestFilenameFormats(t *testing.T) {
+	cfg := &Config{}
+	if got := cfg.FilenameFormats(); !reflect.DeepEqual(got, []string{DefaultFilenameFormat}) {
+		t.Errorf("FilenameFormats() with nothing set = %q, want %q", got, []string{DefaultFilenameFormat})
+	}
+
+	cfg.FilenameFormat = "20060102"
+	cfg.Standup.FilenameFormat = "2006-01-02-standup"
+	if got, want := cfg.FilenameFormats(), []string{"20060102", "2006-01-02-standup"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FilenameFormats() = %q, want %q", got, want)
+	}
+}
+
+func TestSearchWindowDirections(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.BackwardWindowDays() != 30 || cfg.ForwardWindowDays() != 30 {
+		t.Errorf("windows = %d/%d, want search_window_days for both", cfg.BackwardWindowDays(), cfg.ForwardWindowDays())
+	}
+
+	cfg.SearchWindowBackwardDays = 14
+	cfg.SearchWindowForwardDays = 3
+	if cfg.BackwardWindowDays() != 14 || cfg.ForwardWindowDays() != 3 {
+		t.Errorf("windows = %d/%d, want 14/3", cfg.BackwardWindowDays(), cfg.ForwardWindowDays())
+	}
+}
+
+func TestBullet(t *testing.T) {
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{"", "-"},
+		{"-", "-"},
+		{"*", "*"},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{BulletStyle: tt.style}
+		if got := cfg.Bullet(); got != tt.want {
+			t.Errorf("Bullet() with bullet_style %q = %q, want %q", tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestDraftFieldName(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"", "draft"},
+		{"wip", "wip"},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{DraftField: tt.field}
+		if got := cfg.DraftFieldName(); got != tt.want {
+			t.Errorf("DraftFieldName() with draft_field %q = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestLocation(t *testing.T) {
+	tests := []struct {
+		timezone string
+		want     string
+	}{
+		{"", time.Local.String()},
+		{"Asia/Tokyo", "Asia/Tokyo"},
+		{"UTC", "UTC"},
+		{"Mars/Olympus_Mons", time.Local.String()},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{Timezone: tt.timezone}
+		if got := cfg.Location().String(); got != tt.want {
+			t.Errorf("Location() with timezone %q = %q, want %q", tt.timezone, got, tt.want)
+		}
+	}
+}
+
+func TestGoalsHeadings(t *testing.T) {
+	jc := JournalConfig{}
+	if got := jc.DayGoalsHeading(); got != DefaultDayGoalsSection {
+		t.Errorf("DayGoalsHeading() with nothing set = %q, want %q", got, DefaultDayGoalsSection)
+	}
+	if got := jc.WeekGoalsHeading(); got != DefaultWeekGoalsSection {
+		t.Errorf("WeekGoalsHeading() with nothing set = %q, want %q", got, DefaultWeekGoalsSection)
+	}
+
+	jc.DayGoalsSection = "Today's Goals"
+	jc.WeekGoalsSection = "This Week"
+	if got := jc.DayGoalsHeading(); got != "Today's Goals" {
+		t.Errorf("DayGoalsHeading() = %q, want %q", got, "Today's Goals")
+	}
+	if got := jc.WeekGoalsHeading(); got != "This Week" {
+		t.Errorf("WeekGoalsHeading() = %q, want %q", got, "This Week")
+	}
+}
+
+func TestCreateCommandFor(t *testing.T) {
+	jc := JournalConfig{
+		Create: CreateCommand{Cmd: "default {date}"},
+		Templates: map[string]CreateCommand{
+			"travel":  {Cmd: "travel {date}"},
+			"weekend": {Cmd: "weekend {date}"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		wantCmd string
+		wantErr string
+	}{
+		{name: "no template", tmpl: "", wantCmd: "default {date}"},
+		{name: "named template", tmpl: "travel", wantCmd: "travel {date}"},
+		{name: "ignores case", tmpl: "Weekend", wantCmd: "weekend {date}"},
+		{name: "unknown template", tmpl: "holiday", wantErr: `unknown journal template "holiday" (available: travel, weekend)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jc.CreateCommandFor(tt.tmpl)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("CreateCommandFor(%q) error = %v, want %q", tt.tmpl, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateCommandFor(%q) error = %v", tt.tmpl, err)
+			}
+			if got.Cmd != tt.wantCmd {
+				t.Errorf("CreateCommandFor(%q) = %q, want %q", tt.tmpl, got.Cmd, tt.wantCmd)
+			}
+		})
+	}
+
+	if _, err := (&JournalConfig{}).CreateCommandFor("travel"); err == nil ||
+		!strings.Contains(err.Error(), "no journal.templates configured") {
+		t.Errorf("CreateCommandFor() with no templates error = %v", err)
+	}
+}
+
+func TestLoadConfigJournalTemplates(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".za.yaml")
+	content := "journal:\n  dir: /tmp/journal\n  templates:\n    Travel:\n      cmd: \"travel {date}\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got, err := cfg.Journal.CreateCommandFor("travel")
+	if err != nil {
+		t.Fatalf("CreateCommandFor() error = %v", err)
+	}
+	if got.Cmd != "travel {date}" {
+		t.Errorf("CreateCommandFor() = %q, want %q", got.Cmd, "travel {date}")
+	}
+}
+
+func TestLoadConfigJournalDirsOnly(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".za.yaml")
+	content := "vault_root: /vault\njournal:\n  dirs:\n    - ./work\n    - ./personal\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// journal.dir doesn't default to ./journal alongside journal.dirs
+	if cfg.Journal.Dir != "" {
+		t.Errorf("Journal.Dir = %q, want empty", cfg.Journal.Dir)
+	}
+	dirs, err := cfg.JournalDirs()
+	if err != nil {
+		t.Fatalf("JournalDirs() error = %v", err)
+	}
+	want := []string{"/vault/work", "/vault/personal"}
+	if strings.Join(dirs, ",") != strings.Join(want, ",") {
+		t.Errorf("JournalDirs() = %v, want %v", dirs, want)
+	}
+	dir, err := cfg.JournalDir()
+	if err != nil {
+		t.Fatalf("JournalDir() error = %v", err)
+	}
+	if dir != "/vault/work" {
+		t.Errorf("JournalDir() = %v, want /vault/work", dir)
+	}
+
+	// An explicit journal.dir still comes first
+	t.Setenv("ZA_JOURNAL_DIR", "./main")
+	cfg, err = Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if dir, _ := cfg.JournalDir(); dir != "/vault/main" {
+		t.Errorf("JournalDir() with ZA_JOURNAL_DIR = %v, want /vault/main", dir)
+	}
+}
+
+func TestCompanyTagOn(t *testing.T) {
+	// 2025-01-13 is a Monday
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		days []string
+		want []bool // Monday to Sunday
+	}{
+		{
+			name: "weekdays",
+			days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			want: []bool{true, true, true, true, true, false, false},
+		},
+		{
+			name: "full names and mixed case",
+			days: []string{"monday", "TUESDAY", " Sun "},
+			want: []bool{true, true, false, false, false, false, true},
+		},
+		{
+			name: "empty means every day",
+			days: nil,
+			want: []bool{true, true, true, true, true, true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{CompanyTagDays: tt.days}
+			for i, want := range tt.want {
+				date := monday.AddDate(0, 0, i)
+				if got := cfg.CompanyTagOn(date); got != want {
+					t.Errorf("CompanyTagOn(%s) = %v, want %v", date.Weekday(), got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestJournalDir(t *testing.T) {
 	cfg := DefaultConfig()
 	dir, err := cfg.JournalDir()
@@ -302,6 +1234,65 @@ func TestJournalDir(t *testing.T) {
 	}
 }
 
+func TestJournalDirs(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.VaultRoot = "/vault"
+
+	dirs, err := cfg.JournalDirs()
+	if err != nil {
+		t.Fatalf("JournalDirs() error = %v", err)
+	}
+	if len(dirs) != 1 || dirs[0] != "/vault/journal" {
+		t.Errorf("JournalDirs() with single dir = %v, want [/vault/journal]", dirs)
+	}
+
+	// Additional dirs follow dir, with duplicates dropped
+	cfg.Journal.Dirs = []string{"./personal", "/vault/journal", "/work/journal"}
+	dirs, err = cfg.JournalDirs()
+	if err != nil {
+		t.Fatalf("JournalDirs() error = %v", err)
+	}
+	want := []string{"/vault/journal", "/vault/personal", "/work/journal"}
+	if strings.Join(dirs, ",") != strings.Join(want, ",") {
+		t.Errorf("JournalDirs() = %v, want %v", dirs, want)
+	}
+
+	// Without dir, the first of dirs is the primary directory
+	cfg.Journal.Dir = ""
+	dir, err := cfg.JournalDir()
+	if err != nil {
+		t.Fatalf("JournalDir() error = %v", err)
+	}
+	if dir != "/vault/personal" {
+		t.Errorf("JournalDir() without dir = %v, want /vault/personal", dir)
+	}
+}
+
+func TestNoteTypeForPath(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.VaultRoot = "/home/journal/vault"
+	cfg.Journal.Dirs = []string{"./personal"}
+	cfg.Standup.Dir = "./journal/standup"
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/home/journal/vault/journal/2025-01-06.md", "journal"},
+		{"/home/journal/vault/journal/2025/01/2025-01-06.md", "journal"},
+		{"/home/journal/vault/personal/2025-01-06.md", "journal"},
+		// The standup directory is inside the journal directory
+		{"/home/journal/vault/journal/standup/2025-01-06.md", "standup"},
+		{"/home/journal/vault/2025-01-06.md", ""},
+		{"/home/journal/vault/journal-old/2025-01-06.md", ""},
+	}
+	for _, tt := range tests {
+		if got := cfg.NoteTypeForPath(tt.path); got != tt.want {
+			t.Errorf("NoteTypeForPath(%s) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
 func TestStandupDir(t *testing.T) {
 	cfg := DefaultConfig()
 	dir, err := cfg.StandupDir()
@@ -312,3 +1303,27 @@ func TestStandupDir(t *testing.T) {
 		t.Errorf("StandupDir() = %v, want absolute path", dir)
 	}
 }
+
+func TestSlackEmojiFor(t *testing.T) {
+	sc := StandupConfig{
+		SlackEmoji: map[string]string{
+			"worked on yesterday": "✅",
+			"Blocked on":          "⛔",
+		},
+	}
+
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{"Worked on Yesterday", "✅"},
+		{"blocked on", "⛔"},
+		{"Working on Today", ""},
+	}
+
+	for _, tt := range tests {
+		if got := sc.SlackEmojiFor(tt.heading); got != tt.want {
+			t.Errorf("SlackEmojiFor(%q) = %q, want %q", tt.heading, got, tt.want)
+		}
+	}
+}
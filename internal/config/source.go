@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Where a setting's value came from
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+)
+
+// Setting is one effective configuration value and where it came from
+type Setting struct {
+	Key    string
+	Value  any
+	Source string
+}
+
+// Source describes where Load found the configuration
+type Source struct {
+	// File is the config file that was read, or empty if none was found
+	File string
+
+	// Settings are the effective values of every key, sorted by key
+	Settings []Setting
+
+	// UnknownKeys are keys that don't match any configuration field, e.g.
+	// misspellings; their values are ignored
+	UnknownKeys []string
+}
+
+// loadSource records the config file viper read and the source of each key
+func loadSource(v *viper.Viper) Source {
+	keys := v.AllKeys()
+	sort.Strings(keys)
+
+	settings := make([]Setting, 0, len(keys))
+	for i, key := range keys {
+		// Skip a map default (e.g. journal.templates) whose entries are
+		// listed as keys of their own
+		if i+1 < len(keys) && strings.HasPrefix(keys[i+1], key+".") {
+			continue
+		}
+		settings = append(settings, Setting{
+			Key:    key,
+			Value:  v.Get(key),
+			Source: settingSource(v, key),
+		})
+	}
+
+	return Source{
+		File:        v.ConfigFileUsed(),
+		Settings:    settings,
+		UnknownKeys: unknownKeys(keys),
+	}
+}
+
+// unknownKeys returns the keys that don't name a field of Config
+func unknownKeys(keys []string) []string {
+	var unknown []string
+	for _, key := range keys {
+		if !knownKey(reflect.TypeOf(Config{}), strings.Split(key, ".")) {
+			unknown = append(unknown, key)
+		}
+	}
+	return unknown
+}
+
+// knownKey reports whether the key path parts name a field within t, using
+// the fields' mapstructure tags. Any key is allowed within a map.
+func knownKey(t reflect.Type, parts []string) bool {
+	if len(parts) == 0 {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return knownKey(t.Elem(), parts)
+	case reflect.Map:
+		return knownKey(t.Elem(), parts[1:])
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if tag := field.Tag.Get("mapstructure"); tag != "" && tag != "-" && tag == parts[0] {
+				return knownKey(field.Type, parts[1:])
+			}
+		}
+	}
+	return false
+}
+
+// settingSource reports whether key was set by an environment variable, the
+// config file, or neither
+func settingSource(v *viper.Viper, key string) string {
+	if _, ok := os.LookupEnv(envVarName(key)); ok {
+		return SourceEnv
+	}
+	if v.InConfig(key) {
+		return SourceFile
+	}
+	return SourceDefault
+}
+
+// envKeyReplacer maps a config key to its environment variable suffix
+var envKeyReplacer = strings.NewReplacer(".", "_")
+
+// envVarName returns the environment variable that overrides key
+func envVarName(key string) string {
+	return "ZA_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSource(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".za.yaml")
+	content := "journal:\n  dir: /tmp/journal\n  templates:\n    travel:\n      cmd: \"travel {date}\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+	t.Setenv("ZA_SEARCH_WINDOW_DAYS", "12")
+	t.Setenv("ZA_STANDUP_DIR", "/tmp/standup")
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Source.File != configPath {
+		t.Errorf("Source.File = %q, want %q", cfg.Source.File, configPath)
+	}
+
+	sources := make(map[string]string)
+	for _, setting := range cfg.Source.Settings {
+		sources[setting.Key] = setting.Source
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"journal.dir", SourceFile},
+		{"journal.templates.travel.cmd", SourceFile},
+		{"search_window_days", SourceEnv},
+		{"standup.dir", SourceEnv},
+		{"company_tag", SourceDefault},
+		{"journal.templates", ""}, // listed by its entries instead
+	}
+	for _, tt := range tests {
+		if got := sources[tt.key]; got != tt.want {
+			t.Errorf("source of %s = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestUnknownKeys(t *testing.T) {
+	keys := []string{
+		"journal.dir",
+		"journal.work_done_section", // should be work_done_sections
+		"journal.create.cmd",
+		"journal.templates.travel.cmd",
+		"journal.templates.travel.command",
+		"journal.ensure_empty_goals_section",
+		"standup.slack_emoji.worked on yesterday",
+		"standup.create.cmd.extra",
+		"search_window_day",
+		"source",
+	}
+
+	got := unknownKeys(keys)
+	want := []string{
+		"journal.work_done_section",
+		"journal.templates.travel.command",
+		"standup.create.cmd.extra",
+		"search_window_day",
+		"source",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("unknownKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadUnknownKeys(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".za.yaml")
+	content := "journal:\n  dir: /tmp/journal\n  work_done_section: \"Done\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cfg.Source.UnknownKeys) != 1 || cfg.Source.UnknownKeys[0] != "journal.work_done_section" {
+		t.Errorf("UnknownKeys = %v, want [journal.work_done_section]", cfg.Source.UnknownKeys)
+	}
+}
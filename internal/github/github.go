@@ -17,20 +17,48 @@ type PullRequest struct {
 	State     string    `json:"state"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+	ClosedAt  time.Time `json:"closedAt"`
+	MergedAt  time.Time `json:"mergedAt"`
 	Author    string    `json:"author"`
 	Repo      string    `json:"repository"`
 	Reviews   int       `json:"reviews"`
+
+	// ReviewDecision is GitHub's overall review state, e.g. "APPROVED",
+	// "CHANGES_REQUESTED" or "REVIEW_REQUIRED" (empty if none is required)
+	ReviewDecision string `json:"reviewDecision"`
 }
 
+// Bullet point prefixes for FormatPRsAsBulletPoints
+const (
+	// PrefixNeedsReview marks PRs still waiting for review
+	PrefixNeedsReview = "needs-review: "
+
+	// PrefixMerged marks PRs that have been merged
+	PrefixMerged = "merged: "
+)
+
+// searchFields are the fields requested from gh search prs, which supports
+// fewer fields than gh pr view (e.g. no mergedAt or reviews)
+const searchFields = "number,title,url,state,createdAt,updatedAt,closedAt,author,repository"
+
+// reviewFields are the fields requested from gh pr view for a PR's reviews
+const reviewFields = "reviews,reviewDecision"
+
+// executeCommand runs gh; tests replace it to check the arguments
+var executeCommand = util.ExecuteCommand
+
 // Client handles GitHub CLI interactions
 type Client struct {
-	org string
+	org          string
+	lookbackDays int
 }
 
-// NewClient creates a new GitHub client
-func NewClient(org string) *Client {
+// NewClient creates a new GitHub client.
+// lookbackDays is how far back GetPRsOpenAndUnreviewed looks for PRs.
+func NewClient(org string, lookbackDays int) *Client {
 	return &Client{
-		org: org,
+		org:          org,
+		lookbackDays: lookbackDays,
 	}
 }
 
@@ -49,12 +77,64 @@ func (c *Client) GetPRsCreatedYesterday(date time.Time) ([]PullRequest, error) {
 	return c.searchPRs(startOfDay, endOfDay, "")
 }
 
-// GetPRsOpenAndUnreviewed fetches PRs opened in the last 7 days that are still open and unreviewed
+// GetPRsMergedYesterday fetches PRs authored by the current user that were
+// merged yesterday in the organization
+func (c *Client) GetPRsMergedYesterday(date time.Time) ([]PullRequest, error) {
+	yesterday := date.AddDate(0, 0, -1)
+	startOfDay := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, yesterday.Location())
+	endOfDay := startOfDay.Add(24 * time.Hour)
+
+	prs, err := c.searchPRs(time.Time{}, time.Time{}, "--merged-at >="+startOfDay.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Search results have no mergedAt, but a merged PR was closed when it
+	// was merged. The search only bounds the start; drop anything merged
+	// after yesterday.
+	merged := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if !pr.ClosedAt.IsZero() && pr.ClosedAt.Before(endOfDay) {
+			pr.MergedAt = pr.ClosedAt
+			merged = append(merged, pr)
+		}
+	}
+	return merged, nil
+}
+
+// GetPRsOpenAndUnreviewed fetches PRs opened in the lookback window that are
+// still open and unreviewed. Reviews and ReviewDecision are looked up for
+// each PR, as search results don't include them.
 func (c *Client) GetPRsOpenAndUnreviewed(date time.Time) ([]PullRequest, error) {
-	sevenDaysAgo := date.AddDate(0, 0, -7)
-	startOfDay := time.Date(sevenDaysAgo.Year(), sevenDaysAgo.Month(), sevenDaysAgo.Day(), 0, 0, 0, 0, sevenDaysAgo.Location())
+	windowStart := date.AddDate(0, 0, -c.lookbackDays)
+	startOfDay := time.Date(windowStart.Year(), windowStart.Month(), windowStart.Day(), 0, 0, 0, 0, windowStart.Location())
+
+	prs, err := c.searchPRs(startOfDay, time.Time{}, "--state=open review:none")
+	if err != nil {
+		return nil, err
+	}
+	if err := addReviews(prs); err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
 
-	return c.searchPRs(startOfDay, time.Time{}, "--state=open review:none")
+// addReviews fills in the Reviews and ReviewDecision of each PR using
+// gh pr view
+func addReviews(prs []PullRequest) error {
+	for i := range prs {
+		output, err := runGH("pr", "view", prs[i].URL, "--json", reviewFields)
+		if err != nil {
+			return err
+		}
+		reviews, decision, err := parseReviews(output)
+		if err != nil {
+			return fmt.Errorf("failed to read reviews of %s: %w", prs[i].URL, err)
+		}
+		prs[i].Reviews = reviews
+		prs[i].ReviewDecision = decision
+	}
+	return nil
 }
 
 // searchPRs searches for PRs using GitHub CLI
@@ -81,25 +161,39 @@ func (c *Client) searchPRs(createdAfter time.Time, createdBefore time.Time, addi
 
 	// Add JSON output and limit
 	args = append(args,
-		"--json", "number,title,url,state,createdAt,updatedAt,author,repository",
+		"--json", searchFields,
 		"--limit", "100",
 	)
 
-	result := util.ExecuteCommand(util.ExecConfig{
+	output, err := runGH(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parsePRs(output)
+}
+
+// runGH runs gh with args and returns its output
+func runGH(args ...string) (string, error) {
+	name := "gh " + strings.Join(args[:min(2, len(args))], " ")
+	result := executeCommand(util.ExecConfig{
 		Command: "gh",
 		Args:    args,
 		Timeout: 30 * time.Second,
 	})
 
 	if result.Error != nil {
-		return nil, fmt.Errorf("gh search failed: %w (exit code: %d, stderr: %s)", result.Error, result.ExitCode, result.Stderr)
+		return "", fmt.Errorf("%s failed: %w (exit code: %d, stderr: %s)", name, result.Error, result.ExitCode, result.Stderr)
 	}
 
 	if result.ExitCode != 0 {
-		return nil, fmt.Errorf("gh search exited with code %d: %s", result.ExitCode, result.Stderr)
+		return "", fmt.Errorf("%s exited with code %d: %s", name, result.ExitCode, result.Stderr)
 	}
 
-	// Parse JSON response
+	return result.Stdout, nil
+}
+
+// parsePRs parses the JSON output of gh search prs
+func parsePRs(output string) ([]PullRequest, error) {
 	var prs []struct {
 		Number    int    `json:"number"`
 		Title     string `json:"title"`
@@ -107,6 +201,7 @@ func (c *Client) searchPRs(createdAfter time.Time, createdBefore time.Time, addi
 		State     string `json:"state"`
 		CreatedAt string `json:"createdAt"`
 		UpdatedAt string `json:"updatedAt"`
+		ClosedAt  string `json:"closedAt"`
 		Author    struct {
 			Login string `json:"login"`
 		} `json:"author"`
@@ -115,7 +210,7 @@ func (c *Client) searchPRs(createdAfter time.Time, createdBefore time.Time, addi
 		} `json:"repository"`
 	}
 
-	if err := json.Unmarshal([]byte(result.Stdout), &prs); err != nil {
+	if err := json.Unmarshal([]byte(output), &prs); err != nil {
 		return nil, fmt.Errorf("failed to parse gh output: %w", err)
 	}
 
@@ -131,6 +226,15 @@ func (c *Client) searchPRs(createdAfter time.Time, createdBefore time.Time, addi
 			continue
 		}
 
+		// closedAt is empty (or absent) for open PRs
+		var closedAt time.Time
+		if pr.ClosedAt != "" {
+			closedAt, err = time.Parse(time.RFC3339, pr.ClosedAt)
+			if err != nil {
+				continue
+			}
+		}
+
 		results = append(results, PullRequest{
 			Number:    pr.Number,
 			Title:     pr.Title,
@@ -138,6 +242,7 @@ func (c *Client) searchPRs(createdAfter time.Time, createdBefore time.Time, addi
 			State:     pr.State,
 			CreatedAt: createdAt,
 			UpdatedAt: updatedAt,
+			ClosedAt:  closedAt,
 			Author:    pr.Author.Login,
 			Repo:      pr.Repository.NameWithOwner,
 		})
@@ -146,8 +251,50 @@ func (c *Client) searchPRs(createdAfter time.Time, createdBefore time.Time, addi
 	return results, nil
 }
 
-// FormatPRsAsBulletPoints formats PRs as markdown bullet points
-func FormatPRsAsBulletPoints(prs []PullRequest, needsReviewPrefix bool) string {
+// parseReviews parses the output of gh pr view --json reviews,reviewDecision
+// into a review count and the review decision
+func parseReviews(output string) (int, string, error) {
+	var pr struct {
+		Reviews        []json.RawMessage `json:"reviews"`
+		ReviewDecision string            `json:"reviewDecision"`
+	}
+	if err := json.Unmarshal([]byte(output), &pr); err != nil {
+		return 0, "", fmt.Errorf("failed to parse gh output: %w", err)
+	}
+	return reviewCount(len(pr.Reviews), pr.ReviewDecision), pr.ReviewDecision, nil
+}
+
+// reviewCount returns the number of reviews on a PR. If gh returned no review
+// entries but the review decision shows the PR has been reviewed, it counts
+// as one review.
+func reviewCount(reviews int, decision string) int {
+	if reviews == 0 && (decision == "APPROVED" || decision == "CHANGES_REQUESTED") {
+		return 1
+	}
+	return reviews
+}
+
+// FilterUnreviewed returns the PRs that have no reviews
+func FilterUnreviewed(prs []PullRequest) []PullRequest {
+	var unreviewed []PullRequest
+	for _, pr := range prs {
+		if pr.Reviews == 0 {
+			unreviewed = append(unreviewed, pr)
+		}
+	}
+	return unreviewed
+}
+
+// FormatUnreviewedPRsAsBulletPoints is like FormatPRsAsBulletPoints but only
+// includes PRs that have no reviews
+func FormatUnreviewedPRsAsBulletPoints(prs []PullRequest, bullet, prefix string) string {
+	return FormatPRsAsBulletPoints(FilterUnreviewed(prs), bullet, prefix)
+}
+
+// FormatPRsAsBulletPoints formats PRs as markdown list items with the bullet
+// marker ("-" or "*"), starting each with prefix (e.g. PrefixNeedsReview or
+// PrefixMerged, or "" for none)
+func FormatPRsAsBulletPoints(prs []PullRequest, bullet, prefix string) string {
 	if len(prs) == 0 {
 		return ""
 	}
@@ -159,12 +306,7 @@ func FormatPRsAsBulletPoints(prs []PullRequest, needsReviewPrefix bool) string {
 			repoShort = parts[1]
 		}
 
-		prefix := ""
-		if needsReviewPrefix {
-			prefix = "needs-review: "
-		}
-
-		sb.WriteString(fmt.Sprintf("* %s[%s#%d](%s): %s\n", prefix, repoShort, pr.Number, pr.URL, pr.Title))
+		sb.WriteString(fmt.Sprintf("%s %s[%s#%d](%s): %s\n", bullet, prefix, repoShort, pr.Number, pr.URL, pr.Title))
 	}
 	return sb.String()
 }
@@ -0,0 +1,246 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rdark/za/internal/util"
+)
+
+func TestParsePRs(t *testing.T) {
+	output := `[
+  {
+    "number": 12,
+    "title": "Add feature",
+    "url": "https://github.com/acme/app/pull/12",
+    "state": "merged",
+    "createdAt": "2025-01-18T10:00:00Z",
+    "updatedAt": "2025-01-20T15:00:00Z",
+    "closedAt": "2025-01-20T14:30:00Z",
+    "author": {"login": "octocat"},
+    "repository": {"nameWithOwner": "acme/app"}
+  },
+  {
+    "number": 13,
+    "title": "Work in progress",
+    "url": "https://github.com/acme/app/pull/13",
+    "state": "open",
+    "createdAt": "2025-01-20T09:00:00Z",
+    "updatedAt": "2025-01-20T09:00:00Z",
+    "author": {"login": "octocat"},
+    "repository": {"nameWithOwner": "acme/app"}
+  }
+]`
+
+	prs, err := parsePRs(output)
+	if err != nil {
+		t.Fatalf("parsePRs() error = %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("parsePRs() returned %d PRs, want 2", len(prs))
+	}
+
+	wantClosed := time.Date(2025, 1, 20, 14, 30, 0, 0, time.UTC)
+	if !prs[0].ClosedAt.Equal(wantClosed) {
+		t.Errorf("ClosedAt = %v, want %v", prs[0].ClosedAt, wantClosed)
+	}
+	if prs[0].Author != "octocat" || prs[0].Repo != "acme/app" {
+		t.Errorf("Author/Repo = %q/%q, want octocat/acme/app", prs[0].Author, prs[0].Repo)
+	}
+	if !prs[1].ClosedAt.IsZero() {
+		t.Errorf("open PR ClosedAt = %v, want zero", prs[1].ClosedAt)
+	}
+}
+
+// stubGH replaces executeCommand for the duration of a test, recording the
+// arguments of each call and answering with output
+func stubGH(t *testing.T, output func(args []string) string) *[][]string {
+	t.Helper()
+	var calls [][]string
+	old := executeCommand
+	executeCommand = func(cfg util.ExecConfig) util.CommandResult {
+		if cfg.Command != "gh" {
+			t.Errorf("executeCommand() ran %q, want gh", cfg.Command)
+		}
+		calls = append(calls, cfg.Args)
+		return util.CommandResult{Stdout: output(cfg.Args)}
+	}
+	t.Cleanup(func() { executeCommand = old })
+	return &calls
+}
+
+func TestGetPRsMergedYesterday(t *testing.T) {
+	calls := stubGH(t, func([]string) string {
+		return `[
+  {"number": 1, "title": "Merged yesterday", "createdAt": "2025-01-17T09:00:00Z", "updatedAt": "2025-01-20T15:00:00Z",
+   "closedAt": "2025-01-20T14:30:00Z"},
+  {"number": 2, "title": "Merged today", "createdAt": "2025-01-17T09:00:00Z", "updatedAt": "2025-01-21T10:00:00Z",
+   "closedAt": "2025-01-21T10:00:00Z"}
+]`
+	})
+
+	prs, err := NewClient("acme", 7).GetPRsMergedYesterday(time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetPRsMergedYesterday() error = %v", err)
+	}
+
+	wantArgs := [][]string{{
+		"search", "prs",
+		"--owner", "acme",
+		"--author", "@me",
+		"--merged-at", ">=2025-01-20",
+		"--json", "number,title,url,state,createdAt,updatedAt,closedAt,author,repository",
+		"--limit", "100",
+	}}
+	if !reflect.DeepEqual(*calls, wantArgs) {
+		t.Errorf("gh called with %q, want %q", *calls, wantArgs)
+	}
+
+	if len(prs) != 1 || prs[0].Number != 1 {
+		t.Fatalf("GetPRsMergedYesterday() = %+v, want only PR #1", prs)
+	}
+	if want := time.Date(2025, 1, 20, 14, 30, 0, 0, time.UTC); !prs[0].MergedAt.Equal(want) {
+		t.Errorf("MergedAt = %v, want %v", prs[0].MergedAt, want)
+	}
+}
+
+func TestParsePRsInvalidJSON(t *testing.T) {
+	if _, err := parsePRs("not json"); err == nil {
+		t.Error("parsePRs() should fail for invalid JSON")
+	}
+}
+
+func TestFormatPRsAsBulletPoints(t *testing.T) {
+	prs := []PullRequest{
+		{Number: 12, Title: "Add feature", URL: "https://github.com/acme/app/pull/12", Repo: "acme/app"},
+	}
+
+	tests := []struct {
+		name   string
+		bullet string
+		prefix string
+		want   string
+	}{
+		{"no prefix", "*", "", "* [app#12](https://github.com/acme/app/pull/12): Add feature\n"},
+		{"needs review", "*", PrefixNeedsReview, "* needs-review: [app#12](https://github.com/acme/app/pull/12): Add feature\n"},
+		{"merged", "*", PrefixMerged, "* merged: [app#12](https://github.com/acme/app/pull/12): Add feature\n"},
+		{"dash bullet", "-", PrefixMerged, "- merged: [app#12](https://github.com/acme/app/pull/12): Add feature\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatPRsAsBulletPoints(prs, tt.bullet, tt.prefix); got != tt.want {
+				t.Errorf("FormatPRsAsBulletPoints() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := FormatPRsAsBulletPoints(nil, "*", PrefixMerged); got != "" {
+		t.Errorf("FormatPRsAsBulletPoints(nil) = %q, want empty", got)
+	}
+}
+
+func TestParseReviews(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		wantReviews  int
+		wantDecision string
+	}{
+		{
+			name:         "approved",
+			output:       `{"reviews": [{"state": "COMMENTED"}, {"state": "APPROVED"}], "reviewDecision": "APPROVED"}`,
+			wantReviews:  2,
+			wantDecision: "APPROVED",
+		},
+		{
+			name:         "review required",
+			output:       `{"reviews": [], "reviewDecision": "REVIEW_REQUIRED"}`,
+			wantDecision: "REVIEW_REQUIRED",
+		},
+		{
+			name:         "decision without review entries",
+			output:       `{"reviewDecision": "CHANGES_REQUESTED"}`,
+			wantReviews:  1,
+			wantDecision: "CHANGES_REQUESTED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviews, decision, err := parseReviews(tt.output)
+			if err != nil {
+				t.Fatalf("parseReviews() error = %v", err)
+			}
+			if reviews != tt.wantReviews || decision != tt.wantDecision {
+				t.Errorf("parseReviews() = %d, %q, want %d, %q", reviews, decision, tt.wantReviews, tt.wantDecision)
+			}
+		})
+	}
+
+	if _, _, err := parseReviews("not json"); err == nil {
+		t.Error("parseReviews() should fail for invalid JSON")
+	}
+}
+
+func TestGetPRsOpenAndUnreviewed(t *testing.T) {
+	calls := stubGH(t, func(args []string) string {
+		switch {
+		case args[0] == "search":
+			return `[
+  {"number": 1, "url": "https://github.com/acme/app/pull/1", "createdAt": "2025-01-17T09:00:00Z", "updatedAt": "2025-01-17T09:00:00Z"},
+  {"number": 2, "url": "https://github.com/acme/app/pull/2", "createdAt": "2025-01-18T09:00:00Z", "updatedAt": "2025-01-18T09:00:00Z"}
+]`
+		case args[2] == "https://github.com/acme/app/pull/1":
+			return `{"reviews": [{"state": "COMMENTED"}], "reviewDecision": "REVIEW_REQUIRED"}`
+		default:
+			return `{"reviews": [], "reviewDecision": ""}`
+		}
+	})
+
+	prs, err := NewClient("acme", 7).GetPRsOpenAndUnreviewed(time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetPRsOpenAndUnreviewed() error = %v", err)
+	}
+
+	wantArgs := [][]string{
+		{
+			"search", "prs",
+			"--owner", "acme",
+			"--author", "@me",
+			"--created", ">=2025-01-14",
+			"--state=open", "review:none",
+			"--json", "number,title,url,state,createdAt,updatedAt,closedAt,author,repository",
+			"--limit", "100",
+		},
+		{"pr", "view", "https://github.com/acme/app/pull/1", "--json", "reviews,reviewDecision"},
+		{"pr", "view", "https://github.com/acme/app/pull/2", "--json", "reviews,reviewDecision"},
+	}
+	if !reflect.DeepEqual(*calls, wantArgs) {
+		t.Errorf("gh called with %q, want %q", *calls, wantArgs)
+	}
+
+	if len(prs) != 2 || prs[0].Reviews != 1 || prs[0].ReviewDecision != "REVIEW_REQUIRED" || prs[1].Reviews != 0 {
+		t.Errorf("GetPRsOpenAndUnreviewed() = %+v, want PR #1 with 1 review and PR #2 with none", prs)
+	}
+	if got := FilterUnreviewed(prs); len(got) != 1 || got[0].Number != 2 {
+		t.Errorf("FilterUnreviewed() = %+v, want only PR #2", got)
+	}
+}
+
+func TestFormatUnreviewedPRsAsBulletPoints(t *testing.T) {
+	prs := []PullRequest{
+		{Number: 1, Title: "Reviewed", URL: "https://github.com/acme/app/pull/1", Repo: "acme/app", Reviews: 1},
+		{Number: 2, Title: "Waiting", URL: "https://github.com/acme/app/pull/2", Repo: "acme/app"},
+	}
+
+	want := "* needs-review: [app#2](https://github.com/acme/app/pull/2): Waiting\n"
+	if got := FormatUnreviewedPRsAsBulletPoints(prs, "*", PrefixNeedsReview); got != want {
+		t.Errorf("FormatUnreviewedPRsAsBulletPoints() = %q, want %q", got, want)
+	}
+
+	if got := FilterUnreviewed(prs[:1]); len(got) != 0 {
+		t.Errorf("FilterUnreviewed() = %v, want none", got)
+	}
+}
@@ -0,0 +1,39 @@
+package links
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/rdark/za/internal/markdown"
+)
+
+// ErrAttachmentNotFound is returned by ValidateAttachment when the file an
+// attachment link points to does not exist
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// AttachmentPath returns the path of the file a relative link in the note at
+// notePath points to. Percent-encoded characters, e.g. %20, are decoded.
+func AttachmentPath(notePath string, link markdown.Link) string {
+	target := link.Path()
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	return filepath.Join(filepath.Dir(notePath), filepath.FromSlash(target))
+}
+
+// ValidateAttachment checks that the file an attachment link in the note at
+// notePath points to exists, returning an error wrapping
+// ErrAttachmentNotFound if it doesn't
+func ValidateAttachment(notePath string, link markdown.Link) error {
+	target := AttachmentPath(notePath, link)
+	if _, err := os.Stat(target); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrAttachmentNotFound, target)
+		}
+		return fmt.Errorf("failed to check attachment %s: %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package links
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rdark/za/internal/markdown"
+)
+
+func TestValidateAttachment(t *testing.T) {
+	tempDir := t.TempDir()
+	notePath := filepath.Join(tempDir, "journal", "2025-01-06.md")
+	for _, path := range []string{
+		filepath.Join(tempDir, "journal", "img.png"),
+		filepath.Join(tempDir, "attachments", "weekly report.pdf"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+			t.Fatalf("failed to write attachment: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		destination string
+		wantErr     error
+	}{
+		{name: "same directory", destination: "img.png"},
+		{name: "relative path", destination: "./img.png"},
+		{name: "parent directory with encoded space", destination: "../attachments/weekly%20report.pdf"},
+		{name: "fragment", destination: "../attachments/weekly%20report.pdf#page=2"},
+		{name: "missing", destination: "missing.png", wantErr: ErrAttachmentNotFound},
+		{name: "moved", destination: "../attachments/img.png", wantErr: ErrAttachmentNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAttachment(notePath, markdown.Link{Destination: tt.destination})
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("ValidateAttachment(%q) error = %v, want nil", tt.destination, err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateAttachment(%q) error = %v, want %v", tt.destination, err, tt.wantErr)
+			}
+		})
+	}
+}
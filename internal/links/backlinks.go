@@ -0,0 +1,126 @@
+package links
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+)
+
+// Backlink is a link in a note that points to another note's date
+type Backlink struct {
+	// Path is the path of the note containing the link
+	Path string
+
+	// Link is the link itself
+	Link markdown.Link
+}
+
+// FindBacklinks scans the markdown notes in dirs, including subdirectories,
+// for links to the note of noteType on targetDate. Results are ordered by
+// path, then line.
+//
+// A link's target type is taken from a journal/ or standup/ directory in its
+// destination. Without one, the link is assumed to point to a note of the same
+// type as the note containing it, judged the same way from that note's path;
+// if that can't be told either, the link is included. Directories that don't
+// exist are skipped.
+func FindBacklinks(targetDate time.Time, noteType notes.NoteType, dirs ...string) ([]Backlink, error) {
+	target := targetDate.Format(notes.DateFormat)
+	return findBacklinks(noteType, dirs, func(link markdown.Link) bool {
+		return link.GetDateFromDestination() == target
+	})
+}
+
+// FindBacklinksByName is like FindBacklinks, but finds links to the note of
+// noteType whose filename, without the .md extension, is name. It also finds
+// links to misnamed notes, e.g. 2025-01-6.md.
+func FindBacklinksByName(name string, noteType notes.NoteType, dirs ...string) ([]Backlink, error) {
+	name = strings.TrimSuffix(name, ".md")
+	return findBacklinks(noteType, dirs, func(link markdown.Link) bool {
+		return strings.TrimSuffix(path.Base(link.Path()), ".md") == name
+	})
+}
+
+// findBacklinks returns the links in the notes in dirs to notes of noteType
+// for which match returns true
+func findBacklinks(noteType notes.NoteType, dirs []string, match func(markdown.Link) bool) ([]Backlink, error) {
+	if !noteType.IsValid() {
+		return nil, fmt.Errorf("%w: %s", notes.ErrInvalidNoteType, noteType)
+	}
+
+	parser := markdown.NewParser()
+	seen := make(map[string]bool)
+
+	var backlinks []Backlink
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") || seen[path] {
+				return nil
+			}
+			seen[path] = true
+
+			doc, err := parser.ParseFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+
+			for _, link := range doc.ExtractLinks() {
+				if link.IsExternalLink() || !match(link) {
+					continue
+				}
+				if linkType := linkTargetType(path, link); linkType == "" || linkType == noteType {
+					backlinks = append(backlinks, Backlink{Path: path, Link: link})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(backlinks, func(i, j int) bool {
+		if backlinks[i].Path != backlinks[j].Path {
+			return backlinks[i].Path < backlinks[j].Path
+		}
+		return backlinks[i].Link.Line < backlinks[j].Link.Line
+	})
+	return backlinks, nil
+}
+
+// linkTargetType returns the type of note a link in the note at path points
+// to, or "" if it can't be told
+func linkTargetType(path string, link markdown.Link) notes.NoteType {
+	if noteType := link.GetNoteTypeFromDestination(); noteType != "" {
+		return notes.NoteType(noteType)
+	}
+	return notes.TypeFromPath(path)
+}
+
+// NoteTypeOf returns the type of the note at path: that of the configured
+// journal or standup directory containing it, or else of the innermost
+// journal/ or standup/ directory in the path. It returns "" if neither tells.
+func NoteTypeOf(cfg *config.Config, path string) notes.NoteType {
+	if cfg != nil {
+		if noteType := cfg.NoteTypeForPath(path); noteType != "" {
+			return notes.NoteType(noteType)
+		}
+	}
+	return notes.TypeFromPath(path)
+}
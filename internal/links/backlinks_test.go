@@ -0,0 +1,116 @@
+package links
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rdark/za/internal/notes"
+)
+
+func TestFindBacklinks(t *testing.T) {
+	root := t.TempDir()
+	journalDir := filepath.Join(root, "journal")
+	standupDir := filepath.Join(root, "standup")
+
+	files := map[string]string{
+		// Links to the 2025-01-07 journal
+		"journal/2025-01-08.md":      "# Log\n\n[Yesterday](2025-01-07)\n\n[Standup](../standup/2025-01-08)\n",
+		"journal/2025-01-06.md":      "# Log\n\n[Tomorrow](2025-01-07.md#goals)\n",
+		"standup/2025-01-07.md":      "# Standup\n\n[Journal](../journal/2025-01-07)\n\n[Yesterday](2025-01-06)\n",
+		"journal/2025/2025-01-09.md": "# Log\n\nSee [[2025-01-07|the 7th]]\n",
+		// Not links to the 2025-01-07 journal
+		"standup/2025-01-08.md": "# Standup\n\n[Yesterday](2025-01-07)\n",
+		"journal/2025-01-10.md": "# Log\n\n[Release](https://example.com/2025-01-07)\n",
+		"journal/notes.txt":     "[Yesterday](2025-01-07)\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	target := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+	backlinks, err := FindBacklinks(target, notes.NoteTypeJournal, journalDir, standupDir, filepath.Join(root, "missing"))
+	if err != nil {
+		t.Fatalf("FindBacklinks() error = %v", err)
+	}
+
+	want := []struct {
+		path string
+		text string
+	}{
+		{"journal/2025-01-06.md", "Tomorrow"},
+		{"journal/2025-01-08.md", "Yesterday"},
+		{"journal/2025/2025-01-09.md", "the 7th"},
+		{"standup/2025-01-07.md", "Journal"},
+	}
+	if len(backlinks) != len(want) {
+		t.Fatalf("FindBacklinks() returned %d backlinks, want %d: %+v", len(backlinks), len(want), backlinks)
+	}
+	for i, w := range want {
+		if backlinks[i].Path != filepath.Join(root, w.path) || backlinks[i].Link.Text != w.text {
+			t.Errorf("backlink %d = %s %q, want %s %q", i, backlinks[i].Path, backlinks[i].Link.Text, w.path, w.text)
+		}
+	}
+
+	// The standup for the same date has its own backlink
+	standupLinks, err := FindBacklinks(target, notes.NoteTypeStandup, journalDir, standupDir)
+	if err != nil {
+		t.Fatalf("FindBacklinks() error = %v", err)
+	}
+	if len(standupLinks) != 1 || standupLinks[0].Path != filepath.Join(standupDir, "2025-01-08.md") {
+		t.Errorf("FindBacklinks(standup) = %+v, want only standup/2025-01-08.md", standupLinks)
+	}
+
+	if _, err := FindBacklinks(target, notes.NoteType("diary"), journalDir); !errors.Is(err, notes.ErrInvalidNoteType) {
+		t.Errorf("FindBacklinks(diary) error = %v, want ErrInvalidNoteType", err)
+	}
+}
+
+func TestFindBacklinksByName(t *testing.T) {
+	root := t.TempDir()
+	journalDir := filepath.Join(root, "journal")
+	standupDir := filepath.Join(root, "standup")
+
+	files := map[string]string{
+		"journal/2025-01-07.md": "# Log\n\n[Yesterday](2025-01-6)\n\n[Other](2025-01-16)\n",
+		"standup/2025-01-06.md": "# Standup\n\n[Journal](../journal/2025-01-6.md#goals)\n\n[Yesterday](2025-01-6)\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	backlinks, err := FindBacklinksByName("2025-01-6.md", notes.NoteTypeJournal, journalDir, standupDir)
+	if err != nil {
+		t.Fatalf("FindBacklinksByName() error = %v", err)
+	}
+
+	want := []struct {
+		path        string
+		destination string
+	}{
+		{"journal/2025-01-07.md", "2025-01-6"},
+		{"standup/2025-01-06.md", "../journal/2025-01-6.md#goals"},
+	}
+	if len(backlinks) != len(want) {
+		t.Fatalf("FindBacklinksByName() returned %d backlinks, want %d: %+v", len(backlinks), len(want), backlinks)
+	}
+	for i, w := range want {
+		if backlinks[i].Path != filepath.Join(root, w.path) || backlinks[i].Link.Destination != w.destination {
+			t.Errorf("backlink %d = %s %q, want %s %q", i, backlinks[i].Path, backlinks[i].Link.Destination, w.path, w.destination)
+		}
+	}
+}
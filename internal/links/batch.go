@@ -0,0 +1,121 @@
+package links
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+)
+
+// FileResult is the outcome of resolving the links in one note
+type FileResult struct {
+	// Path is the note's path
+	Path string
+
+	// Document is the parsed note, for applying the fixes
+	Document *markdown.Document
+
+	// Links are all the links in the note
+	Links []markdown.Link
+
+	// Fixes are the links that need updating, including any that couldn't
+	// be resolved
+	Fixes []ResolvedLink
+
+	// Err is set if the note couldn't be read or isn't a dated note
+	Err error
+}
+
+// BatchOption configures BatchFix
+type BatchOption func(*batchOptions)
+
+// batchOptions holds the settings applied by BatchOption values
+type batchOptions struct {
+	linkTypes []LinkType
+	trace     bool
+}
+
+// WithLinkTypes only resolves links of the given types
+func WithLinkTypes(linkTypes ...LinkType) BatchOption {
+	return func(o *batchOptions) {
+		o.linkTypes = linkTypes
+	}
+}
+
+// WithTrace records a Trace in each resolved link
+func WithTrace(trace bool) BatchOption {
+	return func(o *batchOptions) {
+		o.trace = trace
+	}
+}
+
+// BatchFix parses the notes in files and resolves their links, spread over
+// up to GOMAXPROCS workers that share one directory cache, so each note
+// directory is only read once. Results are in the same order as files.
+// Nothing is written; apply each result's Fixes to its Document.
+func BatchFix(files []string, cfg *config.Config, opts ...BatchOption) []FileResult {
+	var o batchOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	results := make([]FileResult, len(files))
+	cache := notes.NewIndexCache()
+
+	workers := min(runtime.GOMAXPROCS(0), len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parser := markdown.NewParser()
+			for i := range jobs {
+				results[i] = fixFile(files[i], cfg, parser, cache, o)
+			}
+		}()
+	}
+
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// fixFile resolves the links in the note at path for BatchFix
+func fixFile(path string, cfg *config.Config, parser *markdown.Parser, cache *notes.IndexCache, o batchOptions) FileResult {
+	result := FileResult{Path: path}
+
+	noteType := NoteTypeOf(cfg, path)
+	if noteType == "" {
+		result.Err = fmt.Errorf("cannot determine note type from path: %s (expected path to contain 'journal' or 'standup' directory)", path)
+		return result
+	}
+
+	date, err := notes.ParseDateFromFilename(path, notes.WithFilenameFormat(cfg.FilenameFormatFor(string(noteType))))
+	if err != nil {
+		result.Err = fmt.Errorf("failed to parse date from filename: %w", err)
+		return result
+	}
+
+	doc, err := parser.ParseFile(path)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to parse file: %w", err)
+		return result
+	}
+
+	result.Document = doc
+	result.Links = doc.ExtractLinks()
+	resolver := NewResolver(cfg, date, noteType, notes.WithIndexCache(cache))
+	resolver.SetTrace(o.trace)
+	resolver.SetNoteDir(filepath.Dir(path))
+	result.Fixes = resolver.ResolveFixes(result.Links, o.linkTypes...)
+	return result
+}
@@ -0,0 +1,147 @@
+package links
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/markdown"
+	"github.com/rdark/za/internal/notes"
+)
+
+// writeVault creates days weekday journal and standup notes from start, each
+// linking to the previous and next calendar day, so links across weekends
+// need fixing. It returns the config and the journal note paths.
+func writeVault(tb testing.TB, start time.Time, days int) (*config.Config, []string) {
+	tb.Helper()
+	root := tb.TempDir()
+	journalDir := filepath.Join(root, "journal")
+	standupDir := filepath.Join(root, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			tb.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	var paths []string
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i)
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+		content := fmt.Sprintf("# %s\n\n[Yesterday](%s) [Tomorrow](%s) [Standup](../standup/%s)\n",
+			date.Format(notes.DateFormat),
+			date.AddDate(0, 0, -1).Format(notes.DateFormat),
+			date.AddDate(0, 0, 1).Format(notes.DateFormat),
+			date.Format(notes.DateFormat))
+		for _, dir := range []string{journalDir, standupDir} {
+			path := filepath.Join(dir, notes.GenerateFilename(date))
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				tb.Fatalf("failed to write note: %v", err)
+			}
+		}
+		paths = append(paths, filepath.Join(journalDir, notes.GenerateFilename(date)))
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+	return cfg, paths
+}
+
+func TestBatchFix(t *testing.T) {
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	cfg, paths := writeVault(t, start, 28)
+	paths = append(paths, filepath.Join(filepath.Dir(paths[0]), "notes.md"))
+
+	results := BatchFix(paths, cfg)
+	if len(results) != len(paths) {
+		t.Fatalf("BatchFix() returned %d results, want %d", len(results), len(paths))
+	}
+
+	for i, result := range results {
+		if result.Path != paths[i] {
+			t.Fatalf("results[%d].Path = %s, want %s", i, result.Path, paths[i])
+		}
+		if i == len(paths)-1 {
+			if result.Err == nil {
+				t.Errorf("BatchFix() should fail for an undated note")
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Fatalf("results[%d].Err = %v", i, result.Err)
+		}
+
+		// Each result matches resolving the note on its own
+		date, _ := notes.ParseDateFromFilename(result.Path)
+		want := NewResolver(cfg, date, notes.NoteTypeJournal).ResolveFixes(result.Document.ExtractLinks())
+		if !reflect.DeepEqual(result.Fixes, want) {
+			t.Errorf("results[%d].Fixes = %+v, want %+v", i, result.Fixes, want)
+		}
+	}
+
+	// Friday's Tomorrow link skips the weekend to Monday
+	friday := results[4]
+	if len(friday.Fixes) != 1 || friday.Fixes[0].SuggestedDestination != "2025-01-13" {
+		t.Errorf("Friday fixes = %+v, want Tomorrow -> 2025-01-13", friday.Fixes)
+	}
+}
+
+func TestBatchFixLinkTypes(t *testing.T) {
+	cfg, paths := writeVault(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), 14)
+
+	for _, result := range BatchFix(paths, cfg, WithLinkTypes(LinkTypeTemporalPrevious)) {
+		if result.Err != nil {
+			t.Fatalf("%s: %v", result.Path, result.Err)
+		}
+		for _, fix := range result.Fixes {
+			if fix.Classified.Type != LinkTypeTemporalPrevious {
+				t.Errorf("%s: fixed %s link, want only %s", result.Path, fix.Classified.Type, LinkTypeTemporalPrevious)
+			}
+		}
+	}
+}
+
+func TestBatchFixEmpty(t *testing.T) {
+	if results := BatchFix(nil, config.DefaultConfig()); len(results) != 0 {
+		t.Errorf("BatchFix(nil) = %v, want no results", results)
+	}
+}
+
+// BenchmarkBatchFix compares BatchFix against resolving each note on its own
+// over a vault of 500 journal notes
+func BenchmarkBatchFix(b *testing.B) {
+	// 700 days gives 500 weekday notes
+	cfg, paths := writeVault(b, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC), 700)
+	if len(paths) != 500 {
+		b.Fatalf("vault has %d notes, want 500", len(paths))
+	}
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, result := range BatchFix(paths, cfg) {
+				if result.Err != nil {
+					b.Fatalf("%s: %v", result.Path, result.Err)
+				}
+			}
+		}
+	})
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, path := range paths {
+				doc, err := markdown.NewParser().ParseFile(path)
+				if err != nil {
+					b.Fatalf("%s: %v", path, err)
+				}
+				date, _ := notes.ParseDateFromFilename(path)
+				NewResolver(cfg, date, notes.NoteTypeJournal).ResolveFixes(doc.ExtractLinks())
+			}
+		}
+	})
+}
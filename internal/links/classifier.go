@@ -5,6 +5,9 @@
 package links
 
 import (
+	"fmt"
+	"path"
+	"regexp"
 	"strings"
 
 	"github.com/rdark/za/internal/config"
@@ -21,12 +24,22 @@ const (
 	// LinkTypeTemporalNext represents links to next entries (Tomorrow, Next, etc.)
 	LinkTypeTemporalNext LinkType = "temporal_next"
 
+	// LinkTypeTemporalWeekPrevious represents links to the entry about a week earlier (Last Week)
+	LinkTypeTemporalWeekPrevious LinkType = "temporal_week_previous"
+
+	// LinkTypeTemporalWeekNext represents links to the entry about a week later (Next Week)
+	LinkTypeTemporalWeekNext LinkType = "temporal_week_next"
+
 	// LinkTypeCrossReference represents links between different note types (Journal <-> Standup)
 	LinkTypeCrossReference LinkType = "cross_reference"
 
 	// LinkTypeExternal represents external URLs
 	LinkTypeExternal LinkType = "external"
 
+	// LinkTypeAttachment represents relative links to files other than notes
+	// (images, PDFs, etc.)
+	LinkTypeAttachment LinkType = "attachment"
+
 	// LinkTypeOther represents other types of links (wiki links, etc.)
 	LinkTypeOther LinkType = "other"
 )
@@ -41,6 +54,10 @@ type ClassifiedLink struct {
 
 	// TargetNoteType is the type of note this link points to (if applicable)
 	TargetNoteType string
+
+	// dateFormats are the configured filename formats the link was
+	// classified with, so NeedsFixing recognises the same date links
+	dateFormats []string
 }
 
 // Classifier classifies markdown links
@@ -56,8 +73,9 @@ func NewClassifier(cfg *config.Config) *Classifier {
 // Classify classifies a single link
 func (c *Classifier) Classify(link markdown.Link) ClassifiedLink {
 	classified := ClassifiedLink{
-		Link: link,
-		Type: LinkTypeOther,
+		Link:        link,
+		Type:        LinkTypeOther,
+		dateFormats: c.cfg.FilenameFormats(),
 	}
 
 	// Check if it's an external link
@@ -67,14 +85,32 @@ func (c *Classifier) Classify(link markdown.Link) ClassifiedLink {
 	}
 
 	// Check if it's a date link
-	if !link.IsDateLink() {
-		// Not a date link, might be wiki link or other
+	if !link.IsDateLink(classified.dateFormats...) {
+		// Not a date link, might be an attachment, wiki link or other
+		if isAttachment(link) {
+			classified.Type = LinkTypeAttachment
+		}
 		return classified
 	}
 
 	// It's a date link - determine if it's temporal or cross-reference
 	linkText := strings.ToLower(strings.TrimSpace(link.Text))
 
+	// Check for weekly synonyms before daily ones, so "Last Week" wins even if
+	// it is also listed as a previous title
+	if c.matchesAny(linkText, c.cfg.Journal.LinkPreviousWeekTitles) ||
+		c.matchesAny(linkText, c.cfg.Standup.LinkPreviousWeekTitles) {
+		classified.Type = LinkTypeTemporalWeekPrevious
+		classified.TargetNoteType = link.GetNoteTypeFromDestination()
+		return classified
+	}
+	if c.matchesAny(linkText, c.cfg.Journal.LinkNextWeekTitles) ||
+		c.matchesAny(linkText, c.cfg.Standup.LinkNextWeekTitles) {
+		classified.Type = LinkTypeTemporalWeekNext
+		classified.TargetNoteType = link.GetNoteTypeFromDestination()
+		return classified
+	}
+
 	// Check for temporal previous synonyms
 	if c.matchesAny(linkText, c.cfg.Journal.LinkPreviousTitles) ||
 		c.matchesAny(linkText, c.cfg.Standup.LinkPreviousTitles) {
@@ -102,6 +138,20 @@ func (c *Classifier) Classify(link markdown.Link) ClassifiedLink {
 	return classified
 }
 
+// schemePattern matches a URI scheme such as "mailto:"
+var schemePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+
+// isAttachment reports whether link is a relative link to a file with an
+// extension other than .md, e.g. img.png or ./report.pdf
+func isAttachment(link markdown.Link) bool {
+	target := link.Path()
+	if target == "" || strings.HasPrefix(target, "/") || schemePattern.MatchString(target) {
+		return false
+	}
+	ext := path.Ext(target)
+	return ext != "" && ext != ".md"
+}
+
 // ClassifyAll classifies all links in a list
 func (c *Classifier) ClassifyAll(links []markdown.Link) []ClassifiedLink {
 	classified := make([]ClassifiedLink, 0, len(links))
@@ -121,17 +171,25 @@ func (c *Classifier) matchesAny(text string, patterns []string) bool {
 	return false
 }
 
+// CrossReferencePatterns are the lowercase words that mark link text as a
+// cross-reference to another note type
+var CrossReferencePatterns = []string{
+	"standup",
+	"journal",
+	"daily",
+	"daily log",
+}
+
 // isCrossReference checks if the link text indicates a cross-reference
 func (c *Classifier) isCrossReference(linkText string) bool {
-	// Common cross-reference patterns
-	crossRefPatterns := []string{
-		"standup",
-		"journal",
-		"daily",
-		"daily log",
-	}
+	return IsCrossReferenceText(linkText)
+}
 
-	for _, pattern := range crossRefPatterns {
+// IsCrossReferenceText reports whether link text (case-insensitive) contains
+// one of the CrossReferencePatterns
+func IsCrossReferenceText(linkText string) bool {
+	linkText = strings.ToLower(linkText)
+	for _, pattern := range CrossReferencePatterns {
 		if strings.Contains(linkText, pattern) {
 			return true
 		}
@@ -151,18 +209,69 @@ func FilterByType(links []ClassifiedLink, linkType LinkType) []ClassifiedLink {
 	return filtered
 }
 
-// NeedsFixing returns true if a classified link might need fixing
-// Temporal and cross-reference links with date destinations are candidates for fixing
-func (l *ClassifiedLink) NeedsFixing() bool {
-	switch l.Type {
-	case LinkTypeTemporalPrevious, LinkTypeTemporalNext, LinkTypeCrossReference:
-		// These types might need fixing if they have a date
-		return l.Link.IsDateLink()
+// FilterByTypes filters classified links to those matching any of the given types
+func FilterByTypes(links []ClassifiedLink, linkTypes ...LinkType) []ClassifiedLink {
+	allowed := make(map[LinkType]bool, len(linkTypes))
+	for _, t := range linkTypes {
+		allowed[t] = true
+	}
+
+	var filtered []ClassifiedLink
+	for _, link := range links {
+		if allowed[link.Type] {
+			filtered = append(filtered, link)
+		}
+	}
+	return filtered
+}
+
+// ParseLinkType parses a user-supplied link type name into a LinkType.
+// Accepts the canonical names (e.g. "temporal_previous") as well as the
+// short forms "previous", "next", "previous-week", "next-week" and
+// "cross-reference".
+func ParseLinkType(name string) (LinkType, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "previous", string(LinkTypeTemporalPrevious):
+		return LinkTypeTemporalPrevious, nil
+	case "next", string(LinkTypeTemporalNext):
+		return LinkTypeTemporalNext, nil
+	case "previous-week", string(LinkTypeTemporalWeekPrevious):
+		return LinkTypeTemporalWeekPrevious, nil
+	case "next-week", string(LinkTypeTemporalWeekNext):
+		return LinkTypeTemporalWeekNext, nil
+	case "cross-reference", "crossref", string(LinkTypeCrossReference):
+		return LinkTypeCrossReference, nil
+	case string(LinkTypeExternal):
+		return LinkTypeExternal, nil
+	case string(LinkTypeAttachment):
+		return LinkTypeAttachment, nil
+	case string(LinkTypeOther):
+		return LinkTypeOther, nil
+	default:
+		return "", fmt.Errorf("unknown link type: %q (expected previous, next, previous-week, next-week or cross-reference)", name)
+	}
+}
+
+// IsFixableType reports whether links of linkType can be fixed: the temporal
+// and cross-reference types
+func IsFixableType(linkType LinkType) bool {
+	switch linkType {
+	case LinkTypeTemporalPrevious, LinkTypeTemporalNext,
+		LinkTypeTemporalWeekPrevious, LinkTypeTemporalWeekNext,
+		LinkTypeCrossReference:
+		return true
 	default:
 		return false
 	}
 }
 
+// NeedsFixing returns true if a classified link might need fixing
+// Temporal and cross-reference links with date destinations are candidates for fixing
+func (l *ClassifiedLink) NeedsFixing() bool {
+	// These types might need fixing if they have a date
+	return IsFixableType(l.Type) && l.Link.IsDateLink(l.dateFormats...)
+}
+
 // IsNextLink returns true if this is a temporal "next" link
 func (l *ClassifiedLink) IsNextLink() bool {
 	return l.Type == LinkTypeTemporalNext
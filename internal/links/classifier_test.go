@@ -83,6 +83,46 @@ func TestClassify(t *testing.T) {
 			},
 			expectedType: LinkTypeOther,
 		},
+		{
+			name: "image attachment",
+			link: markdown.Link{
+				Text:        "",
+				Destination: "img.png",
+			},
+			expectedType: LinkTypeAttachment,
+		},
+		{
+			name: "pdf attachment",
+			link: markdown.Link{
+				Text:        "pdf",
+				Destination: "./attachments/report.pdf",
+			},
+			expectedType: LinkTypeAttachment,
+		},
+		{
+			name: "attachment named for a date",
+			link: markdown.Link{
+				Text:        "Whiteboard",
+				Destination: "2025-01-06.png",
+			},
+			expectedType: LinkTypeAttachment,
+		},
+		{
+			name: "non-date note link",
+			link: markdown.Link{
+				Text:        "Project",
+				Destination: "../projects/roadmap.md",
+			},
+			expectedType: LinkTypeOther,
+		},
+		{
+			name: "mailto link",
+			link: markdown.Link{
+				Text:        "Email",
+				Destination: "mailto:someone@example.com",
+			},
+			expectedType: LinkTypeOther,
+		},
 		{
 			name: "case insensitive yesterday",
 			link: markdown.Link{
@@ -97,7 +137,15 @@ func TestClassify(t *testing.T) {
 				Text:        "Last Week",
 				Destination: "2024-12-30",
 			},
-			expectedType: LinkTypeTemporalPrevious,
+			expectedType: LinkTypeTemporalWeekPrevious,
+		},
+		{
+			name: "next week synonym",
+			link: markdown.Link{
+				Text:        "Next Week",
+				Destination: "2025-01-13",
+			},
+			expectedType: LinkTypeTemporalWeekNext,
 		},
 	}
 
@@ -323,3 +371,58 @@ func TestCrossReferencePatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterByTypes(t *testing.T) {
+	links := []ClassifiedLink{
+		{Link: markdown.Link{Text: "Yesterday"}, Type: LinkTypeTemporalPrevious},
+		{Link: markdown.Link{Text: "Tomorrow"}, Type: LinkTypeTemporalNext},
+		{Link: markdown.Link{Text: "External"}, Type: LinkTypeExternal},
+		{Link: markdown.Link{Text: "Standup"}, Type: LinkTypeCrossReference},
+	}
+
+	temporal := FilterByTypes(links, LinkTypeTemporalPrevious, LinkTypeTemporalNext)
+	if len(temporal) != 2 {
+		t.Errorf("FilterByTypes(previous, next) = %d links, want 2", len(temporal))
+	}
+	for _, l := range temporal {
+		if l.Type == LinkTypeCrossReference {
+			t.Errorf("FilterByTypes(previous, next) should not include cross-references")
+		}
+	}
+
+	none := FilterByTypes(links)
+	if len(none) != 0 {
+		t.Errorf("FilterByTypes() with no types = %d links, want 0", len(none))
+	}
+}
+
+func TestParseLinkType(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    LinkType
+		wantErr bool
+	}{
+		{"previous", LinkTypeTemporalPrevious, false},
+		{"Next", LinkTypeTemporalNext, false},
+		{"cross-reference", LinkTypeCrossReference, false},
+		{"previous-week", LinkTypeTemporalWeekPrevious, false},
+		{"temporal_week_next", LinkTypeTemporalWeekNext, false},
+		{"temporal_previous", LinkTypeTemporalPrevious, false},
+		{"cross_reference", LinkTypeCrossReference, false},
+		{"attachment", LinkTypeAttachment, false},
+		{"bogus", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLinkType(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLinkType(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseLinkType(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
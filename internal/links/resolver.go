@@ -1,11 +1,16 @@
 package links
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/rdark/za/internal/config"
+	"github.com/rdark/za/internal/markdown"
 	"github.com/rdark/za/internal/notes"
 )
 
@@ -28,6 +33,41 @@ type ResolvedLink struct {
 
 	// SuggestedDestination is the suggested new destination for the link
 	SuggestedDestination string
+
+	// SkippedDates are the days from the date the link wanted up to, but not
+	// including, ResolvedDate, i.e. the gap the link jumps over
+	SkippedDates []time.Time
+
+	// SkippedHolidays are the configured holidays among SkippedDates, which
+	// explain the gap
+	SkippedHolidays []time.Time
+
+	// Trace records how the link was resolved; it is only set if the
+	// resolver has tracing enabled (see SetTrace)
+	Trace *Trace
+}
+
+// Search directions recorded in a Trace
+const (
+	SearchBackward = "backward"
+	SearchForward  = "forward"
+	SearchSameDay  = "same day"
+)
+
+// Trace records the search made to resolve a link, for debugging
+type Trace struct {
+	// Direction is SearchBackward, SearchForward or SearchSameDay
+	Direction string
+
+	// From is the first date searched
+	From time.Time
+
+	// Days is the most dates the search could check
+	Days int
+
+	// Examined are the dates checked without finding a note, in search
+	// order. If a note was found, it is for the date after the last one.
+	Examined []time.Time
 }
 
 // Resolver resolves links to actual file paths
@@ -35,16 +75,58 @@ type Resolver struct {
 	cfg             *config.Config
 	currentDate     time.Time
 	currentNoteType notes.NoteType
+	opts            []notes.Option
+	trace           bool
+	sourceDir       string
 }
 
 // NewResolver creates a new link resolver
 // currentDate is the date of the current note being processed
 // currentNoteType is the type of the current note (journal or standup)
-func NewResolver(cfg *config.Config, currentDate time.Time, currentNoteType notes.NoteType) *Resolver {
+// opts are extra finder options for every search, e.g. notes.WithIndexCache
+func NewResolver(cfg *config.Config, currentDate time.Time, currentNoteType notes.NoteType, opts ...notes.Option) *Resolver {
 	return &Resolver{
 		cfg:             cfg,
 		currentDate:     currentDate,
 		currentNoteType: currentNoteType,
+		opts:            opts,
+	}
+}
+
+// SetTrace sets whether resolved links record a Trace of their search
+func (r *Resolver) SetTrace(trace bool) {
+	r.trace = trace
+}
+
+// SetNoteDir sets the directory of the note whose links are resolved, which
+// suggested destinations are relative to. If it isn't set, the note is
+// assumed to be where a new note of its type and date would be created.
+func (r *Resolver) SetNoteDir(dir string) {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	r.sourceDir = dir
+}
+
+// startTrace records in resolved the search about to be made, if tracing
+func (r *Resolver) startTrace(resolved *ResolvedLink, direction string, from time.Time, days int) {
+	if r.trace {
+		resolved.Trace = &Trace{Direction: direction, From: from, Days: days}
+	}
+}
+
+// traceNotFound records that every date in the traced search was examined
+func traceNotFound(resolved *ResolvedLink) {
+	trace := resolved.Trace
+	if trace == nil {
+		return
+	}
+	step := 1
+	if trace.Direction == SearchBackward {
+		step = -1
+	}
+	for i := 0; i < trace.Days; i++ {
+		trace.Examined = append(trace.Examined, trace.From.AddDate(0, 0, i*step))
 	}
 }
 
@@ -65,6 +147,10 @@ func (r *Resolver) Resolve(classified ClassifiedLink) ResolvedLink {
 		return r.resolvePreviousLink(classified)
 	case LinkTypeTemporalNext:
 		return r.resolveNextLink(classified)
+	case LinkTypeTemporalWeekPrevious:
+		return r.resolveWeekLink(classified, -7)
+	case LinkTypeTemporalWeekNext:
+		return r.resolveWeekLink(classified, 7)
 	case LinkTypeCrossReference:
 		return r.resolveCrossReference(classified)
 	default:
@@ -81,46 +167,29 @@ func (r *Resolver) resolvePreviousLink(classified ClassifiedLink) ResolvedLink {
 	// Determine target note type
 	targetType := r.determineTargetNoteType(classified)
 
-	// Get directory for target note type
-	dir, err := r.getDirForNoteType(targetType)
+	// Get directories for target note type
+	dirs, err := r.getDirsForNoteType(targetType)
 	if err != nil {
 		resolved.Error = err
 		return resolved
 	}
 
-	// Find previous note - start searching from the day before current
-	searchDate := r.currentDate.AddDate(0, 0, -1)
-	path, err := notes.FindNoteByDate(
-		searchDate,
+	// Find previous note - strictly before the current date
+	r.startTrace(&resolved, SearchBackward, r.currentDate.AddDate(0, 0, -1), r.cfg.BackwardWindowDays())
+	path, err := notes.FindPreviousNoteMulti(
+		r.currentDate,
 		targetType,
-		dir,
-		r.cfg.SearchWindowDays,
+		dirs,
+		r.cfg.BackwardWindowDays(),
+		r.finderOptions(targetType)...,
 	)
 	if err != nil {
 		resolved.Error = fmt.Errorf("failed to find previous note: %w", err)
+		traceNotFound(&resolved)
 		return resolved
 	}
 
-	// Extract date from path
-	date, err := notes.ParseDateFromFilename(path)
-	if err != nil {
-		resolved.Error = fmt.Errorf("failed to parse date from path: %w", err)
-		return resolved
-	}
-
-	resolved.ResolvedPath = path
-	resolved.ResolvedDate = date
-
-	// Check if link needs updating
-	currentDest := classified.Link.GetDateFromDestination()
-	suggestedDest := r.formatDestination(date, targetType)
-
-	if currentDest != date.Format(notes.DateFormat) {
-		resolved.NeedsUpdate = true
-		resolved.SuggestedDestination = suggestedDest
-	}
-
-	return resolved
+	return r.resolveToNote(resolved, path, targetType, r.currentDate.AddDate(0, 0, -1))
 }
 
 // resolveNextLink resolves a "next" temporal link
@@ -132,48 +201,34 @@ func (r *Resolver) resolveNextLink(classified ClassifiedLink) ResolvedLink {
 	// Determine target note type
 	targetType := r.determineTargetNoteType(classified)
 
-	// Get directory for target note type
-	dir, err := r.getDirForNoteType(targetType)
+	// Get directories for target note type
+	dirs, err := r.getDirsForNoteType(targetType)
 	if err != nil {
 		resolved.Error = err
 		return resolved
 	}
 
 	// Find next note
-	path, err := notes.FindNextNote(
+	r.startTrace(&resolved, SearchForward, r.currentDate.AddDate(0, 0, 1), r.cfg.ForwardWindowDays())
+	path, err := notes.FindNextNoteMulti(
 		r.currentDate,
 		targetType,
-		dir,
-		r.cfg.SearchWindowDays,
+		dirs,
+		r.cfg.ForwardWindowDays(),
+		r.finderOptions(targetType)...,
 	)
 	if err != nil {
 		resolved.Error = fmt.Errorf("failed to find next note: %w", err)
+		traceNotFound(&resolved)
 		return resolved
 	}
 
-	// Extract date from path
-	date, err := notes.ParseDateFromFilename(path)
-	if err != nil {
-		resolved.Error = fmt.Errorf("failed to parse date from path: %w", err)
-		return resolved
-	}
-
-	resolved.ResolvedPath = path
-	resolved.ResolvedDate = date
-
-	// Check if link needs updating
-	currentDest := classified.Link.GetDateFromDestination()
-	suggestedDest := r.formatDestination(date, targetType)
-
-	if currentDest != date.Format(notes.DateFormat) {
-		resolved.NeedsUpdate = true
-		resolved.SuggestedDestination = suggestedDest
-	}
-
-	return resolved
+	return r.resolveToNote(resolved, path, targetType, r.currentDate.AddDate(0, 0, 1))
 }
 
 // resolveCrossReference resolves a cross-reference link (e.g., journal -> standup)
+// to the note of the other type for the same date. If there isn't one, the
+// link is left alone, without an Error: the other note may not exist yet.
 func (r *Resolver) resolveCrossReference(classified ClassifiedLink) ResolvedLink {
 	resolved := ResolvedLink{
 		Classified: classified,
@@ -190,27 +245,85 @@ func (r *Resolver) resolveCrossReference(classified ClassifiedLink) ResolvedLink
 		}
 	}
 
-	// Get directory for target note type
-	dir, err := r.getDirForNoteType(targetType)
+	// Get directories for target note type
+	dirs, err := r.getDirsForNoteType(targetType)
 	if err != nil {
 		resolved.Error = err
 		return resolved
 	}
 
-	// Find note for the same date (cross-reference)
-	path, err := notes.FindNoteByDate(
+	// Only a note for the same date will do; falling back to an earlier
+	// note would point the cross-reference backwards
+	r.startTrace(&resolved, SearchSameDay, r.currentDate, 1)
+	paths, err := notes.FindNotesInRangeMulti(
+		r.currentDate,
 		r.currentDate,
 		targetType,
-		dir,
-		r.cfg.SearchWindowDays,
+		dirs,
+		r.finderOptions(targetType)...,
 	)
-	if err != nil {
+	if err != nil && !errors.Is(err, notes.ErrDirNotExist) {
 		resolved.Error = fmt.Errorf("failed to find cross-reference note: %w", err)
+		traceNotFound(&resolved)
 		return resolved
 	}
+	if len(paths) == 0 {
+		// The other note may not have been written yet, so the link is left
+		// unchanged rather than reported as unresolved
+		traceNotFound(&resolved)
+		return resolved
+	}
+
+	return r.resolveToNote(resolved, paths[0], targetType, r.currentDate)
+}
+
+// resolveWeekLink resolves a "last week" or "next week" link. It looks for the
+// note offsetDays away, falling back to the nearest earlier note for last week
+// and the nearest later note for next week.
+func (r *Resolver) resolveWeekLink(classified ClassifiedLink, offsetDays int) ResolvedLink {
+	resolved := ResolvedLink{
+		Classified: classified,
+	}
+
+	// Determine target note type
+	targetType := r.determineTargetNoteType(classified)
+
+	// Get directories for target note type
+	dirs, err := r.getDirsForNoteType(targetType)
+	if err != nil {
+		resolved.Error = err
+		return resolved
+	}
+
+	target := r.currentDate.AddDate(0, 0, offsetDays)
+
+	var path string
+	if offsetDays < 0 {
+		// FindNoteByDateMulti tries the target date, then searches backwards
+		r.startTrace(&resolved, SearchBackward, target, r.cfg.BackwardWindowDays()+1)
+		path, err = notes.FindNoteByDateMulti(target, targetType, dirs, r.cfg.BackwardWindowDays(), r.finderOptions(targetType)...)
+	} else {
+		// FindNextNoteMulti is strictly after, so start the day before the target
+		r.startTrace(&resolved, SearchForward, target, r.cfg.ForwardWindowDays())
+		path, err = notes.FindNextNoteMulti(target.AddDate(0, 0, -1), targetType, dirs, r.cfg.ForwardWindowDays(), r.finderOptions(targetType)...)
+	}
+	if err != nil {
+		resolved.Error = fmt.Errorf("failed to find note for %s: %w", target.Format(notes.DateFormat), err)
+		traceNotFound(&resolved)
+		return resolved
+	}
+
+	return r.resolveToNote(resolved, path, targetType, target)
+}
+
+// resolveToNote fills in resolved with the note found at path, marking the
+// link for update if it doesn't already point to that note's date. wanted is
+// the date the link would ideally point to.
+func (r *Resolver) resolveToNote(resolved ResolvedLink, path string, targetType notes.NoteType, wanted time.Time) ResolvedLink {
+	classified := resolved.Classified
 
 	// Extract date from path
-	date, err := notes.ParseDateFromFilename(path)
+	date, err := notes.ParseDateFromFilename(path, r.finderOptions(targetType)...)
 	if err != nil {
 		resolved.Error = fmt.Errorf("failed to parse date from path: %w", err)
 		return resolved
@@ -218,12 +331,17 @@ func (r *Resolver) resolveCrossReference(classified ClassifiedLink) ResolvedLink
 
 	resolved.ResolvedPath = path
 	resolved.ResolvedDate = date
+	resolved.SkippedDates = datesBetween(wanted, date)
+	resolved.SkippedHolidays = r.holidays(resolved.SkippedDates)
+	if resolved.Trace != nil {
+		resolved.Trace.Examined = datesBetween(resolved.Trace.From, date)
+	}
 
 	// Check if link needs updating
-	currentDest := classified.Link.GetDateFromDestination()
-	suggestedDest := r.formatDestination(date, targetType)
+	currentDest := classified.Link.GetDateFromDestination(r.cfg.FilenameFormats()...)
+	suggestedDest := r.suggestDestination(classified.Link, path, date, targetType)
 
-	if currentDest != date.Format(notes.DateFormat) {
+	if currentDest != date.Format(notes.DateFormat) || !r.linksTo(classified.Link, path) {
 		resolved.NeedsUpdate = true
 		resolved.SuggestedDestination = suggestedDest
 	}
@@ -231,6 +349,58 @@ func (r *Resolver) resolveCrossReference(classified ClassifiedLink) ResolvedLink
 	return resolved
 }
 
+// linksTo reports whether link, from the note in the directory set by
+// SetNoteDir, reaches the note at path. Without a note directory, or for wiki
+// links, which are resolved by name, any link is assumed to.
+func (r *Resolver) linksTo(link markdown.Link, path string) bool {
+	if r.sourceDir == "" || link.Wiki {
+		return true
+	}
+	target := link.Path()
+	if !strings.HasSuffix(target, ".md") {
+		target += ".md"
+	}
+	linked, err := os.Stat(filepath.Join(r.sourceDir, filepath.FromSlash(target)))
+	if err != nil {
+		return false
+	}
+	resolved, err := os.Stat(path)
+	return err == nil && os.SameFile(linked, resolved)
+}
+
+// datesBetween returns the days from wanted up to, but not including, found,
+// in order from wanted
+func datesBetween(wanted, found time.Time) []time.Time {
+	step := 1
+	if found.Before(wanted) {
+		step = -1
+	}
+
+	var dates []time.Time
+	for day := wanted; !sameDay(day, found); day = day.AddDate(0, 0, step) {
+		dates = append(dates, day)
+	}
+	return dates
+}
+
+// holidays returns the configured holidays among dates
+func (r *Resolver) holidays(dates []time.Time) []time.Time {
+	var holidays []time.Time
+	for _, day := range dates {
+		if r.cfg.IsHoliday(day) {
+			holidays = append(holidays, day)
+		}
+	}
+	return holidays
+}
+
+// sameDay reports whether a and b fall on the same calendar date
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
 // determineTargetNoteType determines the target note type from the classified link
 func (r *Resolver) determineTargetNoteType(classified ClassifiedLink) notes.NoteType {
 	// If we have a target note type from the link destination, use it
@@ -239,7 +409,9 @@ func (r *Resolver) determineTargetNoteType(classified ClassifiedLink) notes.Note
 	}
 
 	// Otherwise, for temporal links, assume same type as current note
-	if classified.Type == LinkTypeTemporalPrevious || classified.Type == LinkTypeTemporalNext {
+	switch classified.Type {
+	case LinkTypeTemporalPrevious, LinkTypeTemporalNext,
+		LinkTypeTemporalWeekPrevious, LinkTypeTemporalWeekNext:
 		return r.currentNoteType
 	}
 
@@ -250,7 +422,31 @@ func (r *Resolver) determineTargetNoteType(classified ClassifiedLink) notes.Note
 	return notes.NoteTypeJournal
 }
 
-// getDirForNoteType returns the directory path for a given note type
+// finderOptions returns the notes finder options for a note type derived from
+// the configuration
+func (r *Resolver) finderOptions(noteType notes.NoteType) []notes.Option {
+	return append([]notes.Option{
+		notes.WithSkipEmptyNotes(r.cfg.SkipEmptyNotes),
+		notes.WithFilenameFormat(r.cfg.FilenameFormatFor(string(noteType))),
+		notes.WithPathLayout(r.cfg.PathLayoutFor(string(noteType))),
+	}, r.opts...)
+}
+
+// getDirsForNoteType returns the directories to search for a given note type,
+// in priority order
+func (r *Resolver) getDirsForNoteType(noteType notes.NoteType) ([]string, error) {
+	if noteType == notes.NoteTypeJournal {
+		return r.cfg.JournalDirs()
+	}
+	dir, err := r.getDirForNoteType(noteType)
+	if err != nil {
+		return nil, err
+	}
+	return []string{dir}, nil
+}
+
+// getDirForNoteType returns the directory new notes of a given type are
+// created in
 func (r *Resolver) getDirForNoteType(noteType notes.NoteType) (string, error) {
 	switch noteType {
 	case notes.NoteTypeJournal:
@@ -262,16 +458,127 @@ func (r *Resolver) getDirForNoteType(noteType notes.NoteType) (string, error) {
 	}
 }
 
-// formatDestination formats a date and note type into a link destination
-// Uses relative path format: ../notetype/YYYY-MM-DD
-func (r *Resolver) formatDestination(date time.Time, targetType notes.NoteType) string {
-	// If target is same type as current, use simple date
-	if targetType == r.currentNoteType {
-		return date.Format(notes.DateFormat)
+// suggestDestination returns the destination a link should point to, keeping
+// the style of the original: its .md extension, its directory prefix when the
+// target is in the same directory, and any #fragment or ?query. Wiki links are
+// resolved by note name, so they get the bare name.
+func (r *Resolver) suggestDestination(link markdown.Link, targetPath string, date time.Time, targetType notes.NoteType) string {
+	if link.Wiki {
+		return r.noteName(date, targetType) + link.Fragment()
 	}
 
-	// Otherwise use relative path
-	return filepath.Join("..", string(targetType), date.Format(notes.DateFormat))
+	dest := filepath.ToSlash(r.formatDestination(targetPath, date, targetType))
+	original := link.Path()
+
+	// e.g. [Yesterday](../journal/2025-01-05.md) from a journal keeps ../journal/
+	if dir := path.Dir(original); dir != "." && path.Dir(dest) == "." {
+		dest = dir + "/" + dest
+	}
+	if strings.HasSuffix(original, ".md") {
+		dest += ".md"
+	}
+
+	return dest + link.Fragment()
+}
+
+// formatDestination formats the note at targetPath, for date and note type,
+// into a link destination. Uses a path relative to the current note's
+// directory, e.g. ../notetype/YYYY-MM-DD, or just the note name for a note in
+// the same directory
+func (r *Resolver) formatDestination(targetPath string, date time.Time, targetType notes.NoteType) string {
+	sameType := targetType == r.currentNoteType
+	name := r.noteName(date, targetType)
+
+	// Use the relative path between the two note directories, or the simple
+	// name if they're the same directory
+	fromDir, fromErr := r.currentNoteDir()
+	toDir := filepath.Dir(targetPath)
+	if fromErr != nil {
+		if sameType {
+			return name
+		}
+		return filepath.Join("..", string(targetType), name)
+	}
+	if sameType && resolveSymlinks(fromDir) == resolveSymlinks(toDir) {
+		return name
+	}
+	return filepath.Join(RelativeLinkDir(fromDir, toDir), name)
+}
+
+// noteName returns the filename, without .md, of the note of targetType for
+// date in the configured filename format
+func (r *Resolver) noteName(date time.Time, targetType notes.NoteType) string {
+	return strings.TrimSuffix(notes.GenerateFilename(date, r.finderOptions(targetType)...), ".md")
+}
+
+// currentNoteDir returns the directory of the note whose links are resolved
+func (r *Resolver) currentNoteDir() (string, error) {
+	if r.sourceDir != "" {
+		return r.sourceDir, nil
+	}
+	return r.noteDir(r.currentNoteType, r.currentDate)
+}
+
+// noteDir returns the directory a note of the given type and date lives in,
+// including any subdirectories from the configured path layout
+func (r *Resolver) noteDir(noteType notes.NoteType, date time.Time) (string, error) {
+	dir, err := r.getDirForNoteType(noteType)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(notes.NotePath(dir, date, r.finderOptions(noteType)...)), nil
+}
+
+// RelativeLinkDir returns the path of toDir relative to fromDir, for use as
+// the directory part of a link destination. Symlinks are resolved first so
+// that links stay correct when note directories are symlinked into the vault.
+// If fromDir and toDir are the same directory, or no relative path exists,
+// it falls back to ../<base of toDir>.
+func RelativeLinkDir(fromDir, toDir string) string {
+	from := resolveSymlinks(fromDir)
+	to := resolveSymlinks(toDir)
+
+	rel, err := filepath.Rel(from, to)
+	if err != nil || rel == "." {
+		return filepath.Join("..", filepath.Base(toDir))
+	}
+	return rel
+}
+
+// resolveSymlinks returns dir with symlinks evaluated, or dir unchanged if it
+// can't be evaluated (e.g. it doesn't exist yet)
+func resolveSymlinks(dir string) string {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+	return dir
+}
+
+// ResolveFixes classifies links from the resolver's note and resolves those
+// that need fixing, returning the ones that need updating, including any that
+// couldn't be resolved. If linkTypes are given, only links of those types are
+// considered.
+func (r *Resolver) ResolveFixes(allLinks []markdown.Link, linkTypes ...LinkType) []ResolvedLink {
+	classified := NewClassifier(r.cfg).ClassifyAll(allLinks)
+	if len(linkTypes) > 0 {
+		classified = FilterByTypes(classified, linkTypes...)
+	}
+
+	var fixable []ClassifiedLink
+	for _, c := range classified {
+		if c.NeedsFixing() {
+			fixable = append(fixable, c)
+		}
+	}
+
+	// Keep failures so they can be reported
+	var needsUpdate []ResolvedLink
+	for _, resolved := range r.ResolveAll(fixable) {
+		if resolved.NeedsUpdate || resolved.Error != nil {
+			needsUpdate = append(needsUpdate, resolved)
+		}
+	}
+	return needsUpdate
 }
 
 // ResolveAll resolves all classified links
@@ -1,6 +1,9 @@
 package links
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -127,6 +130,37 @@ func TestResolveCrossReference(t *testing.T) {
 	t.Logf("Resolved cross-reference: %s", resolved.ResolvedPath)
 }
 
+func TestResolveCrossReferenceMissingSameDate(t *testing.T) {
+	root := t.TempDir()
+	journalDir := filepath.Join(root, "journal")
+	standupDir := filepath.Join(root, "standup")
+	for _, dir := range []string{journalDir, standupDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+
+	// Only an earlier standup exists
+	if err := os.WriteFile(filepath.Join(standupDir, "2025-01-06.md"), []byte("# Standup\n"), 0644); err != nil {
+		t.Fatalf("failed to write standup: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	resolver := NewResolver(cfg, time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), notes.NoteTypeJournal)
+	link := markdown.Link{Text: "Standup", Destination: "../standup/2025-01-08"}
+	resolved := resolver.Resolve(NewClassifier(cfg).Classify(link))
+
+	if resolved.Error != nil {
+		t.Errorf("Resolve() error = %v, want nil", resolved.Error)
+	}
+	if resolved.NeedsUpdate || resolved.SuggestedDestination != "" || resolved.ResolvedPath != "" {
+		t.Errorf("Resolve() = %+v, want the link left unchanged", resolved)
+	}
+}
+
 func TestResolveWeekendGap(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Journal.Dir = "../../testdata/journal"
@@ -297,3 +331,801 @@ func TestResolveWithDifferentNoteTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveSkipsEmptyNotes(t *testing.T) {
+	tmpDir := t.TempDir()
+	journalDir := filepath.Join(tmpDir, "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	// 01-07 is a frontmatter-only placeholder between two real notes
+	files := map[string]string{
+		"2025-01-06": "# Daily Log\n",
+		"2025-01-07": "---\ntitle: placeholder\n---\n",
+		"2025-01-08": "# Daily Log\n",
+	}
+	for dateStr, content := range files {
+		if err := os.WriteFile(filepath.Join(journalDir, dateStr+".md"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.SkipEmptyNotes = true
+
+	currentDate := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	resolver := NewResolver(cfg, currentDate, notes.NoteTypeJournal)
+	classified := NewClassifier(cfg).Classify(markdown.Link{
+		Text:        "Yesterday",
+		Destination: "2025-01-07",
+	})
+
+	resolved := resolver.Resolve(classified)
+	if resolved.Error != nil {
+		t.Fatalf("Resolve() error = %v", resolved.Error)
+	}
+	if resolved.SuggestedDestination != "2025-01-06" {
+		t.Errorf("SuggestedDestination = %q, want %q", resolved.SuggestedDestination, "2025-01-06")
+	}
+}
+
+func TestRelativeLinkDir(t *testing.T) {
+	tests := []struct {
+		name    string
+		fromDir string
+		toDir   string
+		want    string
+	}{
+		{"siblings at vault root", "/vault/journal", "/vault/standup", "../standup"},
+		{"siblings nested in vault", "/vault/notes/daily/journal", "/vault/notes/daily/standup", "../standup"},
+		{"different depths", "/vault/journal", "/vault/work/standup", "../work/standup"},
+		{"deeper source", "/vault/personal/2025/journal", "/vault/standup", "../../../standup"},
+		{"same directory", "/vault/notes", "/vault/notes", "../notes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RelativeLinkDir(tt.fromDir, tt.toDir); got != tt.want {
+				t.Errorf("RelativeLinkDir(%q, %q) = %q, want %q", tt.fromDir, tt.toDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveCrossReferenceWithVaultRoot(t *testing.T) {
+	tests := []struct {
+		name       string
+		journalDir string
+		standupDir string
+		want       string
+	}{
+		{"flat layout", "journal", "standup", "../standup/2025-01-07"},
+		{"nested standup", "journal", "work/standup", "../work/standup/2025-01-07"},
+		{"nested journal", "personal/2025/journal", "standup", "../../../standup/2025-01-07"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vaultRoot := t.TempDir()
+			cfg := config.DefaultConfig()
+			cfg.VaultRoot = vaultRoot
+			cfg.Journal.Dir = tt.journalDir
+			cfg.Standup.Dir = tt.standupDir
+
+			standupDir := filepath.Join(vaultRoot, tt.standupDir)
+			if err := os.MkdirAll(standupDir, 0755); err != nil {
+				t.Fatalf("failed to create dir: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(standupDir, "2025-01-07.md"), []byte("# Standup\n"), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+
+			currentDate := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+			resolver := NewResolver(cfg, currentDate, notes.NoteTypeJournal)
+			classified := NewClassifier(cfg).Classify(markdown.Link{
+				Text:        "Standup",
+				Destination: "../standup/2025-01-06",
+			})
+
+			resolved := resolver.Resolve(classified)
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+			if resolved.SuggestedDestination != tt.want {
+				t.Errorf("SuggestedDestination = %q, want %q", resolved.SuggestedDestination, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveWithFilenameFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	journalDir := filepath.Join(tempDir, "journal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, path := range []string{
+		filepath.Join(journalDir, "20250103.md"),
+		filepath.Join(journalDir, "20250107.md"),
+		filepath.Join(standupDir, "2025-01-06-standup.md"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("# Note\n"), 0644); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.FilenameFormat = "20060102"
+	cfg.Standup.FilenameFormat = "2006-01-02-standup"
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = standupDir
+
+	tests := []struct {
+		name        string
+		link        markdown.Link
+		wantUpdate  bool
+		wantDest    string
+		wantFixable bool
+	}{
+		{
+			name:        "stale previous link",
+			link:        markdown.Link{Text: "Yesterday", Destination: "20250105"},
+			wantUpdate:  true,
+			wantDest:    "20250103",
+			wantFixable: true,
+		},
+		{
+			name:        "correct next link with extension",
+			link:        markdown.Link{Text: "Tomorrow", Destination: "20250107.md"},
+			wantFixable: true,
+		},
+		{
+			name:        "stale wiki link",
+			link:        markdown.Link{Text: "Yesterday", Destination: "20250105", Wiki: true},
+			wantUpdate:  true,
+			wantDest:    "20250103",
+			wantFixable: true,
+		},
+		{
+			name:        "stale cross-reference",
+			link:        markdown.Link{Text: "Standup", Destination: "../standup/2025-01-05-standup"},
+			wantUpdate:  true,
+			wantDest:    "../standup/2025-01-06-standup",
+			wantFixable: true,
+		},
+	}
+
+	classifier := NewClassifier(cfg)
+	resolver := NewResolver(cfg, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), notes.NoteTypeJournal)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classified := classifier.Classify(tt.link)
+			if classified.NeedsFixing() != tt.wantFixable {
+				t.Fatalf("NeedsFixing() = %v for %s link %q, want %v",
+					classified.NeedsFixing(), classified.Type, tt.link.Destination, tt.wantFixable)
+			}
+
+			resolved := resolver.Resolve(classified)
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+			if resolved.NeedsUpdate != tt.wantUpdate || resolved.SuggestedDestination != tt.wantDest {
+				t.Errorf("Resolve() = update %v to %q, want update %v to %q",
+					resolved.NeedsUpdate, resolved.SuggestedDestination, tt.wantUpdate, tt.wantDest)
+			}
+		})
+	}
+}
+
+func TestResolveWithJournalDirs(t *testing.T) {
+	tempDir := t.TempDir()
+	workDir := filepath.Join(tempDir, "work")
+	personalDir := filepath.Join(tempDir, "personal")
+	standupDir := filepath.Join(tempDir, "standup")
+	for _, path := range []string{
+		filepath.Join(workDir, "2025-01-06.md"),
+		filepath.Join(personalDir, "2025-01-03.md"),
+		filepath.Join(personalDir, "2025-01-07.md"),
+		filepath.Join(standupDir, "2025-01-03.md"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("# Note\n"), 0644); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+	}
+
+	// Only journal.dirs is set, as Load leaves it
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = ""
+	cfg.Journal.Dirs = []string{workDir, personalDir}
+	cfg.Standup.Dir = standupDir
+
+	tests := []struct {
+		name     string
+		noteType notes.NoteType
+		link     markdown.Link
+		wantPath string
+		wantDest string
+	}{
+		{
+			name:     "previous journal in second directory",
+			noteType: notes.NoteTypeJournal,
+			link:     markdown.Link{Text: "Yesterday", Destination: "2025-01-05"},
+			wantPath: filepath.Join(personalDir, "2025-01-03.md"),
+			wantDest: "../personal/2025-01-03",
+		},
+		{
+			name:     "next journal in second directory",
+			noteType: notes.NoteTypeJournal,
+			link:     markdown.Link{Text: "Tomorrow", Destination: "2025-01-08"},
+			wantPath: filepath.Join(personalDir, "2025-01-07.md"),
+			wantDest: "../personal/2025-01-07",
+		},
+		{
+			name:     "cross-reference to journal in second directory",
+			noteType: notes.NoteTypeStandup,
+			link:     markdown.Link{Text: "Journal", Destination: "../journal/2025-01-02"},
+			wantPath: filepath.Join(personalDir, "2025-01-03.md"),
+			wantDest: "../personal/2025-01-03",
+		},
+	}
+
+	classifier := NewClassifier(cfg)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+			if tt.noteType == notes.NoteTypeStandup {
+				date = time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+			}
+			resolver := NewResolver(cfg, date, tt.noteType)
+
+			resolved := resolver.Resolve(classifier.Classify(tt.link))
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+			if resolved.ResolvedPath != tt.wantPath {
+				t.Errorf("ResolvedPath = %s, want %s", resolved.ResolvedPath, tt.wantPath)
+			}
+			if resolved.SuggestedDestination != tt.wantDest {
+				t.Errorf("SuggestedDestination = %s, want %s", resolved.SuggestedDestination, tt.wantDest)
+			}
+		})
+	}
+}
+
+func TestResolveFromNoteDir(t *testing.T) {
+	tempDir := t.TempDir()
+	workDir := filepath.Join(tempDir, "work", "journal")
+	personalDir := filepath.Join(tempDir, "personal", "journal")
+	for _, path := range []string{
+		filepath.Join(workDir, "2025-01-07.md"),
+		filepath.Join(personalDir, "2025-01-08.md"),
+		filepath.Join(personalDir, "2025-01-06.md"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("# Note\n"), 0644); err != nil {
+			t.Fatalf("failed to create note: %v", err)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = ""
+	cfg.Journal.Dirs = []string{workDir, personalDir}
+	cfg.Standup.Dir = filepath.Join(tempDir, "standup")
+
+	tests := []struct {
+		name     string
+		noteDir  string
+		date     time.Time
+		link     markdown.Link
+		wantDest string
+	}{
+		{
+			name:     "target in the other directory",
+			noteDir:  personalDir,
+			date:     time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+			link:     markdown.Link{Text: "Yesterday", Destination: "2025-01-06"},
+			wantDest: "../../work/journal/2025-01-07",
+		},
+		{
+			name:     "target in the same directory",
+			noteDir:  workDir,
+			date:     time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+			link:     markdown.Link{Text: "Yesterday", Destination: "2025-01-06"},
+			wantDest: "2025-01-07",
+		},
+		{
+			name:     "target in the same non-primary directory",
+			noteDir:  personalDir,
+			date:     time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC),
+			link:     markdown.Link{Text: "Tomorrow", Destination: "2025-01-09"},
+			wantDest: "2025-01-08",
+		},
+	}
+
+	classifier := NewClassifier(cfg)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewResolver(cfg, tt.date, notes.NoteTypeJournal)
+			resolver.SetNoteDir(tt.noteDir)
+
+			resolved := resolver.Resolve(classifier.Classify(tt.link))
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+			if resolved.SuggestedDestination != tt.wantDest {
+				t.Errorf("SuggestedDestination = %s, want %s", resolved.SuggestedDestination, tt.wantDest)
+			}
+		})
+	}
+}
+
+func TestResolveWithPathLayout(t *testing.T) {
+	vaultRoot := t.TempDir()
+	cfg := config.DefaultConfig()
+	cfg.VaultRoot = vaultRoot
+	cfg.Journal.PathLayout = "{year}/{month}"
+
+	// Previous journal is in the previous month's directory
+	prevPath := filepath.Join(vaultRoot, "journal", "2025", "01", "2025-01-31.md")
+	if err := os.MkdirAll(filepath.Dir(prevPath), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(prevPath, []byte("# Daily Log\n"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	currentDate := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	resolver := NewResolver(cfg, currentDate, notes.NoteTypeJournal)
+	classified := NewClassifier(cfg).Classify(markdown.Link{
+		Text:        "Yesterday",
+		Destination: "2025-02-02",
+	})
+
+	resolved := resolver.Resolve(classified)
+	if resolved.Error != nil {
+		t.Fatalf("Resolve() error = %v", resolved.Error)
+	}
+	if resolved.ResolvedPath != prevPath {
+		t.Errorf("ResolvedPath = %q, want %q", resolved.ResolvedPath, prevPath)
+	}
+	want := filepath.Join("..", "01", "2025-01-31")
+	if resolved.SuggestedDestination != want {
+		t.Errorf("SuggestedDestination = %q, want %q", resolved.SuggestedDestination, want)
+	}
+}
+
+func TestResolvePreservesFragment(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = "../../testdata/journal"
+	cfg.Standup.Dir = "../../testdata/standup"
+
+	// Current date: 2025-01-13 (Monday), previous journal is 2025-01-10
+	currentDate := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	resolver := NewResolver(cfg, currentDate, notes.NoteTypeJournal)
+	classifier := NewClassifier(cfg)
+
+	tests := []struct {
+		name string
+		link markdown.Link
+		want string
+	}{
+		{
+			name: "previous with anchor",
+			link: markdown.Link{Text: "Yesterday", Destination: "2025-01-12#goals"},
+			want: "2025-01-10#goals",
+		},
+		{
+			name: "cross-reference with query",
+			link: markdown.Link{Text: "Standup", Destination: "../standup/2025-01-12?plain=1"},
+			want: "../standup/2025-01-13?plain=1",
+		},
+		{
+			name: "wiki link with anchor",
+			link: markdown.Link{Text: "Yesterday", Destination: "2025-01-12#goals", Wiki: true},
+			want: "2025-01-10#goals",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := resolver.Resolve(classifier.Classify(tt.link))
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+			if !resolved.NeedsUpdate {
+				t.Fatal("expected link to need updating")
+			}
+			if resolved.SuggestedDestination != tt.want {
+				t.Errorf("SuggestedDestination = %q, want %q", resolved.SuggestedDestination, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveWeekLinks(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = "../../testdata/journal"
+	cfg.Standup.Dir = "../../testdata/standup"
+	classifier := NewClassifier(cfg)
+
+	tests := []struct {
+		name        string
+		currentDate string
+		link        markdown.Link
+		wantType    LinkType
+		wantDate    string
+	}{
+		{
+			name:        "last week exact",
+			currentDate: "2025-01-13",
+			link:        markdown.Link{Text: "Last Week", Destination: "2025-01-10"},
+			wantType:    LinkTypeTemporalWeekPrevious,
+			wantDate:    "2025-01-06",
+		},
+		{
+			name:        "last week falls back to earlier note",
+			currentDate: "2025-01-14",
+			link:        markdown.Link{Text: "Last Week", Destination: "2025-01-13"},
+			wantType:    LinkTypeTemporalWeekPrevious,
+			wantDate:    "2025-01-07",
+		},
+		{
+			name:        "next week exact",
+			currentDate: "2025-01-06",
+			link:        markdown.Link{Text: "Next Week", Destination: "2025-01-07"},
+			wantType:    LinkTypeTemporalWeekNext,
+			wantDate:    "2025-01-13",
+		},
+		{
+			name:        "next week falls forward to later note",
+			currentDate: "2025-01-10",
+			link:        markdown.Link{Text: "Next Week", Destination: "2025-01-13"},
+			wantType:    LinkTypeTemporalWeekNext,
+			wantDate:    "2025-02-03",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currentDate, _ := time.Parse(notes.DateFormat, tt.currentDate)
+			resolver := NewResolver(cfg, currentDate, notes.NoteTypeJournal)
+
+			classified := classifier.Classify(tt.link)
+			if classified.Type != tt.wantType {
+				t.Fatalf("Classify() type = %v, want %v", classified.Type, tt.wantType)
+			}
+
+			resolved := resolver.Resolve(classified)
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+			if got := resolved.ResolvedDate.Format(notes.DateFormat); got != tt.wantDate {
+				t.Errorf("ResolvedDate = %s, want %s", got, tt.wantDate)
+			}
+			if !resolved.NeedsUpdate || resolved.SuggestedDestination != tt.wantDate {
+				t.Errorf("SuggestedDestination = %q (NeedsUpdate %v), want %q", resolved.SuggestedDestination, resolved.NeedsUpdate, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestResolvePreservesDestinationStyle(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = "../../testdata/journal"
+	cfg.Standup.Dir = "../../testdata/standup"
+
+	// Current date: 2025-01-13 (Monday), previous journal is 2025-01-10
+	currentDate := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	resolver := NewResolver(cfg, currentDate, notes.NoteTypeJournal)
+	classifier := NewClassifier(cfg)
+
+	tests := []struct {
+		name        string
+		text        string
+		destination string
+		want        string
+	}{
+		{"bare date", "Yesterday", "2025-01-12", "2025-01-10"},
+		{"bare date with .md", "Yesterday", "2025-01-12.md", "2025-01-10.md"},
+		{"relative prefix with .md", "Yesterday", "../journal/2025-01-12.md", "../journal/2025-01-10.md"},
+		{"relative prefix without .md", "Yesterday", "../journal/2025-01-12", "../journal/2025-01-10"},
+		{"cross-reference with .md", "Standup", "../standup/2025-01-12.md", "../standup/2025-01-13.md"},
+		{"cross-reference without .md", "Standup", "../standup/2025-01-12", "../standup/2025-01-13"},
+		{"anchor after .md", "Yesterday", "2025-01-12.md#goals", "2025-01-10.md#goals"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link := markdown.Link{Text: tt.text, Destination: tt.destination}
+			resolved := resolver.Resolve(classifier.Classify(link))
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+			if resolved.SuggestedDestination != tt.want {
+				t.Errorf("SuggestedDestination = %q, want %q", resolved.SuggestedDestination, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSearchWindows(t *testing.T) {
+	journalDir := t.TempDir()
+	for _, name := range []string{"2025-01-06.md", "2025-01-10.md"} {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte("# Log\n"), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		linkText     string
+		backwardDays int
+		forwardDays  int
+		wantDate     string
+	}{
+		{name: "previous within default window", linkText: "Yesterday", wantDate: "2025-01-06"},
+		{name: "previous beyond backward window", linkText: "Yesterday", backwardDays: 1},
+		{name: "previous within backward window", linkText: "Yesterday", backwardDays: 2, forwardDays: 1, wantDate: "2025-01-06"},
+		{name: "next within default window", linkText: "Tomorrow", wantDate: "2025-01-10"},
+		{name: "next beyond forward window", linkText: "Tomorrow", forwardDays: 1},
+		{name: "next within forward window", linkText: "Tomorrow", backwardDays: 1, forwardDays: 2, wantDate: "2025-01-10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Journal.Dir = journalDir
+			cfg.SearchWindowBackwardDays = tt.backwardDays
+			cfg.SearchWindowForwardDays = tt.forwardDays
+
+			currentDate := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+			resolver := NewResolver(cfg, currentDate, notes.NoteTypeJournal)
+			classified := NewClassifier(cfg).Classify(markdown.Link{Text: tt.linkText, Destination: "2025-01-01"})
+
+			resolved := resolver.Resolve(classified)
+			if tt.wantDate == "" {
+				if resolved.Error == nil {
+					t.Errorf("expected no note within the window, resolved %s", resolved.ResolvedDate.Format(notes.DateFormat))
+				}
+				return
+			}
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+			if got := resolved.ResolvedDate.Format(notes.DateFormat); got != tt.wantDate {
+				t.Errorf("ResolvedDate = %v, want %v", got, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestResolveSkippedDates(t *testing.T) {
+	journalDir := t.TempDir()
+	for _, name := range []string{"2025-01-09.md", "2025-01-10.md", "2025-01-13.md"} {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte("# Log\n"), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	classifier := NewClassifier(cfg)
+
+	tests := []struct {
+		name        string
+		currentDate time.Time
+		linkText    string
+		want        []string
+	}{
+		{
+			name:        "tomorrow skips weekend",
+			currentDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			linkText:    "Tomorrow",
+			want:        []string{"2025-01-11", "2025-01-12"},
+		},
+		{
+			name:        "yesterday skips weekend",
+			currentDate: time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			linkText:    "Yesterday",
+			want:        []string{"2025-01-12", "2025-01-11"},
+		},
+		{
+			name:        "tomorrow without gap",
+			currentDate: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC),
+			linkText:    "Tomorrow",
+		},
+		{
+			name:        "yesterday without gap",
+			currentDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			linkText:    "Yesterday",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewResolver(cfg, tt.currentDate, notes.NoteTypeJournal)
+			resolved := resolver.Resolve(classifier.Classify(markdown.Link{Text: tt.linkText, Destination: "2025-01-01"}))
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+
+			var got []string
+			for _, date := range resolved.SkippedDates {
+				got = append(got, date.Format(notes.DateFormat))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SkippedDates = %v, want %v", got, tt.want)
+			}
+			if len(resolved.SkippedHolidays) != 0 {
+				t.Errorf("SkippedHolidays = %v, want none", resolved.SkippedHolidays)
+			}
+		})
+	}
+}
+
+func TestResolveTrace(t *testing.T) {
+	journalDir := filepath.Join(t.TempDir(), "journal")
+	if err := os.MkdirAll(journalDir, 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	for _, name := range []string{"2025-01-10.md", "2025-01-13.md"} {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte("# Log\n"), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Standup.Dir = filepath.Join(filepath.Dir(journalDir), "standup")
+	cfg.SearchWindowForwardDays = 3
+	classifier := NewClassifier(cfg)
+
+	tests := []struct {
+		name          string
+		currentDate   time.Time
+		link          markdown.Link
+		wantDirection string
+		wantFrom      string
+		wantDays      int
+		wantExamined  []string
+		wantErr       bool
+	}{
+		{
+			name:          "yesterday across a weekend",
+			currentDate:   time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			link:          markdown.Link{Text: "Yesterday", Destination: "2025-01-12"},
+			wantDirection: SearchBackward,
+			wantFrom:      "2025-01-12",
+			wantDays:      30,
+			wantExamined:  []string{"2025-01-12", "2025-01-11"},
+		},
+		{
+			name:          "tomorrow across a weekend",
+			currentDate:   time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			link:          markdown.Link{Text: "Tomorrow", Destination: "2025-01-11"},
+			wantDirection: SearchForward,
+			wantFrom:      "2025-01-11",
+			wantDays:      3,
+			wantExamined:  []string{"2025-01-11", "2025-01-12"},
+		},
+		{
+			name:          "tomorrow not found",
+			currentDate:   time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			link:          markdown.Link{Text: "Tomorrow", Destination: "2025-01-14"},
+			wantDirection: SearchForward,
+			wantFrom:      "2025-01-14",
+			wantDays:      3,
+			wantExamined:  []string{"2025-01-14", "2025-01-15", "2025-01-16"},
+			wantErr:       true,
+		},
+		{
+			name:          "cross-reference",
+			currentDate:   time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC),
+			link:          markdown.Link{Text: "Standup", Destination: "../standup/2025-01-10"},
+			wantDirection: SearchSameDay,
+			wantFrom:      "2025-01-13",
+			wantDays:      1,
+			wantExamined:  []string{"2025-01-13"},
+			wantErr:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewResolver(cfg, tt.currentDate, notes.NoteTypeJournal)
+			classified := classifier.Classify(tt.link)
+
+			if resolved := resolver.Resolve(classified); resolved.Trace != nil {
+				t.Errorf("Resolve() without tracing has Trace %+v", resolved.Trace)
+			}
+
+			resolver.SetTrace(true)
+			resolved := resolver.Resolve(classified)
+			if (resolved.Error != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", resolved.Error, tt.wantErr)
+			}
+
+			trace := resolved.Trace
+			if trace == nil {
+				t.Fatal("Resolve() with tracing has no Trace")
+			}
+			if trace.Direction != tt.wantDirection || trace.From.Format(notes.DateFormat) != tt.wantFrom || trace.Days != tt.wantDays {
+				t.Errorf("Trace = %s from %s over %d days, want %s from %s over %d days",
+					trace.Direction, trace.From.Format(notes.DateFormat), trace.Days, tt.wantDirection, tt.wantFrom, tt.wantDays)
+			}
+
+			var examined []string
+			for _, date := range trace.Examined {
+				examined = append(examined, date.Format(notes.DateFormat))
+			}
+			if strings.Join(examined, ",") != strings.Join(tt.wantExamined, ",") {
+				t.Errorf("Trace.Examined = %v, want %v", examined, tt.wantExamined)
+			}
+		})
+	}
+}
+
+func TestResolveSkippedHolidays(t *testing.T) {
+	journalDir := t.TempDir()
+	for _, name := range []string{"2024-12-23.md", "2024-12-24.md", "2024-12-27.md"} {
+		if err := os.WriteFile(filepath.Join(journalDir, name), []byte("# Log\n"), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Journal.Dir = journalDir
+	cfg.Holidays = []string{"2024-12-25", "2024-12-26", "2025-01-01"}
+	classifier := NewClassifier(cfg)
+
+	tests := []struct {
+		name        string
+		currentDate time.Time
+		linkText    string
+		want        []string
+	}{
+		{
+			name:        "tomorrow skips holidays",
+			currentDate: time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC),
+			linkText:    "Tomorrow",
+			want:        []string{"2024-12-25", "2024-12-26"},
+		},
+		{
+			name:        "yesterday skips holidays",
+			currentDate: time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC),
+			linkText:    "Yesterday",
+			want:        []string{"2024-12-26", "2024-12-25"},
+		},
+		{
+			name:        "no gap",
+			currentDate: time.Date(2024, 12, 24, 0, 0, 0, 0, time.UTC),
+			linkText:    "Yesterday",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewResolver(cfg, tt.currentDate, notes.NoteTypeJournal)
+			resolved := resolver.Resolve(classifier.Classify(markdown.Link{Text: tt.linkText, Destination: "2024-12-01"}))
+			if resolved.Error != nil {
+				t.Fatalf("Resolve() error = %v", resolved.Error)
+			}
+
+			var got []string
+			for _, holiday := range resolved.SkippedHolidays {
+				got = append(got, holiday.Format(notes.DateFormat))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SkippedHolidays = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/rdark/za/internal/util"
 	"gopkg.in/yaml.v3"
 )
 
@@ -14,11 +15,91 @@ import (
 // If the file doesn't have frontmatter or tags, it won't modify the file
 // Returns true if the tag was added, false if it already existed or couldn't be added
 func AddTagToFile(filePath string, tag string) (bool, error) {
+	return updateFileTags(filePath, false, appendTag(tag))
+}
+
+// EnsureTagInFile is like AddTagToFile, but if the frontmatter has no tags
+// field it adds one holding the tag. Files without frontmatter are still left
+// alone. Returns true if the tag was added, false if it already existed or
+// couldn't be added.
+func EnsureTagInFile(filePath string, tag string) (bool, error) {
+	return updateFileTags(filePath, true, appendTag(tag))
+}
+
+// appendTag returns a tags update that appends tag unless it's already present
+func appendTag(tag string) func(tags []string) ([]string, bool) {
+	return func(tags []string) ([]string, bool) {
+		for _, existingTag := range tags {
+			if existingTag == tag {
+				return nil, false
+			}
+		}
+		return append(tags, tag), true
+	}
+}
+
+// RemoveTagFromFile removes a tag from the frontmatter tags array in a
+// markdown file. Like AddTagToFile, it won't modify a file without frontmatter
+// or tags. Returns true if the tag was removed, false if it wasn't present.
+func RemoveTagFromFile(filePath string, tag string) (bool, error) {
+	return updateFileTags(filePath, false, func(tags []string) ([]string, bool) {
+		kept := make([]string, 0, len(tags))
+		for _, existingTag := range tags {
+			if existingTag != tag {
+				kept = append(kept, existingTag)
+			}
+		}
+		return kept, len(kept) != len(tags)
+	})
+}
+
+// RenameTagInFile replaces oldTag with newTag in the frontmatter tags array
+// of a markdown file, keeping its position. If newTag is already present,
+// oldTag is just removed. Like AddTagToFile, it won't modify a file without
+// frontmatter or tags. Returns true if the tag was renamed, false if oldTag
+// wasn't present.
+func RenameTagInFile(filePath string, oldTag, newTag string) (bool, error) {
+	if oldTag == newTag {
+		return false, nil
+	}
+
+	return updateFileTags(filePath, false, func(tags []string) ([]string, bool) {
+		hasNew := false
+		for _, existingTag := range tags {
+			if existingTag == newTag {
+				hasNew = true
+			}
+		}
+
+		renamed := make([]string, 0, len(tags))
+		changed := false
+		for _, existingTag := range tags {
+			if existingTag != oldTag {
+				renamed = append(renamed, existingTag)
+				continue
+			}
+			changed = true
+			if !hasNew {
+				renamed = append(renamed, newTag)
+				hasNew = true
+			}
+		}
+		return renamed, changed
+	})
+}
+
+// updateFileTags rewrites the frontmatter tags array of a markdown file using
+// update, which returns the new tags and whether they changed. Files without
+// frontmatter or with tags in an unknown format are left alone, as are files
+// without a tags field unless create is set. Returns whether the file was
+// modified.
+func updateFileTags(filePath string, create bool, update func(tags []string) ([]string, bool)) (bool, error) {
 	// Read the file
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return false, fmt.Errorf("failed to read file: %w", err)
 	}
+	content = util.NormalizeNewlines(content)
 
 	// Parse frontmatter
 	frontmatterEnd, frontmatter, err := extractFrontmatter(content)
@@ -33,16 +114,19 @@ func AddTagToFile(filePath string, tag string) (bool, error) {
 		return false, fmt.Errorf("failed to parse frontmatter: %w", err)
 	}
 
-	// Check if tags exist
-	tagsRaw, hasTagsField := fm["tags"]
-	if !hasTagsField {
-		// No tags field - don't add it
-		return false, nil
+	if fm == nil {
+		// Frontmatter with no fields
+		fm = make(map[string]interface{})
 	}
 
 	// Convert tags to string slice
 	var tags []string
-	switch v := tagsRaw.(type) {
+	switch v := fm["tags"].(type) {
+	case nil:
+		// No tags field (or an empty one) - only add it if creating
+		if !create {
+			return false, nil
+		}
 	case []interface{}:
 		for _, tag := range v {
 			if strTag, ok := tag.(string); ok {
@@ -56,15 +140,10 @@ func AddTagToFile(filePath string, tag string) (bool, error) {
 		return false, nil
 	}
 
-	// Check if tag already exists
-	for _, existingTag := range tags {
-		if existingTag == tag {
-			return false, nil // Tag already exists
-		}
+	tags, changed := update(tags)
+	if !changed {
+		return false, nil
 	}
-
-	// Add the tag
-	tags = append(tags, tag)
 	fm["tags"] = tags
 
 	// Serialize back to YAML with inline array style for tags
@@ -81,13 +160,84 @@ func AddTagToFile(filePath string, tag string) (bool, error) {
 	buf.Write(content[frontmatterEnd:])
 
 	// Write back to file
-	if err := os.WriteFile(filePath, buf.Bytes(), 0644); err != nil {
+	if err := util.RewriteFile(filePath, buf.Bytes()); err != nil {
 		return false, fmt.Errorf("failed to write file: %w", err)
 	}
 
 	return true, nil
 }
 
+// SetFrontmatterField sets key to value in the frontmatter of a markdown file,
+// adding the key if it's missing. Other fields keep their order and styling,
+// the tags array stays in inline double-quoted style and the body is left
+// untouched. Returns an error if the file has no frontmatter.
+func SetFrontmatterField(filePath string, key string, value any) error {
+	// Read the file
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	content = util.NormalizeNewlines(content)
+
+	// Parse frontmatter
+	frontmatterEnd, frontmatter, err := extractFrontmatter(content)
+	if err != nil {
+		return fmt.Errorf("no frontmatter in %s: %w", filePath, err)
+	}
+
+	// Parse YAML frontmatter as a node, keeping field order and styles
+	var doc yaml.Node
+	if err := yaml.Unmarshal(frontmatter, &doc); err != nil {
+		return fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	if doc.Kind == 0 {
+		// Frontmatter with no fields
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("frontmatter is not a mapping")
+	}
+	mapping := doc.Content[0]
+
+	var valueNode yaml.Node
+	if err := valueNode.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+
+	// Replace the existing value, or add the key at the end
+	replaced := false
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = &valueNode
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+		mapping.Content = append(mapping.Content, keyNode, &valueNode)
+	}
+
+	newFrontmatter, err := marshalFrontmatterNode(&doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal frontmatter: %w", err)
+	}
+
+	// Reconstruct the file
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(newFrontmatter)
+	buf.WriteString("---\n")
+	buf.Write(content[frontmatterEnd:])
+
+	// Write back to file
+	if err := util.RewriteFile(filePath, buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
 // extractFrontmatter extracts the YAML frontmatter from markdown content
 // Returns the end position of frontmatter and the frontmatter bytes
 func extractFrontmatter(content []byte) (int, []byte, error) {
@@ -133,8 +283,14 @@ func marshalFrontmatterWithFlowTags(fm map[string]interface{}) ([]byte, error) {
 		return nil, err
 	}
 
+	return marshalFrontmatterNode(&node)
+}
+
+// marshalFrontmatterNode marshals a frontmatter YAML node, with inline array
+// style for tags
+func marshalFrontmatterNode(node *yaml.Node) ([]byte, error) {
 	// Find and modify the tags field to use flow style
-	if err := setFlowStyleForTags(&node); err != nil {
+	if err := setFlowStyleForTags(node); err != nil {
 		return nil, err
 	}
 
@@ -142,7 +298,7 @@ func marshalFrontmatterWithFlowTags(fm map[string]interface{}) ([]byte, error) {
 	var buf bytes.Buffer
 	encoder := yaml.NewEncoder(&buf)
 	encoder.SetIndent(2)
-	if err := encoder.Encode(&node); err != nil {
+	if err := encoder.Encode(node); err != nil {
 		return nil, err
 	}
 	if err := encoder.Close(); err != nil {
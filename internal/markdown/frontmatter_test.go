@@ -378,6 +378,535 @@ tags: ["daily", "journal"]
 	}
 }
 
+func TestAddTagToFile_CRLF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.md")
+	content := "---\r\ntags: [\"daily\"]\r\n---\r\n\r\n# Content\r\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	added, err := AddTagToFile(path, "company:acme")
+	if err != nil {
+		t.Fatalf("AddTagToFile() error = %v", err)
+	}
+	if !added {
+		t.Fatal("expected tag to be added")
+	}
+
+	result, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	want := "---\r\ntags: [\"daily\", \"company:acme\"]\r\n---\r\n\r\n# Content\r\n"
+	if string(result) != want {
+		t.Errorf("AddTagToFile() wrote %q, want %q", result, want)
+	}
+}
+
+func TestAddTagToFile_KeepsMode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.md")
+	if err := os.WriteFile(path, []byte("---\ntags: [\"daily\"]\n---\n"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	// Set the mode explicitly, as WriteFile's is subject to the umask
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatalf("failed to chmod file: %v", err)
+	}
+
+	if _, err := AddTagToFile(path, "company:acme"); err != nil {
+		t.Fatalf("AddTagToFile() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+}
+
+func TestEnsureTagInFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		tag         string
+		expectAdded bool
+		wantResult  string
+	}{
+		{
+			name: "frontmatter without tags",
+			content: `---
+title: Test Document
+---
+
+# Content`,
+			tag:         "company:acme",
+			expectAdded: true,
+			wantResult: `---
+tags: ["company:acme"]
+title: Test Document
+---
+
+# Content`,
+		},
+		{
+			name: "empty tags field",
+			content: `---
+tags:
+---
+
+# Content`,
+			tag:         "company:acme",
+			expectAdded: true,
+			wantResult: `---
+tags: ["company:acme"]
+---
+
+# Content`,
+		},
+		{
+			name: "empty frontmatter",
+			content: `---
+---
+
+# Content`,
+			tag:         "company:acme",
+			expectAdded: true,
+			wantResult: `---
+tags: ["company:acme"]
+---
+
+# Content`,
+		},
+		{
+			name: "existing tags array",
+			content: `---
+tags: ["daily"]
+---
+
+# Content`,
+			tag:         "company:acme",
+			expectAdded: true,
+			wantResult: `---
+tags: ["daily", "company:acme"]
+---
+
+# Content`,
+		},
+		{
+			name: "tag already exists",
+			content: `---
+tags: ["company:acme"]
+---
+
+# Content`,
+			tag:         "company:acme",
+			expectAdded: false,
+			wantResult: `---
+tags: ["company:acme"]
+---
+
+# Content`,
+		},
+		{
+			name: "no frontmatter",
+			content: `# Content
+No frontmatter here`,
+			tag:         "company:acme",
+			expectAdded: false,
+			wantResult: `# Content
+No frontmatter here`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create temp file
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, "test.md")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			// Ensure tag
+			added, err := EnsureTagInFile(filePath, tt.tag)
+			if err != nil {
+				t.Fatalf("EnsureTagInFile failed: %v", err)
+			}
+
+			if added != tt.expectAdded {
+				t.Errorf("Expected added=%v, got %v", tt.expectAdded, added)
+			}
+
+			result, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read result file: %v", err)
+			}
+			if string(result) != tt.wantResult {
+				t.Errorf("Result mismatch\nWant:\n%s\nGot:\n%s", tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestRemoveTagFromFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		tag           string
+		expectRemoved bool
+		wantTagsLine  string
+	}{
+		{
+			name: "remove tag from existing tags array",
+			content: `---
+title: Test Document
+tags: ["daily", "company:acme", "journal"]
+---
+
+# Content`,
+			tag:           "company:acme",
+			expectRemoved: true,
+			wantTagsLine:  `tags: ["daily", "journal"]`,
+		},
+		{
+			name: "remove last tag",
+			content: `---
+title: Test Document
+tags: ["company:acme"]
+---
+
+# Content`,
+			tag:           "company:acme",
+			expectRemoved: true,
+			wantTagsLine:  `tags: []`,
+		},
+		{
+			name: "tag not present",
+			content: `---
+title: Test Document
+tags: ["daily", "journal"]
+---
+
+# Content`,
+			tag:           "company:acme",
+			expectRemoved: false,
+			wantTagsLine:  `tags: ["daily", "journal"]`,
+		},
+		{
+			name: "no frontmatter",
+			content: `# Content
+No frontmatter here`,
+			tag:           "company:acme",
+			expectRemoved: false,
+		},
+		{
+			name: "no tags field",
+			content: `---
+title: Test Document
+date: 2025-01-01
+---
+
+# Content`,
+			tag:           "company:acme",
+			expectRemoved: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create temp file
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, "test.md")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			// Remove tag
+			removed, err := RemoveTagFromFile(filePath, tt.tag)
+			if err != nil {
+				t.Fatalf("RemoveTagFromFile failed: %v", err)
+			}
+
+			if removed != tt.expectRemoved {
+				t.Errorf("Expected removed=%v, got %v", tt.expectRemoved, removed)
+			}
+
+			checkTagsResult(t, filePath, tt.content, tt.expectRemoved, tt.wantTagsLine)
+		})
+	}
+}
+
+func TestRenameTagInFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		oldTag        string
+		newTag        string
+		expectRenamed bool
+		wantTagsLine  string
+	}{
+		{
+			name: "rename keeps position",
+			content: `---
+title: Test Document
+tags: ["daily", "company:acme", "journal"]
+---
+
+# Content`,
+			oldTag:        "company:acme",
+			newTag:        "company:globex",
+			expectRenamed: true,
+			wantTagsLine:  `tags: ["daily", "company:globex", "journal"]`,
+		},
+		{
+			name: "new tag already present",
+			content: `---
+title: Test Document
+tags: ["company:globex", "daily", "company:acme"]
+---
+
+# Content`,
+			oldTag:        "company:acme",
+			newTag:        "company:globex",
+			expectRenamed: true,
+			wantTagsLine:  `tags: ["company:globex", "daily"]`,
+		},
+		{
+			name: "old tag not present",
+			content: `---
+title: Test Document
+tags: ["daily", "journal"]
+---
+
+# Content`,
+			oldTag:        "company:acme",
+			newTag:        "company:globex",
+			expectRenamed: false,
+			wantTagsLine:  `tags: ["daily", "journal"]`,
+		},
+		{
+			name: "same tag",
+			content: `---
+title: Test Document
+tags: ["company:acme"]
+---
+
+# Content`,
+			oldTag:        "company:acme",
+			newTag:        "company:acme",
+			expectRenamed: false,
+			wantTagsLine:  `tags: ["company:acme"]`,
+		},
+		{
+			name: "no tags field",
+			content: `---
+title: Test Document
+---
+
+# Content`,
+			oldTag:        "company:acme",
+			newTag:        "company:globex",
+			expectRenamed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create temp file
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, "test.md")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			// Rename tag
+			renamed, err := RenameTagInFile(filePath, tt.oldTag, tt.newTag)
+			if err != nil {
+				t.Fatalf("RenameTagInFile failed: %v", err)
+			}
+
+			if renamed != tt.expectRenamed {
+				t.Errorf("Expected renamed=%v, got %v", tt.expectRenamed, renamed)
+			}
+
+			checkTagsResult(t, filePath, tt.content, tt.expectRenamed, tt.wantTagsLine)
+		})
+	}
+}
+
+// checkTagsResult checks a file is unchanged unless modified, and that its
+// tags line matches wantTagsLine (if set)
+func checkTagsResult(t *testing.T, filePath, original string, modified bool, wantTagsLine string) {
+	t.Helper()
+
+	result, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read result file: %v", err)
+	}
+	resultStr := string(result)
+
+	if !modified && resultStr != original {
+		t.Errorf("File was modified:\n%s", resultStr)
+	}
+
+	if wantTagsLine != "" {
+		tagsLine := ""
+		for _, line := range strings.Split(resultStr, "\n") {
+			if strings.HasPrefix(line, "tags:") {
+				tagsLine = line
+				break
+			}
+		}
+		if tagsLine != wantTagsLine {
+			t.Errorf("Tags line mismatch\nWant: %q\nGot:  %q", wantTagsLine, tagsLine)
+		}
+	}
+
+	// Verify content is preserved
+	if !strings.Contains(resultStr, "# Content") {
+		t.Errorf("Content section was not preserved")
+	}
+}
+
+func TestSetFrontmatterField(t *testing.T) {
+	content := `---
+title: Daily Log
+date: 2025-01-06
+tags: ["daily", "journal"]
+---
+
+# Content
+Body  with  odd   spacing
+`
+
+	tests := []struct {
+		name       string
+		key        string
+		value      any
+		wantResult string
+	}{
+		{
+			name:  "replace string",
+			key:   "title",
+			value: "Daily Log 2025-01-06",
+			wantResult: `---
+title: Daily Log 2025-01-06
+date: 2025-01-06
+tags: ["daily", "journal"]
+---
+
+# Content
+Body  with  odd   spacing
+`,
+		},
+		{
+			name:  "add string",
+			key:   "status",
+			value: "draft",
+			wantResult: `---
+title: Daily Log
+date: 2025-01-06
+tags: ["daily", "journal"]
+status: draft
+---
+
+# Content
+Body  with  odd   spacing
+`,
+		},
+		{
+			name:  "add number",
+			key:   "week",
+			value: 2,
+			wantResult: `---
+title: Daily Log
+date: 2025-01-06
+tags: ["daily", "journal"]
+week: 2
+---
+
+# Content
+Body  with  odd   spacing
+`,
+		},
+		{
+			name:  "add bool",
+			key:   "reviewed",
+			value: true,
+			wantResult: `---
+title: Daily Log
+date: 2025-01-06
+tags: ["daily", "journal"]
+reviewed: true
+---
+
+# Content
+Body  with  odd   spacing
+`,
+		},
+		{
+			name:  "replace tags",
+			key:   "tags",
+			value: []string{"weekly"},
+			wantResult: `---
+title: Daily Log
+date: 2025-01-06
+tags: ["weekly"]
+---
+
+# Content
+Body  with  odd   spacing
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			filePath := filepath.Join(tmpDir, "test.md")
+			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			if err := SetFrontmatterField(filePath, tt.key, tt.value); err != nil {
+				t.Fatalf("SetFrontmatterField failed: %v", err)
+			}
+
+			result, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("Failed to read result file: %v", err)
+			}
+			if string(result) != tt.wantResult {
+				t.Errorf("Result mismatch\nWant:\n%s\nGot:\n%s", tt.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestSetFrontmatterField_NoFrontmatter(t *testing.T) {
+	content := "# Content\nNo frontmatter here"
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test.md")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	if err := SetFrontmatterField(filePath, "title", "Test"); err == nil {
+		t.Error("Expected error for file without frontmatter")
+	}
+
+	result, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read result file: %v", err)
+	}
+	if string(result) != content {
+		t.Errorf("File was modified:\n%s", result)
+	}
+}
+
 func TestExtractFrontmatter(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -2,33 +2,83 @@ package markdown
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var (
-	// Regex to match checkbox items: - [ ], * [ ], - [], * [], - [x], * [x], etc.
-	// Handles both - and * bullet markers, and both well-formed [ ] and malformed [] (no space)
-	checkboxRegex = regexp.MustCompile(`^\s*[-*]\s*\[([\ xX]*)\]\s*(.+)$`)
-	// Regex to match plain bullet points: - item or * item
-	bulletRegex = regexp.MustCompile(`^\s*[-*]\s+(.+)$`)
+	// Regex to match checkbox items: - [ ], * [ ], 1. [ ], - [], - [x], 1. [x], etc.
+	// Handles -, * and ordered (1.) list markers, and both well-formed [ ] and
+	// malformed [] (no space)
+	checkboxRegex = regexp.MustCompile(`^\s*(?:[-*]|\d+\.)\s*\[([\ xX]*)\]\s*(.+)$`)
+	// Regex to match plain bullet points: - item, * item or 1. item
+	bulletRegex = regexp.MustCompile(`^\s*(?:[-*]|\d+\.)\s+(.+)$`)
+	// Regexes to match the list marker (and checkbox) at the start of a goal line
+	checkboxPrefixRegex = regexp.MustCompile(`^(\s*(?:[-*]|\d+\.)\s*)\[[\ xX]*\]`)
+	bulletPrefixRegex   = regexp.MustCompile(`^(\s*(?:[-*]|\d+\.)\s+)`)
+	// Regex to match inline tags like #blocked or #team/infra. Tags must start
+	// with a letter and follow whitespace, so "#123" and "page#anchor" aren't tags
+	tagRegex = regexp.MustCompile(`(?:^|\s)#([\pL][\pL\pN_/-]*)`)
 )
 
+// indentWidth is the number of spaces per nesting level when formatting items
+const indentWidth = 2
+
+// orderedIndentWidth is the number of spaces per nesting level when formatting
+// ordered lists, wide enough to nest under a "1. " marker
+const orderedIndentWidth = 3
+
+// tabWidth is the number of columns a tab counts as when measuring indentation
+const tabWidth = 4
+
 // CheckboxItem represents a task with a checkbox
 type CheckboxItem struct {
 	Checked bool
 	Text    string
+	Indent  int // Nesting level, 0 for top-level items
 }
 
 // GoalItem represents a goal that can be either a checkbox item or plain bullet point
 type GoalItem struct {
 	Text        string
 	HasCheckbox bool
-	Checked     bool // Only meaningful if HasCheckbox is true
+	Checked     bool      // Only meaningful if HasCheckbox is true
+	Due         time.Time // Zero unless set by AnnotateDueDates
+	Indent      int       // Nesting level, 0 for top-level items
+	Tags        []string  // Inline #tags in Text, lowercased and without the #
+}
+
+// HasDueDate returns true if the goal has a parsed due date
+func (g GoalItem) HasDueDate() bool {
+	return !g.Due.IsZero()
+}
+
+// HasTag returns true if the goal is tagged with tag (case-insensitive, with
+// or without the leading #)
+func (g GoalItem) HasTag(tag string) bool {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	for _, t := range g.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTags returns the inline tags in text, lowercased and without the #
+func parseTags(text string) []string {
+	var tags []string
+	for _, matches := range tagRegex.FindAllStringSubmatch(text, -1) {
+		tags = append(tags, strings.ToLower(matches[1]))
+	}
+	return tags
 }
 
 // ParseCheckboxItems extracts checkbox items from content
 func ParseCheckboxItems(content string) []CheckboxItem {
 	var items []CheckboxItem
+	var nesting nestingTracker
 
 	lines := strings.Split(content, "\n")
 	for _, line := range lines {
@@ -40,6 +90,7 @@ func ParseCheckboxItems(content string) []CheckboxItem {
 			items = append(items, CheckboxItem{
 				Checked: checked,
 				Text:    text,
+				Indent:  nesting.level(line),
 			})
 		}
 	}
@@ -58,19 +109,26 @@ func FilterPendingItems(items []CheckboxItem) []CheckboxItem {
 	return pending
 }
 
-// FormatCheckboxItems converts items back to markdown checkbox format
-func FormatCheckboxItems(items []CheckboxItem) string {
+// FormatCheckboxItems converts items back to markdown checkbox format, as -
+// bullets unless WithBullet is given
+func FormatCheckboxItems(items []CheckboxItem, opts ...FormatOption) string {
 	if len(items) == 0 {
 		return ""
 	}
 
+	var o formatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var lines []string
+	marker := newListMarker(o)
 	for _, item := range items {
 		checkbox := "[ ]"
 		if item.Checked {
 			checkbox = "[x]"
 		}
-		lines = append(lines, "- "+checkbox+" "+item.Text)
+		lines = append(lines, marker(item.Indent)+" "+checkbox+" "+item.Text)
 	}
 
 	return strings.Join(lines, "\n")
@@ -79,6 +137,7 @@ func FormatCheckboxItems(items []CheckboxItem) string {
 // ParseGoalItems extracts both checkbox items and plain bullet points from content
 func ParseGoalItems(content string) []GoalItem {
 	var items []GoalItem
+	var nesting nestingTracker
 
 	lines := strings.Split(content, "\n")
 	for _, line := range lines {
@@ -92,6 +151,8 @@ func ParseGoalItems(content string) []GoalItem {
 				Text:        text,
 				HasCheckbox: true,
 				Checked:     checked,
+				Indent:      nesting.level(line),
+				Tags:        parseTags(text),
 			})
 			continue
 		}
@@ -107,6 +168,8 @@ func ParseGoalItems(content string) []GoalItem {
 				Text:        text,
 				HasCheckbox: false,
 				Checked:     false,
+				Indent:      nesting.level(line),
+				Tags:        parseTags(text),
 			})
 		}
 	}
@@ -119,35 +182,223 @@ func ParseGoalItems(content string) []GoalItem {
 // - Plain bullet points without checkboxes (unknown state)
 // Does NOT include checked items [x]
 func FilterUnfinishedGoals(items []GoalItem) []GoalItem {
-	var unfinished []GoalItem
-	for _, item := range items {
+	return FilterGoals(items, func(item GoalItem) bool {
 		// Include if it's not a checkbox (plain bullet)
 		// OR if it's a checkbox that's not checked
-		if !item.HasCheckbox || !item.Checked {
-			unfinished = append(unfinished, item)
+		return !item.HasCheckbox || !item.Checked
+	})
+}
+
+// FilterCompletedGoals returns only checked checkbox items [x]. Plain bullet
+// points have no completion state, so are never included.
+func FilterCompletedGoals(items []GoalItem) []GoalItem {
+	return FilterGoals(items, func(item GoalItem) bool {
+		return item.HasCheckbox && item.Checked
+	})
+}
+
+// FilterGoals returns the items for which keep returns true, in order
+func FilterGoals(items []GoalItem, keep func(GoalItem) bool) []GoalItem {
+	var kept []GoalItem
+	for _, item := range items {
+		if keep(item) {
+			kept = append(kept, item)
 		}
 	}
-	return unfinished
+	return kept
+}
+
+// SetGoalLineChecked returns a goal line with its checkbox checked or
+// unchecked, leaving the rest of the line untouched. A plain bullet gains a
+// checkbox. It returns false if the line isn't a list item.
+func SetGoalLineChecked(line string, checked bool) (string, bool) {
+	checkbox := "[ ]"
+	if checked {
+		checkbox = "[x]"
+	}
+
+	if loc := checkboxPrefixRegex.FindStringSubmatchIndex(line); loc != nil {
+		return line[:loc[3]] + checkbox + line[loc[1]:], true
+	}
+	if loc := bulletPrefixRegex.FindStringSubmatchIndex(line); loc != nil {
+		return line[:loc[3]] + checkbox + " " + line[loc[1]:], true
+	}
+	return line, false
+}
+
+// FormatOption configures optional FormatGoalItems and FormatCheckboxItems
+// behaviour
+type FormatOption func(*formatOptions)
+
+// formatOptions holds the optional settings applied by FormatOption values
+type formatOptions struct {
+	ordered bool
+	bullet  string
 }
 
-// FormatGoalItems converts goal items back to markdown format
-func FormatGoalItems(items []GoalItem) string {
+// DefaultBullet is the list marker used unless WithBullet is given
+const DefaultBullet = "-"
+
+// WithBullet formats unordered items with marker (e.g. "*") instead of "-".
+// An empty marker means DefaultBullet.
+func WithBullet(marker string) FormatOption {
+	return func(o *formatOptions) {
+		o.bullet = marker
+	}
+}
+
+// WithOrderedList formats goals as an ordered list (1., 2., ...) instead of
+// - bullets. Numbering restarts for each nested list.
+func WithOrderedList() FormatOption {
+	return func(o *formatOptions) {
+		o.ordered = true
+	}
+}
+
+// FormatGoalItems converts goal items back to markdown format, as - bullets
+// unless WithBullet or WithOrderedList is given
+func FormatGoalItems(items []GoalItem, opts ...FormatOption) string {
 	if len(items) == 0 {
 		return ""
 	}
 
+	var o formatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var lines []string
+	marker := newListMarker(o)
 	for _, item := range items {
 		if item.HasCheckbox {
 			checkbox := "[ ]"
 			if item.Checked {
 				checkbox = "[x]"
 			}
-			lines = append(lines, "- "+checkbox+" "+item.Text)
+			lines = append(lines, marker(item.Indent)+" "+checkbox+" "+item.Text)
 		} else {
-			lines = append(lines, "- "+item.Text)
+			lines = append(lines, marker(item.Indent)+" "+item.Text)
 		}
 	}
 
 	return strings.Join(lines, "\n")
 }
+
+// nestingTracker converts the leading whitespace of successive list items
+// into nesting levels, so that lists indented by any consistent amount (2 or 4
+// spaces, tabs) are understood the same way
+type nestingTracker struct {
+	widths []int // Indentation width of each open nesting level
+}
+
+// level returns the nesting level of a list item line
+func (n *nestingTracker) level(line string) int {
+	width := 0
+	for _, r := range line {
+		if r == ' ' {
+			width++
+		} else if r == '\t' {
+			width += tabWidth
+		} else {
+			break
+		}
+	}
+
+	// Close levels indented deeper than this item, then open a new level if
+	// this item is indented deeper than its parent
+	for len(n.widths) > 0 && width < n.widths[len(n.widths)-1] {
+		n.widths = n.widths[:len(n.widths)-1]
+	}
+	if len(n.widths) == 0 || width > n.widths[len(n.widths)-1] {
+		n.widths = append(n.widths, width)
+	}
+	return len(n.widths) - 1
+}
+
+// newListMarker returns a function giving the indented list marker ("-", "*"
+// or "1.") for successive items at the given nesting levels. An item is never
+// nested more than one level below the previous item, so filtering out a
+// parent doesn't leave its children indented under nothing.
+func newListMarker(o formatOptions) func(level int) string {
+	bullet := o.bullet
+	if bullet == "" {
+		bullet = DefaultBullet
+	}
+	prev := -1
+	var counters []int // Item number at each open level, for ordered lists
+	return func(level int) string {
+		if level > prev+1 {
+			level = prev + 1
+		}
+		if level < 0 {
+			level = 0
+		}
+		prev = level
+
+		if !o.ordered {
+			return strings.Repeat(" ", level*indentWidth) + bullet
+		}
+
+		// Returning to a shallower level ends the deeper lists
+		for len(counters) <= level {
+			counters = append(counters, 0)
+		}
+		counters = counters[:level+1]
+		counters[level]++
+		return strings.Repeat(" ", level*orderedIndentWidth) + strconv.Itoa(counters[level]) + "."
+	}
+}
+
+// AnnotateDueDates sets the Due field on goals whose text matches pattern.
+// The first capture group of pattern is the due value, which may be a date
+// (YYYY-MM-DD) or a weekday name resolved within the week of reference.
+// Goals without a recognisable annotation are returned unchanged.
+func AnnotateDueDates(items []GoalItem, pattern *regexp.Regexp, reference time.Time) []GoalItem {
+	annotated := make([]GoalItem, len(items))
+	for i, item := range items {
+		annotated[i] = item
+		matches := pattern.FindStringSubmatch(item.Text)
+		if len(matches) < 2 {
+			continue
+		}
+		if due, ok := ParseDueValue(matches[1], reference); ok {
+			annotated[i].Due = due
+		}
+	}
+	return annotated
+}
+
+// ParseDueValue parses a due annotation value. Dates in YYYY-MM-DD format are
+// returned as-is; weekday names ("Wed", "Wednesday") resolve to that day in the
+// Monday-Sunday week containing reference.
+func ParseDueValue(value string, reference time.Time) (time.Time, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	if date, err := time.ParseInLocation("2006-01-02", value, reference.Location()); err == nil {
+		return date, true
+	}
+
+	weekday, ok := parseWeekday(value)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	// Find Monday of the reference week, then offset to the target weekday
+	refDay := time.Date(reference.Year(), reference.Month(), reference.Day(), 0, 0, 0, 0, reference.Location())
+	offsetFromMonday := (int(refDay.Weekday()) + 6) % 7
+	monday := refDay.AddDate(0, 0, -offsetFromMonday)
+	targetOffset := (int(weekday) + 6) % 7
+
+	return monday.AddDate(0, 0, targetOffset), true
+}
+
+// parseWeekday parses a full or abbreviated English weekday name
+func parseWeekday(value string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if value == name || (len(value) >= 3 && strings.HasPrefix(name, value)) {
+			return day, true
+		}
+	}
+	return 0, false
+}
@@ -1,7 +1,11 @@
 package markdown
 
 import (
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseCheckboxItems(t *testing.T) {
@@ -54,7 +58,18 @@ func TestParseCheckboxItems(t *testing.T) {
 			content: "  - [ ] Indented task\n    - [x] More indented",
 			expected: []CheckboxItem{
 				{Checked: false, Text: "Indented task"},
-				{Checked: true, Text: "More indented"},
+				{Checked: true, Text: "More indented", Indent: 1},
+			},
+		},
+		{
+			name:    "two-level nesting",
+			content: "- [ ] Parent\n    - [ ] Child\n        - [x] Grandchild\n    - [ ] Second child\n- [ ] Sibling",
+			expected: []CheckboxItem{
+				{Checked: false, Text: "Parent"},
+				{Checked: false, Text: "Child", Indent: 1},
+				{Checked: true, Text: "Grandchild", Indent: 2},
+				{Checked: false, Text: "Second child", Indent: 1},
+				{Checked: false, Text: "Sibling"},
 			},
 		},
 		{
@@ -95,6 +110,9 @@ func TestParseCheckboxItems(t *testing.T) {
 				if item.Text != tt.expected[i].Text {
 					t.Errorf("item %d: expected Text=%q, got %q", i, tt.expected[i].Text, item.Text)
 				}
+				if item.Indent != tt.expected[i].Indent {
+					t.Errorf("item %d: expected Indent=%d, got %d", i, tt.expected[i].Indent, item.Indent)
+				}
 			}
 		})
 	}
@@ -146,6 +164,16 @@ func TestFormatCheckboxItems(t *testing.T) {
 			},
 			expected: "- [ ] Task 1\n- [ ] Task 2",
 		},
+		{
+			name: "two-level nesting",
+			items: []CheckboxItem{
+				{Text: "Parent"},
+				{Text: "Child", Indent: 1},
+				{Text: "Grandchild", Checked: true, Indent: 2},
+				{Text: "Sibling"},
+			},
+			expected: "- [ ] Parent\n  - [ ] Child\n    - [x] Grandchild\n- [ ] Sibling",
+		},
 	}
 
 	for _, tt := range tests {
@@ -203,7 +231,39 @@ func TestParseGoalItems(t *testing.T) {
 			content: "  - [ ] Indented checkbox\n    - Plain indented",
 			expected: []GoalItem{
 				{Text: "Indented checkbox", HasCheckbox: true, Checked: false},
-				{Text: "Plain indented", HasCheckbox: false, Checked: false},
+				{Text: "Plain indented", HasCheckbox: false, Checked: false, Indent: 1},
+			},
+		},
+		{
+			name:    "mixed ordered and unordered",
+			content: "1. [ ] First\n2. [x] Second\n- [ ] Bullet\n10. Plain ordered\n* Plain bullet\n3.[] Malformed",
+			expected: []GoalItem{
+				{Text: "First", HasCheckbox: true},
+				{Text: "Second", HasCheckbox: true, Checked: true},
+				{Text: "Bullet", HasCheckbox: true},
+				{Text: "Plain ordered"},
+				{Text: "Plain bullet"},
+				{Text: "Malformed", HasCheckbox: true},
+			},
+		},
+		{
+			name:    "nested ordered list",
+			content: "1. [ ] Parent\n   1. [ ] Child\n   2. Second child\n2. [ ] Sibling",
+			expected: []GoalItem{
+				{Text: "Parent", HasCheckbox: true},
+				{Text: "Child", HasCheckbox: true, Indent: 1},
+				{Text: "Second child", Indent: 1},
+				{Text: "Sibling", HasCheckbox: true},
+			},
+		},
+		{
+			name:    "two-level nesting with tabs",
+			content: "* [ ] Parent\n\t* Child\n\t\t* [x] Grandchild\n* Sibling",
+			expected: []GoalItem{
+				{Text: "Parent", HasCheckbox: true},
+				{Text: "Child", Indent: 1},
+				{Text: "Grandchild", HasCheckbox: true, Checked: true, Indent: 2},
+				{Text: "Sibling"},
 			},
 		},
 		{
@@ -252,6 +312,9 @@ func TestParseGoalItems(t *testing.T) {
 				if item.Checked != tt.expected[i].Checked {
 					t.Errorf("item %d: expected Checked=%v, got %v", i, tt.expected[i].Checked, item.Checked)
 				}
+				if item.Indent != tt.expected[i].Indent {
+					t.Errorf("item %d: expected Indent=%d, got %d", i, tt.expected[i].Indent, item.Indent)
+				}
 			}
 		})
 	}
@@ -282,6 +345,139 @@ func TestFilterUnfinishedGoals(t *testing.T) {
 	}
 }
 
+func TestFilterCompletedGoals(t *testing.T) {
+	items := []GoalItem{
+		{Text: "Unchecked", HasCheckbox: true, Checked: false},
+		{Text: "Completed", HasCheckbox: true, Checked: true},
+		{Text: "Plain bullet", HasCheckbox: false, Checked: false},
+		{Text: "Another completed", HasCheckbox: true, Checked: true},
+		{Text: "Another plain", HasCheckbox: false, Checked: false},
+	}
+
+	completed := FilterCompletedGoals(items)
+
+	// Should include only the checked items; plain bullets have no state
+	if len(completed) != 2 {
+		t.Fatalf("expected 2 completed items, got %d", len(completed))
+	}
+
+	expectedTexts := []string{"Completed", "Another completed"}
+	for i, item := range completed {
+		if item.Text != expectedTexts[i] {
+			t.Errorf("item %d: expected %q, got %q", i, expectedTexts[i], item.Text)
+		}
+	}
+}
+
+func TestFilterCompletedGoals_Parsed(t *testing.T) {
+	// Malformed checkboxes ([] with no space) and plain bullets, as written by hand
+	content := `- [] get pagination working
+- [x] office
+* [X] review PR
+* Check Slack messages
+- [ ] write docs`
+
+	completed := FilterCompletedGoals(ParseGoalItems(content))
+
+	expectedTexts := []string{"office", "review PR"}
+	if len(completed) != len(expectedTexts) {
+		t.Fatalf("expected %d completed items, got %d", len(expectedTexts), len(completed))
+	}
+	for i, item := range completed {
+		if item.Text != expectedTexts[i] {
+			t.Errorf("item %d: expected %q, got %q", i, expectedTexts[i], item.Text)
+		}
+	}
+}
+
+func TestSetGoalLineChecked(t *testing.T) {
+	tests := []struct {
+		line    string
+		checked bool
+		want    string
+		wantOK  bool
+	}{
+		{"- [ ] Task", true, "- [x] Task", true},
+		{"* [X] Task", false, "* [ ] Task", true},
+		{"  - [] Malformed", true, "  - [x] Malformed", true},
+		{"1. [ ] Ordered (by Fri) #tag", true, "1. [x] Ordered (by Fri) #tag", true},
+		{"- Plain bullet", true, "- [x] Plain bullet", true},
+		{"Not a list item", true, "Not a list item", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := SetGoalLineChecked(tt.line, tt.checked)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("SetGoalLineChecked(%q, %v) = %q, %v; want %q, %v", tt.line, tt.checked, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseGoalItemsTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"no tags", "- [ ] Write docs", nil},
+		{"single tag", "- [ ] Ship auth #blocked", []string{"blocked"}},
+		{"multiple tags", "* #Infra migrate DB #team/platform", []string{"infra", "team/platform"}},
+		{"issue reference is not a tag", "- [ ] Fix bug #123", nil},
+		{"URL fragment is not a tag", "- Read https://example.com/page#section", nil},
+		{"due annotation kept", "- [ ] Plan Q2 (by Fri) #dropped", []string{"dropped"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := ParseGoalItems(tt.content)
+			if len(items) != 1 {
+				t.Fatalf("expected 1 item, got %d", len(items))
+			}
+			if !reflect.DeepEqual(items[0].Tags, tt.want) {
+				t.Errorf("expected tags %v, got %v", tt.want, items[0].Tags)
+			}
+			// Text is left intact
+			if !strings.Contains(tt.content, items[0].Text) {
+				t.Errorf("expected Text %q to be unchanged", items[0].Text)
+			}
+		})
+	}
+}
+
+func TestGoalItemHasTag(t *testing.T) {
+	item := GoalItem{Text: "Migrate DB #Blocked", Tags: []string{"blocked"}}
+
+	for _, tag := range []string{"blocked", "#blocked", "BLOCKED"} {
+		if !item.HasTag(tag) {
+			t.Errorf("HasTag(%q) = false, want true", tag)
+		}
+	}
+	if item.HasTag("dropped") {
+		t.Error("HasTag(\"dropped\") = true, want false")
+	}
+}
+
+func TestFilterGoals(t *testing.T) {
+	items := ParseGoalItems("- [ ] Ship auth\n- [ ] Plan Q2 #dropped\n- [x] Fix CI\n- Review #blocked")
+
+	kept := FilterGoals(items, func(item GoalItem) bool {
+		return !item.HasTag("dropped")
+	})
+
+	var texts []string
+	for _, item := range kept {
+		texts = append(texts, item.Text)
+	}
+	want := []string{"Ship auth", "Fix CI", "Review #blocked"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("expected %v, got %v", want, texts)
+	}
+
+	if got := FilterGoals(items, func(GoalItem) bool { return false }); len(got) != 0 {
+		t.Errorf("expected no goals, got %v", got)
+	}
+}
+
 func TestFormatGoalItems(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -310,6 +506,26 @@ func TestFormatGoalItems(t *testing.T) {
 			},
 			expected: "- Item 1\n- Item 2",
 		},
+		{
+			name: "two-level nesting",
+			items: []GoalItem{
+				{Text: "Parent", HasCheckbox: true},
+				{Text: "Child", Indent: 1},
+				{Text: "Grandchild", HasCheckbox: true, Indent: 2},
+				{Text: "Sibling", HasCheckbox: true},
+			},
+			expected: "- [ ] Parent\n  - Child\n    - [ ] Grandchild\n- [ ] Sibling",
+		},
+		{
+			name: "orphaned children are outdented",
+			items: []GoalItem{
+				{Text: "Child", HasCheckbox: true, Indent: 1},
+				{Text: "Grandchild", HasCheckbox: true, Indent: 2},
+				{Text: "Sibling", HasCheckbox: true},
+				{Text: "Deep", HasCheckbox: true, Indent: 2},
+			},
+			expected: "- [ ] Child\n  - [ ] Grandchild\n- [ ] Sibling\n  - [ ] Deep",
+		},
 	}
 
 	for _, tt := range tests {
@@ -321,3 +537,135 @@ func TestFormatGoalItems(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatGoalItemsOrdered(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []GoalItem
+		expected string
+	}{
+		{
+			name: "mixed items",
+			items: []GoalItem{
+				{Text: "Unchecked task", HasCheckbox: true},
+				{Text: "Completed task", HasCheckbox: true, Checked: true},
+				{Text: "Plain item"},
+			},
+			expected: "1. [ ] Unchecked task\n2. [x] Completed task\n3. Plain item",
+		},
+		{
+			name: "nested numbering restarts",
+			items: []GoalItem{
+				{Text: "Parent", HasCheckbox: true},
+				{Text: "Child", HasCheckbox: true, Indent: 1},
+				{Text: "Grandchild", Indent: 2},
+				{Text: "Second child", Indent: 1},
+				{Text: "Sibling", HasCheckbox: true},
+				{Text: "Sibling child", Indent: 1},
+			},
+			expected: "1. [ ] Parent\n   1. [ ] Child\n      1. Grandchild\n   2. Second child\n2. [ ] Sibling\n   1. Sibling child",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatGoalItems(tt.items, WithOrderedList())
+			if result != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatWithBullet(t *testing.T) {
+	goals := []GoalItem{
+		{Text: "Parent", HasCheckbox: true},
+		{Text: "Child", Indent: 1},
+		{Text: "Done", HasCheckbox: true, Checked: true},
+	}
+	checkboxes := []CheckboxItem{
+		{Text: "Parent"},
+		{Text: "Child", Checked: true, Indent: 1},
+	}
+
+	tests := []struct {
+		name   string
+		got    string
+		expect string
+	}{
+		{"goals with *", FormatGoalItems(goals, WithBullet("*")), "* [ ] Parent\n  * Child\n* [x] Done"},
+		{"goals with empty bullet", FormatGoalItems(goals, WithBullet("")), "- [ ] Parent\n  - Child\n- [x] Done"},
+		{"checkboxes with *", FormatCheckboxItems(checkboxes, WithBullet("*")), "* [ ] Parent\n  * [x] Child"},
+		{"ordered ignores bullet", FormatGoalItems(goals[:1], WithBullet("*"), WithOrderedList()), "1. [ ] Parent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expect {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expect, tt.got)
+			}
+		})
+	}
+}
+
+func TestAnnotateDueDates(t *testing.T) {
+	pattern := regexp.MustCompile(`\(by\s+([^)]+)\)`)
+	// Wednesday 2025-01-15; week runs Mon 2025-01-13 to Sun 2025-01-19
+	reference := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	items := []GoalItem{
+		{Text: "Ship auth service (by Wed)", HasCheckbox: true},
+		{Text: "Write docs (by Friday)", HasCheckbox: true},
+		{Text: "Plan Q2 (by 2025-01-14)"},
+		{Text: "No annotation here", HasCheckbox: true},
+		{Text: "Bogus annotation (by someday)"},
+	}
+
+	annotated := AnnotateDueDates(items, pattern, reference)
+
+	expected := []string{"2025-01-15", "2025-01-17", "2025-01-14", "", ""}
+	for i, want := range expected {
+		got := ""
+		if annotated[i].HasDueDate() {
+			got = annotated[i].Due.Format("2006-01-02")
+		}
+		if got != want {
+			t.Errorf("item %d (%q): due = %q, want %q", i, items[i].Text, got, want)
+		}
+	}
+
+	// Input is not modified
+	if items[0].HasDueDate() {
+		t.Error("AnnotateDueDates() should not modify its input")
+	}
+}
+
+func TestParseDueValue(t *testing.T) {
+	// Sunday 2025-01-19 is the last day of the week starting Mon 2025-01-13
+	reference := time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   string
+		wantOK bool
+	}{
+		{"Mon", "2025-01-13", true},
+		{"tues", "2025-01-14", true},
+		{"Sunday", "2025-01-19", true},
+		{"2025-02-01", "2025-02-01", true},
+		{"mo", "", false},
+		{"next week", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := ParseDueValue(tt.value, reference)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseDueValue(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if ok && got.Format("2006-01-02") != tt.want {
+				t.Errorf("ParseDueValue(%q) = %s, want %s", tt.value, got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
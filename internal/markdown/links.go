@@ -1,8 +1,12 @@
 package markdown
 
 import (
+	"bytes"
+	"path"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/yuin/goldmark/ast"
 )
@@ -15,47 +19,190 @@ type Link struct {
 	// Destination is the link target (what appears between ())
 	Destination string
 
+	// Title is the optional link title, as in [text](destination "title")
+	Title string
+
 	// Line is the line number where the link appears (1-indexed)
 	Line int
 
-	// Node is the AST node for this link
+	// Node is the AST node for this link (nil for wiki links and images)
 	Node *ast.Link
+
+	// IsImage is true for images like ![alt](img.png), which only
+	// ExtractAllLinks returns. Text is the alt text.
+	IsImage bool
+
+	// ImageNode is the AST node for an image (nil otherwise)
+	ImageNode *ast.Image
+
+	// Wiki is true for wiki-style links like [[2025-01-06]] or
+	// [[2025-01-06|Yesterday]]. Destination is the target and Text is the
+	// alias, or the target if there is no alias.
+	Wiki bool
+
+	// offset is the byte offset of the link in the source, used for ordering
+	offset int
 }
 
-// ExtractLinks extracts all markdown links from the document
+// wikiLinkPattern matches [[target]] and [[target|alias]]
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]|]+)(?:\|([^\[\]]*))?\]\]`)
+
+// ExtractLinks extracts all markdown and wiki-style links from the document,
+// in the order they appear
 func (doc *Document) ExtractLinks() []Link {
+	return sortLinks(append(doc.extractMarkdownLinks(false), doc.ExtractWikiLinks()...))
+}
+
+// ExtractAllLinks is like ExtractLinks, but also includes images, with
+// IsImage set
+func (doc *Document) ExtractAllLinks() []Link {
+	return sortLinks(append(doc.extractMarkdownLinks(true), doc.ExtractWikiLinks()...))
+}
+
+// sortLinks sorts links into the order they appear in the source
+func sortLinks(links []Link) []Link {
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].offset < links[j].offset
+	})
+	return links
+}
+
+// ExtractWikiLinks extracts wiki-style [[target]] links from the document.
+// Links inside code spans and code blocks are ignored.
+func (doc *Document) ExtractWikiLinks() []Link {
 	var links []Link
 
 	doc.WalkAST(func(node ast.Node, entering bool) ast.WalkStatus {
-		if !entering {
+		if !entering || node.Type() != ast.TypeBlock {
+			return ast.WalkContinue
+		}
+
+		switch node.(type) {
+		case *ast.Paragraph, *ast.TextBlock, *ast.Heading:
+		default:
 			return ast.WalkContinue
 		}
 
-		if linkNode, ok := node.(*ast.Link); ok {
-			// Get link text
-			text := doc.GetNodeText(linkNode)
+		codeSpans := codeSpanRanges(node)
 
-			// Get destination
-			destination := string(linkNode.Destination)
+		lines := node.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			segment := lines.At(i)
+			value := segment.Value(doc.Source)
 
-			// Get line number by finding parent block node
-			line := 0
-			parent := linkNode.Parent()
-			for parent != nil {
-				if parent.Lines().Len() > 0 {
-					lineSegment := parent.Lines().At(0)
-					// Count newlines up to this point to get approximate line number
-					line = countLines(doc.Source[:lineSegment.Start]) + 1
-					break
+			for _, m := range wikiLinkPattern.FindAllSubmatchIndex(value, -1) {
+				start := segment.Start + m[0]
+				if inRanges(start, codeSpans) {
+					continue
 				}
-				parent = parent.Parent()
+
+				target := strings.TrimSpace(string(value[m[2]:m[3]]))
+				text := target
+				if m[4] >= 0 {
+					text = strings.TrimSpace(string(value[m[4]:m[5]]))
+				}
+
+				links = append(links, Link{
+					Text:        text,
+					Destination: target,
+					Line:        countLines(doc.Source[:start]) + 1,
+					Wiki:        true,
+					offset:      start,
+				})
+			}
+		}
+
+		return ast.WalkContinue
+	})
+
+	return links
+}
+
+// codeSpanRanges returns the source byte ranges covered by code spans within
+// a block node
+func codeSpanRanges(block ast.Node) [][2]int {
+	var ranges [][2]int
+	_ = ast.Walk(block, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if _, ok := n.(*ast.CodeSpan); !ok {
+			return ast.WalkContinue, nil
+		}
+		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+			if textNode, ok := child.(*ast.Text); ok {
+				ranges = append(ranges, [2]int{textNode.Segment.Start, textNode.Segment.Stop})
 			}
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	return ranges
+}
+
+// inRanges reports whether pos falls within any of the ranges
+func inRanges(pos int, ranges [][2]int) bool {
+	for _, r := range ranges {
+		if pos >= r[0] && pos < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Format renders the link in its original style with the given destination.
+// A title is rendered in double quotes.
+func (l *Link) Format(destination string) string {
+	if !l.Wiki {
+		if l.Title != "" {
+			destination += ` "` + strings.ReplaceAll(l.Title, `"`, `\"`) + `"`
+		}
+		formatted := "[" + l.Text + "](" + destination + ")"
+		if l.IsImage {
+			formatted = "!" + formatted
+		}
+		return formatted
+	}
+	if l.Text == l.Destination {
+		return "[[" + destination + "]]"
+	}
+	return "[[" + destination + "|" + l.Text + "]]"
+}
 
+// extractMarkdownLinks extracts all [text](destination) links from the
+// document, and ![alt](destination) images if includeImages is set. Links
+// inside code spans and code blocks are ignored.
+func (doc *Document) extractMarkdownLinks(includeImages bool) []Link {
+	var links []Link
+
+	doc.WalkAST(func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering || inCode(node) {
+			return ast.WalkContinue
+		}
+
+		switch n := node.(type) {
+		case *ast.Link:
+			line, offset := doc.linkPosition(n)
 			links = append(links, Link{
-				Text:        text,
-				Destination: destination,
+				Text:        doc.GetNodeText(n),
+				Destination: string(n.Destination),
+				Title:       string(n.Title),
 				Line:        line,
-				Node:        linkNode,
+				Node:        n,
+				offset:      offset,
+			})
+		case *ast.Image:
+			if !includeImages {
+				break
+			}
+			line, offset := doc.linkPosition(n)
+			links = append(links, Link{
+				Text:        doc.GetNodeText(n),
+				Destination: string(n.Destination),
+				Title:       string(n.Title),
+				Line:        line,
+				IsImage:     true,
+				ImageNode:   n,
+				offset:      offset,
 			})
 		}
 
@@ -65,6 +212,65 @@ func (doc *Document) ExtractLinks() []Link {
 	return links
 }
 
+// inCode reports whether node is within a code span or code block, where
+// link syntax is an example rather than a link
+func inCode(node ast.Node) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch parent.(type) {
+		case *ast.CodeSpan, *ast.CodeBlock, *ast.FencedCodeBlock:
+			return true
+		}
+	}
+	return false
+}
+
+// linkPosition returns the 1-indexed line a link or image appears on and its
+// byte offset in the source. It uses the position of the link text, or for
+// links without text (e.g. [](2025-01-06)), the first "[]" after the content
+// that precedes the link in its block.
+func (doc *Document) linkPosition(linkNode ast.Node) (int, int) {
+	for child := linkNode.FirstChild(); child != nil; child = child.FirstChild() {
+		if textNode, ok := child.(*ast.Text); ok {
+			return countLines(doc.Source[:textNode.Segment.Start]) + 1, textNode.Segment.Start
+		}
+	}
+
+	start := -1
+	for sibling := linkNode.PreviousSibling(); sibling != nil && start < 0; sibling = sibling.PreviousSibling() {
+		start = lastSegmentStop(sibling)
+	}
+	if start < 0 {
+		for parent := linkNode.Parent(); parent != nil; parent = parent.Parent() {
+			if parent.Lines().Len() > 0 {
+				start = parent.Lines().At(0).Start
+				break
+			}
+		}
+	}
+	if start < 0 {
+		return 0, 0
+	}
+
+	if i := bytes.Index(doc.Source[start:], []byte("[]")); i >= 0 {
+		start += i
+	}
+	return countLines(doc.Source[:start]) + 1, start
+}
+
+// lastSegmentStop returns the source offset where the last text within node
+// ends, or -1 if it has none
+func lastSegmentStop(node ast.Node) int {
+	if textNode, ok := node.(*ast.Text); ok {
+		return textNode.Segment.Stop
+	}
+	for child := node.LastChild(); child != nil; child = child.PreviousSibling() {
+		if stop := lastSegmentStop(child); stop >= 0 {
+			return stop
+		}
+	}
+	return -1
+}
+
 // countLines counts the number of newlines in a byte slice
 func countLines(data []byte) int {
 	count := 0
@@ -76,17 +282,54 @@ func countLines(data []byte) int {
 	return count
 }
 
-// IsDateLink returns true if the link destination looks like a date (YYYY-MM-DD)
-func (l *Link) IsDateLink() bool {
-	// Match YYYY-MM-DD pattern
-	matched, _ := regexp.MatchString(`^\d{4}-\d{2}-\d{2}(\.md)?$`, l.Destination)
-	if matched {
+// dateFilePattern matches a date note's filename, with or without .md
+var dateFilePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(\.md)?$`)
+
+// IsDateLink returns true if the link destination is a relative path whose
+// last element looks like a date (YYYY-MM-DD), e.g. 2025-01-06,
+// ../journal/2025-01-06.md or, with a nested path layout,
+// ../journal/2025/01/2025-01-06. A last element in one of formats, Go time
+// layouts for note filenames such as 20060102, also counts.
+func (l *Link) IsDateLink(formats ...string) bool {
+	target := l.Path()
+	if l.IsExternalLink() || strings.HasPrefix(target, "/") {
+		return false
+	}
+	name := path.Base(target)
+	if dateFilePattern.MatchString(name) {
 		return true
 	}
+	_, ok := parseDateName(name, formats)
+	return ok
+}
+
+// parseDateName parses a note name, with or without .md, in the first of
+// formats that matches
+func parseDateName(name string, formats []string) (time.Time, bool) {
+	name = strings.TrimSuffix(name, ".md")
+	for _, format := range formats {
+		if format == "" {
+			continue
+		}
+		if date, err := time.Parse(format, name); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
 
-	// Also check for relative paths like ../journal/YYYY-MM-DD.md
-	matched, _ = regexp.MatchString(`\.\./[^/]+/\d{4}-\d{2}-\d{2}(\.md)?$`, l.Destination)
-	return matched
+// Path returns the link destination without any ?query or #fragment suffix
+func (l *Link) Path() string {
+	return strings.TrimSuffix(l.Destination, l.Fragment())
+}
+
+// Fragment returns the ?query and/or #fragment suffix of the link destination,
+// including the leading ? or #, or "" if there is none
+func (l *Link) Fragment() string {
+	if i := strings.IndexAny(l.Destination, "?#"); i >= 0 {
+		return l.Destination[i:]
+	}
+	return ""
 }
 
 // IsRelativeLink returns true if the link is a relative path
@@ -100,11 +343,19 @@ func (l *Link) IsExternalLink() bool {
 	return strings.Contains(l.Destination, "://")
 }
 
-// GetDateFromDestination extracts the date portion from a link destination
-// Returns the date string (YYYY-MM-DD) or empty string if not a date link
-func (l *Link) GetDateFromDestination() string {
+// GetDateFromDestination extracts the date portion from the last element of
+// a link destination, so directories such as ../work/standup/ or a nested
+// path layout are ignored. The element is parsed with formats first, as in
+// IsDateLink. Returns the date string (YYYY-MM-DD) or empty string if not a
+// date link.
+func (l *Link) GetDateFromDestination(formats ...string) string {
+	name := path.Base(l.Path())
+	if date, ok := parseDateName(name, formats); ok {
+		return date.Format("2006-01-02")
+	}
+
 	datePattern := regexp.MustCompile(`(\d{4}-\d{2}-\d{2})`)
-	matches := datePattern.FindStringSubmatch(l.Destination)
+	matches := datePattern.FindStringSubmatch(name)
 	if len(matches) > 1 {
 		return matches[1]
 	}
@@ -90,6 +90,66 @@ func TestIsDateLink(t *testing.T) {
 			destination: "some-page",
 			want:        false,
 		},
+		{
+			name:        "date with anchor",
+			destination: "2025-01-06#goals",
+			want:        true,
+		},
+		{
+			name:        "relative path with anchor",
+			destination: "../journal/2025-01-06.md#goals",
+			want:        true,
+		},
+		{
+			name:        "relative path with query",
+			destination: "../journal/2025-01-06.md?plain=1",
+			want:        true,
+		},
+		{
+			name:        "query and anchor",
+			destination: "2025-01-06.md?plain=1#L10",
+			want:        true,
+		},
+		{
+			name:        "date only in anchor",
+			destination: "notes.md#2025-01-06",
+			want:        false,
+		},
+		{
+			name:        "nested layout in another directory",
+			destination: "../journal/2025/02/2025-02-03",
+			want:        true,
+		},
+		{
+			name:        "nested layout in the same directory tree",
+			destination: "../01/2025-01-31.md",
+			want:        true,
+		},
+		{
+			name:        "nested layout across years",
+			destination: "../../2024/12/2024-12-31",
+			want:        true,
+		},
+		{
+			name:        "nested layout below the note",
+			destination: "2025/02/2025-02-03.md#goals",
+			want:        true,
+		},
+		{
+			name:        "date directory without a date filename",
+			destination: "../journal/2025-02-03/notes.md",
+			want:        false,
+		},
+		{
+			name:        "external URL ending in a date",
+			destination: "https://example.com/2025-01-06",
+			want:        false,
+		},
+		{
+			name:        "absolute path",
+			destination: "/journal/2025-01-06.md",
+			want:        false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -102,6 +162,40 @@ func TestIsDateLink(t *testing.T) {
 	}
 }
 
+func TestIsDateLinkFormats(t *testing.T) {
+	formats := []string{"20060102", "2006-01-02-standup"}
+
+	tests := []struct {
+		destination string
+		want        bool
+		wantDate    string
+	}{
+		{"20250106", true, "2025-01-06"},
+		{"20250106.md#goals", true, "2025-01-06"},
+		{"../standup/2025-01-06-standup", true, "2025-01-06"},
+		{"../journal/2025/01/20250106.md", true, "2025-01-06"},
+		{"2025-01-06", true, "2025-01-06"},
+		{"20251306", false, ""},
+		{"notes.md", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			link := Link{Destination: tt.destination}
+			if got := link.IsDateLink(formats...); got != tt.want {
+				t.Errorf("IsDateLink(%q) = %v, want %v", formats, got, tt.want)
+			}
+			if got := link.GetDateFromDestination(formats...); got != tt.wantDate {
+				t.Errorf("GetDateFromDestination(%q) = %q, want %q", formats, got, tt.wantDate)
+			}
+		})
+	}
+
+	if link := (Link{Destination: "20250106"}); link.IsDateLink() {
+		t.Error("IsDateLink() without formats should only accept YYYY-MM-DD")
+	}
+}
+
 func TestIsRelativeLink(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -214,6 +308,31 @@ func TestGetDateFromDestination(t *testing.T) {
 			destination: "https://example.com",
 			want:        "",
 		},
+		{
+			name:        "relative path with anchor",
+			destination: "../journal/2025-01-06.md#goals",
+			want:        "2025-01-06",
+		},
+		{
+			name:        "date with query",
+			destination: "2025-01-06?plain=1",
+			want:        "2025-01-06",
+		},
+		{
+			name:        "date only in anchor",
+			destination: "notes.md#2025-01-06",
+			want:        "",
+		},
+		{
+			name:        "vault root relative path",
+			destination: "../work/standup/2025-01-07",
+			want:        "2025-01-07",
+		},
+		{
+			name:        "date in a directory only",
+			destination: "../2024-12-01-archive/notes.md",
+			want:        "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -403,3 +522,223 @@ Sixth line has [another](dest2).
 		t.Logf("Link %d at line %d: [%s](%s)", i, link.Line, link.Text, link.Destination)
 	}
 }
+
+func TestExtractLinksTitle(t *testing.T) {
+	content := "* [Yesterday](2025-01-05 \"Sunday\")\n* [Tomorrow](2025-01-07)\n"
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	links := doc.ExtractLinks()
+	if len(links) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(links))
+	}
+	if links[0].Destination != "2025-01-05" || links[0].Title != "Sunday" {
+		t.Errorf("links[0] = %q %q, want destination 2025-01-05 and title Sunday", links[0].Destination, links[0].Title)
+	}
+	if links[1].Title != "" {
+		t.Errorf("links[1].Title = %q, want empty", links[1].Title)
+	}
+}
+
+func TestLinkLineNumbersMultiLineParagraph(t *testing.T) {
+	content := "# Daily Log\n\n" +
+		"Carried over from [yesterday](2025-01-05)\n" +
+		"and picked up again\n" +
+		"before [standup](../standup/2025-01-06) and\n" +
+		"an untitled [](2025-01-07) link.\n"
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	links := doc.ExtractLinks()
+
+	expected := []struct {
+		destination string
+		line        int
+	}{
+		{"2025-01-05", 3},
+		{"../standup/2025-01-06", 5},
+		{"2025-01-07", 6},
+	}
+
+	if len(links) != len(expected) {
+		t.Fatalf("expected %d links, got %d: %+v", len(expected), len(links), links)
+	}
+	for i, want := range expected {
+		if links[i].Destination != want.destination || links[i].Line != want.line {
+			t.Errorf("link %d = %q at line %d, want %q at line %d", i,
+				links[i].Destination, links[i].Line, want.destination, want.line)
+		}
+	}
+}
+
+func TestExtractLinksIgnoresCode(t *testing.T) {
+	content := "# Linking Notes\n\n" +
+		"Link to yesterday with `[Yesterday](2025-01-05)`.\n\n" +
+		"```markdown\n[Tomorrow](2025-01-07)\n```\n\n" +
+		"    [Indented](2025-01-08)\n\n" +
+		"See [Standup](../standup/2025-01-06).\n"
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	links := doc.ExtractLinks()
+	if len(links) != 1 {
+		t.Fatalf("expected 1 link, got %d: %+v", len(links), links)
+	}
+	if links[0].Destination != "../standup/2025-01-06" {
+		t.Errorf("expected link to ../standup/2025-01-06, got %q", links[0].Destination)
+	}
+}
+
+func TestExtractWikiLinks(t *testing.T) {
+	content := "# Daily Log\n\n" +
+		"* [[2025-01-05|Yesterday]]\n" +
+		"* [[2025-01-07]] and [Standup](../standup/2025-01-06)\n\n" +
+		"Use `[[2025-01-01]]` for wiki links.\n\n" +
+		"```\n[[2025-01-02]]\n```\n"
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	links := doc.ExtractLinks()
+
+	expected := []struct {
+		text        string
+		destination string
+		line        int
+		wiki        bool
+	}{
+		{"Yesterday", "2025-01-05", 3, true},
+		{"2025-01-07", "2025-01-07", 4, true},
+		{"Standup", "../standup/2025-01-06", 4, false},
+	}
+
+	if len(links) != len(expected) {
+		t.Fatalf("expected %d links, got %d: %+v", len(expected), len(links), links)
+	}
+
+	for i, want := range expected {
+		got := links[i]
+		if got.Text != want.text || got.Destination != want.destination || got.Line != want.line || got.Wiki != want.wiki {
+			t.Errorf("link %d = {%q %q %d %v}, want {%q %q %d %v}", i,
+				got.Text, got.Destination, got.Line, got.Wiki,
+				want.text, want.destination, want.line, want.wiki)
+		}
+	}
+
+	if !links[0].IsDateLink() {
+		t.Error("wiki link [[2025-01-05|Yesterday]] should be a date link")
+	}
+}
+
+func TestExtractAllLinks(t *testing.T) {
+	content := "# Daily Log\n\n" +
+		"* [Yesterday](2025-01-05)\n" +
+		"* ![Whiteboard](2025-01-06.png \"Planning\") and [[2025-01-07]]\n" +
+		"* ![](attachments/diagram.svg)\n\n" +
+		"Embed with `![alt](img.png)`.\n"
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	if links := doc.ExtractLinks(); len(links) != 2 {
+		t.Errorf("ExtractLinks() returned %d links, want 2 without images: %+v", len(links), links)
+	}
+
+	links := doc.ExtractAllLinks()
+
+	expected := []struct {
+		text        string
+		destination string
+		title       string
+		line        int
+		image       bool
+	}{
+		{"Yesterday", "2025-01-05", "", 3, false},
+		{"Whiteboard", "2025-01-06.png", "Planning", 4, true},
+		{"2025-01-07", "2025-01-07", "", 4, false},
+		{"", "attachments/diagram.svg", "", 5, true},
+	}
+
+	if len(links) != len(expected) {
+		t.Fatalf("ExtractAllLinks() returned %d links, want %d: %+v", len(links), len(expected), links)
+	}
+
+	for i, want := range expected {
+		got := links[i]
+		if got.Text != want.text || got.Destination != want.destination || got.Title != want.title ||
+			got.Line != want.line || got.IsImage != want.image {
+			t.Errorf("link %d = {%q %q %q %d %v}, want {%q %q %q %d %v}", i,
+				got.Text, got.Destination, got.Title, got.Line, got.IsImage,
+				want.text, want.destination, want.title, want.line, want.image)
+		}
+		if got.IsImage && (got.ImageNode == nil || got.Node != nil) {
+			t.Errorf("link %d: image should have ImageNode and no Node", i)
+		}
+	}
+}
+
+func TestLinkFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		link Link
+		want string
+	}{
+		{"markdown", Link{Text: "Yesterday", Destination: "2025-01-05"}, "[Yesterday](2025-01-06)"},
+		{"wiki", Link{Text: "2025-01-05", Destination: "2025-01-05", Wiki: true}, "[[2025-01-06]]"},
+		{"wiki with alias", Link{Text: "Yesterday", Destination: "2025-01-05", Wiki: true}, "[[2025-01-06|Yesterday]]"},
+		{"markdown with title", Link{Text: "Yesterday", Destination: "2025-01-05", Title: "Monday"}, `[Yesterday](2025-01-06 "Monday")`},
+		{"title with quotes", Link{Text: "Yesterday", Destination: "2025-01-05", Title: `The "big" day`}, `[Yesterday](2025-01-06 "The \"big\" day")`},
+		{"image", Link{Text: "Whiteboard", Destination: "2025-01-05.png", IsImage: true}, "![Whiteboard](2025-01-06)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.link.Format("2025-01-06"); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkPathAndFragment(t *testing.T) {
+	tests := []struct {
+		destination  string
+		wantPath     string
+		wantFragment string
+	}{
+		{"2025-01-06", "2025-01-06", ""},
+		{"../journal/2025-01-06.md#goals", "../journal/2025-01-06.md", "#goals"},
+		{"2025-01-06?plain=1", "2025-01-06", "?plain=1"},
+		{"2025-01-06.md?plain=1#L10", "2025-01-06.md", "?plain=1#L10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.destination, func(t *testing.T) {
+			link := Link{Destination: tt.destination}
+			if got := link.Path(); got != tt.wantPath {
+				t.Errorf("Path() = %q, want %q", got, tt.wantPath)
+			}
+			if got := link.Fragment(); got != tt.wantFragment {
+				t.Errorf("Fragment() = %q, want %q", got, tt.wantFragment)
+			}
+		})
+	}
+}
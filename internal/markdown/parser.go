@@ -6,7 +6,10 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/rdark/za/internal/util"
 	"github.com/yuin/goldmark"
 	meta "github.com/yuin/goldmark-meta"
 	"github.com/yuin/goldmark/ast"
@@ -63,8 +66,10 @@ func (p *Parser) ParseFile(filePath string) (*Document, error) {
 	return p.Parse(filePath, content)
 }
 
-// Parse parses markdown content and returns a Document
+// Parse parses markdown content and returns a Document. "\r\n" line endings
+// are converted to "\n".
 func (p *Parser) Parse(filePath string, content []byte) (*Document, error) {
+	content = util.NormalizeNewlines(content)
 	doc := &Document{
 		FilePath: filePath,
 		Content:  content,
@@ -137,6 +142,75 @@ func (doc *Document) GetMetadataStringSlice(key string) ([]string, bool) {
 	return nil, false
 }
 
+// GetMetadataBool returns a metadata value as a bool
+func (doc *Document) GetMetadataBool(key string) (bool, bool) {
+	val, ok := doc.GetMetadata(key)
+	if !ok {
+		return false, false
+	}
+	b, ok := val.(bool)
+	return b, ok
+}
+
+// GetMetadataInt returns a metadata value as an int. Whole-number floats are
+// accepted; strings are not.
+func (doc *Document) GetMetadataInt(key string) (int, bool) {
+	val, ok := doc.GetMetadata(key)
+	if !ok {
+		return 0, false
+	}
+
+	switch v := val.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+// metadataTimeLayouts are the layouts GetMetadataTime tries, in order
+var metadataTimeLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"Monday, January 2, 2006",
+}
+
+// GetMetadataTime returns a metadata value as a time, parsing strings such
+// as "2025-01-06", "2025-01-06T09:30:00Z" or "January 6, 2025". Dates
+// without a zone are in UTC.
+func (doc *Document) GetMetadataTime(key string) (time.Time, bool) {
+	val, ok := doc.GetMetadata(key)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	switch v := val.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		value := strings.TrimSpace(v)
+		for _, layout := range metadataTimeLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
 // GetNodeText extracts text content from a node
 func (doc *Document) GetNodeText(node ast.Node) string {
 	var buf bytes.Buffer
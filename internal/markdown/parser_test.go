@@ -3,6 +3,7 @@ package markdown
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/yuin/goldmark/ast"
 )
@@ -348,11 +349,134 @@ list_field: ["one", "two", "three"]
 		t.Errorf("expected list_field with 3 items, got %v, ok=%v", listVal, ok)
 	}
 
+	// Test bool
+	boolVal, ok := doc.GetMetadataBool("bool_field")
+	if !ok || !boolVal {
+		t.Errorf("expected bool_field=true, got %v, ok=%v", boolVal, ok)
+	}
+
+	// Test int
+	intVal, ok := doc.GetMetadataInt("number_field")
+	if !ok || intVal != 42 {
+		t.Errorf("expected number_field=42, got %d, ok=%v", intVal, ok)
+	}
+
+	// Values of another type are not converted
+	if _, ok := doc.GetMetadataBool("string_field"); ok {
+		t.Error("string_field should not be a bool")
+	}
+	if _, ok := doc.GetMetadataInt("string_field"); ok {
+		t.Error("string_field should not be an int")
+	}
+	if _, ok := doc.GetMetadataInt("bool_field"); ok {
+		t.Error("bool_field should not be an int")
+	}
+
 	// Test non-existent key
 	_, ok = doc.GetMetadataString("nonexistent")
 	if ok {
 		t.Error("should not find nonexistent key")
 	}
+	if _, ok := doc.GetMetadataBool("nonexistent"); ok {
+		t.Error("should not find nonexistent bool key")
+	}
+	if _, ok := doc.GetMetadataInt("nonexistent"); ok {
+		t.Error("should not find nonexistent int key")
+	}
+}
+
+func TestGetMetadataInt(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   int
+		wantOK bool
+	}{
+		{"3", 3, true},
+		{"-1", -1, true},
+		{"0", 0, true},
+		{"9000000000", 9000000000, true},
+		{"3.0", 3, true},
+		{"3.5", 0, false},
+		{`"3"`, 0, false},
+		{"yes", 0, false},
+	}
+
+	p := NewParser()
+	for _, tt := range tests {
+		doc, err := p.Parse("test.md", []byte("---\npriority: "+tt.value+"\n---\n"))
+		if err != nil {
+			t.Fatalf("Parse() failed: %v", err)
+		}
+		got, ok := doc.GetMetadataInt("priority")
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("GetMetadataInt() for %s = %d, %v, want %d, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestGetMetadataTime(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   string
+		wantOK bool
+	}{
+		{"date", "2025-01-06", "2025-01-06T00:00:00Z", true},
+		{"quoted date", `"2025-01-06"`, "2025-01-06T00:00:00Z", true},
+		{"RFC3339", "2025-01-06T09:30:00Z", "2025-01-06T09:30:00Z", true},
+		{"RFC3339 with offset", "2025-01-06T09:30:00+10:00", "2025-01-06T09:30:00+10:00", true},
+		{"date and time", `"2025-01-06 09:30:00"`, "2025-01-06T09:30:00Z", true},
+		{"long date", "January 6, 2025", "2025-01-06T00:00:00Z", true},
+		{"short month", "Jan 6, 2025", "2025-01-06T00:00:00Z", true},
+		{"weekday and long date", `"Monday, January 6, 2025"`, "2025-01-06T00:00:00Z", true},
+		{"not a date", "Monday", "", false},
+		{"number", "42", "", false},
+	}
+
+	p := NewParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := p.Parse("test.md", []byte("---\ndate: "+tt.value+"\n---\n# Log\n"))
+			if err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+
+			got, ok := doc.GetMetadataTime("date")
+			if ok != tt.wantOK {
+				t.Fatalf("GetMetadataTime(%q) ok = %v, want %v", tt.value, ok, tt.wantOK)
+			}
+			if ok && got.Format(time.RFC3339) != tt.want {
+				t.Errorf("GetMetadataTime(%q) = %s, want %s", tt.value, got.Format(time.RFC3339), tt.want)
+			}
+		})
+	}
+
+	doc, _ := p.Parse("test.md", []byte("# No frontmatter\n"))
+	if _, ok := doc.GetMetadataTime("date"); ok {
+		t.Error("GetMetadataTime() should not find a missing key")
+	}
+}
+
+func TestGetMetadataTimeTestData(t *testing.T) {
+	tests := map[string]string{
+		"../../testdata/journal/2025-01-06.md": "2025-01-06",
+		"../../testdata/journal/2024-11-25.md": "2024-11-25",
+		"../../testdata/standup/2025-01-07.md": "2025-01-07",
+	}
+
+	p := NewParser()
+	for file, want := range tests {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			doc, err := p.ParseFile(file)
+			if err != nil {
+				t.Fatalf("ParseFile() failed: %v", err)
+			}
+			got, ok := doc.GetMetadataTime("date")
+			if !ok || got.Format("2006-01-02") != want {
+				t.Errorf("GetMetadataTime(date) = %v, %v, want %s", got, ok, want)
+			}
+		})
+	}
 }
 
 // TestParseAllTestData tests parsing all testdata files
@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// unorderedBulletRegex matches a "*" or "+" list marker, keeping the
+	// indentation and the whitespace after the marker
+	unorderedBulletRegex = regexp.MustCompile(`^(\s*)[*+](\s+)`)
+
+	// thematicBreakRegex matches a thematic break such as "***" or "* * *",
+	// which would otherwise look like a bullet
+	thematicBreakRegex = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+
+	// codeFenceRegex matches the opening or closing line of a fenced code block
+	codeFenceRegex = regexp.MustCompile("^ {0,3}(?:```|~~~)")
+)
+
+// Render returns the section content as normalized markdown: every bullet uses
+// "-", trailing whitespace is trimmed and runs of blank lines are collapsed
+// into one. Fenced code blocks are left untouched. Content itself keeps the
+// raw source.
+func (s Section) Render() string {
+	return renderMarkdown(s.Content)
+}
+
+// renderMarkdown normalizes markdown text as described for Section.Render
+func renderMarkdown(content string) string {
+	var lines []string
+	inFence := false
+	blank := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if codeFenceRegex.MatchString(line) {
+			inFence = !inFence
+		} else if inFence {
+			lines = append(lines, line)
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+
+		if !thematicBreakRegex.MatchString(line) {
+			line = unorderedBulletRegex.ReplaceAllString(line, "$1-$2")
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,59 @@
+package markdown
+
+import "testing"
+
+func TestSectionRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "mixed bullet markers",
+			content: "* one\n+ two\n- three\n  * nested",
+			want:    "- one\n- two\n- three\n  - nested",
+		},
+		{
+			name:    "blank lines collapsed and trailing whitespace trimmed",
+			content: "\n\nFirst paragraph.  \n\n\n\n* item\t\n\n",
+			want:    "First paragraph.\n\n- item",
+		},
+		{
+			name:    "CRLF line endings",
+			content: "* one\r\n\r\n\r\n* two\r\n",
+			want:    "- one\n\n- two",
+		},
+		{
+			name:    "thematic break kept",
+			content: "Above\n\n* * *\n\nBelow",
+			want:    "Above\n\n* * *\n\nBelow",
+		},
+		{
+			name:    "emphasis is not a bullet",
+			content: "*important* work",
+			want:    "*important* work",
+		},
+		{
+			name:    "ordered list untouched",
+			content: "1. first\n2. second",
+			want:    "1. first\n2. second",
+		},
+		{
+			name:    "fenced code untouched",
+			content: "* before\n\n```\n* not a bullet  \n\n\n+ raw\n```\n* after",
+			want:    "- before\n\n```\n* not a bullet  \n\n\n+ raw\n```\n- after",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := Section{Content: tt.content}
+			if got := section.Render(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+			if section.Content != tt.content {
+				t.Errorf("Render() modified Content: %q", section.Content)
+			}
+		})
+	}
+}
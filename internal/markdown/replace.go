@@ -0,0 +1,127 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// ErrNotInlineLink is returned when replacing the destination of a link whose
+// destination isn't written inline, e.g. a reference-style link like
+// [text][ref]
+var ErrNotInlineLink = errors.New("not an inline link")
+
+// DestinationReplacement pairs a link extracted from a document with the
+// destination it should have
+type DestinationReplacement struct {
+	Link        Link
+	Destination string
+}
+
+// ReplaceLinkDestination returns the document's content with the destination
+// of link, which must have been extracted from the document, replaced by
+// newDest. Only the destination changes; the link's text, title and
+// formatting are kept.
+func (doc *Document) ReplaceLinkDestination(link Link, newDest string) ([]byte, error) {
+	return doc.ReplaceLinkDestinations([]DestinationReplacement{{Link: link, Destination: newDest}})
+}
+
+// ReplaceLinkDestinations is like ReplaceLinkDestination for several links
+// at once
+func (doc *Document) ReplaceLinkDestinations(replacements []DestinationReplacement) ([]byte, error) {
+	type splice struct {
+		start, end int
+		dest       string
+	}
+
+	splices := make([]splice, 0, len(replacements))
+	for _, r := range replacements {
+		start, end, err := doc.destinationRange(r.Link)
+		if err != nil {
+			return nil, err
+		}
+		splices = append(splices, splice{start: start, end: end, dest: r.Destination})
+	}
+
+	// Splice from the end so earlier offsets stay valid
+	sort.Slice(splices, func(i, j int) bool {
+		return splices[i].start > splices[j].start
+	})
+
+	content := bytes.Clone(doc.Source)
+	for i, s := range splices {
+		if i > 0 && s.end > splices[i-1].start {
+			return nil, fmt.Errorf("overlapping link replacements at offset %d", s.start)
+		}
+		content = append(content[:s.start], append([]byte(s.dest), content[s.end:]...)...)
+	}
+	return content, nil
+}
+
+// IsReferenceLink reports whether link, which must have been extracted from
+// the document, is a reference-style link like [text][ref] whose destination
+// is defined elsewhere, so ReplaceLinkDestination can't change it
+func (doc *Document) IsReferenceLink(link Link) bool {
+	_, _, err := doc.destinationRange(link)
+	return errors.Is(err, ErrNotInlineLink)
+}
+
+// destinationRange returns the source byte range of a link's destination
+func (doc *Document) destinationRange(link Link) (int, int, error) {
+	src := doc.Source
+	formatted := link.Format(link.Destination)
+
+	if link.Wiki {
+		// offset is the start of "[[", followed by the target and any
+		// surrounding whitespace
+		start := link.offset + len("[[")
+		for start < len(src) && (src[start] == ' ' || src[start] == '\t') {
+			start++
+		}
+		end := start + len(link.Destination)
+		if link.offset < 0 || end > len(src) || !bytes.HasPrefix(src[link.offset:], []byte("[[")) ||
+			string(src[start:end]) != link.Destination {
+			return 0, 0, fmt.Errorf("link %s not found at line %d", formatted, link.Line)
+		}
+		return start, end, nil
+	}
+
+	var node ast.Node
+	switch {
+	case link.IsImage && link.ImageNode != nil:
+		node = link.ImageNode
+	case !link.IsImage && link.Node != nil:
+		node = link.Node
+	default:
+		return 0, 0, fmt.Errorf("link %s has no position in the document", formatted)
+	}
+
+	// The destination follows the "](" after the link text
+	from := lastSegmentStop(node)
+	if from < 0 {
+		from = link.offset
+	}
+	if from < 0 || from > len(src) {
+		return 0, 0, fmt.Errorf("link %s not found at line %d", formatted, link.Line)
+	}
+	closing := bytes.IndexByte(src[from:], ']')
+	if closing < 0 || from+closing+1 >= len(src) || src[from+closing+1] != '(' {
+		return 0, 0, fmt.Errorf("link %s at line %d: %w", formatted, link.Line, ErrNotInlineLink)
+	}
+
+	start := from + closing + 2
+	for start < len(src) && (src[start] == ' ' || src[start] == '\t' || src[start] == '\n') {
+		start++
+	}
+	if start < len(src) && src[start] == '<' {
+		start++
+	}
+	end := start + len(link.Destination)
+	if end > len(src) || string(src[start:end]) != link.Destination {
+		return 0, 0, fmt.Errorf("link %s not found at line %d", formatted, link.Line)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,227 @@
+package markdown
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReplaceLinkDestination(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		index   int // Which extracted link to replace
+		want    string
+	}{
+		{
+			name:    "simple link",
+			content: "* [Yesterday](2025-01-07)\n",
+			want:    "* [Yesterday](2025-01-06)\n",
+		},
+		{
+			name:    "wiki link",
+			content: "* [[2025-01-07|Yesterday]]\n",
+			want:    "* [[2025-01-06|Yesterday]]\n",
+		},
+		{
+			name:    "second of two identical links on a line",
+			content: "[Yesterday](2025-01-07) and [Yesterday](2025-01-07)\n",
+			index:   1,
+			want:    "[Yesterday](2025-01-07) and [Yesterday](2025-01-06)\n",
+		},
+		{
+			name:    "destination is a substring of an earlier one",
+			content: "[Notes](2025-01-07-notes) then [Yesterday](2025-01-07)\n",
+			index:   1,
+			want:    "[Notes](2025-01-07-notes) then [Yesterday](2025-01-06)\n",
+		},
+		{
+			name:    "text contains link syntax in a code span",
+			content: "Use `[Yesterday](2025-01-07)` like [Yesterday](2025-01-07)\n",
+			want:    "Use `[Yesterday](2025-01-07)` like [Yesterday](2025-01-06)\n",
+		},
+		{
+			name:    "single-quoted title kept",
+			content: "[Yesterday](2025-01-07 'Tuesday')\n",
+			want:    "[Yesterday](2025-01-06 'Tuesday')\n",
+		},
+		{
+			name:    "angle-bracketed destination",
+			content: "[Yesterday](<2025-01-07>)\n",
+			want:    "[Yesterday](<2025-01-06>)\n",
+		},
+		{
+			name:    "emphasised text",
+			content: "[**Yesterday**](2025-01-07)\n",
+			want:    "[**Yesterday**](2025-01-06)\n",
+		},
+		{
+			name:    "no text",
+			content: "See [](2025-01-07)\n",
+			want:    "See [](2025-01-06)\n",
+		},
+		{
+			name:    "wiki link with spaces",
+			content: "[[ 2025-01-07 ]]\n",
+			want:    "[[ 2025-01-06 ]]\n",
+		},
+		{
+			name:    "text spanning lines",
+			content: "A [long link\ntext](2025-01-07) here\n",
+			want:    "A [long link\ntext](2025-01-06) here\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := NewParser().Parse("test.md", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+			links := doc.ExtractLinks()
+			if tt.index >= len(links) {
+				t.Fatalf("expected at least %d links, got %d", tt.index+1, len(links))
+			}
+
+			got, err := doc.ReplaceLinkDestination(links[tt.index], "2025-01-06")
+			if err != nil {
+				t.Fatalf("ReplaceLinkDestination() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ReplaceLinkDestination() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceLinkDestinationMatchesFormat(t *testing.T) {
+	// For plain links, splicing the destination gives the same result as
+	// replacing the formatted link
+	content := "# Log\n\n* [Yesterday](2025-01-07)\n* [[2025-01-09|Tomorrow]]\n* [[2025-01-05]]\n"
+
+	doc, err := NewParser().Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	for _, link := range doc.ExtractLinks() {
+		got, err := doc.ReplaceLinkDestination(link, "2025-01-08")
+		if err != nil {
+			t.Fatalf("ReplaceLinkDestination(%s) error = %v", link.Format(link.Destination), err)
+		}
+		want := strings.Replace(content, link.Format(link.Destination), link.Format("2025-01-08"), 1)
+		if string(got) != want {
+			t.Errorf("ReplaceLinkDestination(%s) = %q, want %q", link.Format(link.Destination), got, want)
+		}
+	}
+}
+
+func TestReplaceLinkDestinations(t *testing.T) {
+	content := "[Yesterday](2025-01-07) [[2025-01-09|Tomorrow]]\n[Standup](../standup/2025-01-08)\n"
+
+	doc, err := NewParser().Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	links := doc.ExtractLinks()
+	if len(links) != 3 {
+		t.Fatalf("expected 3 links, got %d", len(links))
+	}
+
+	got, err := doc.ReplaceLinkDestinations([]DestinationReplacement{
+		{Link: links[2], Destination: "../standup/2025-01-10"},
+		{Link: links[0], Destination: "2025-01-06"},
+		{Link: links[1], Destination: "2025-01-10"},
+	})
+	if err != nil {
+		t.Fatalf("ReplaceLinkDestinations() error = %v", err)
+	}
+
+	want := "[Yesterday](2025-01-06) [[2025-01-10|Tomorrow]]\n[Standup](../standup/2025-01-10)\n"
+	if string(got) != want {
+		t.Errorf("ReplaceLinkDestinations() = %q, want %q", got, want)
+	}
+	if string(doc.Content) != content {
+		t.Error("ReplaceLinkDestinations() modified the document")
+	}
+}
+
+func TestReplaceImageDestinations(t *testing.T) {
+	content := "![Whiteboard](img/2025-01-06.png) ![](diagram.svg) [Yesterday](2025-01-05)\n"
+
+	doc, err := NewParser().Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	links := doc.ExtractAllLinks()
+	if len(links) != 3 {
+		t.Fatalf("expected 3 links, got %d", len(links))
+	}
+
+	got, err := doc.ReplaceLinkDestinations([]DestinationReplacement{
+		{Link: links[0], Destination: "attachments/2025-01-06.png"},
+		{Link: links[1], Destination: "attachments/diagram.svg"},
+		{Link: links[2], Destination: "2025-01-03"},
+	})
+	if err != nil {
+		t.Fatalf("ReplaceLinkDestinations() error = %v", err)
+	}
+
+	want := "![Whiteboard](attachments/2025-01-06.png) ![](attachments/diagram.svg) [Yesterday](2025-01-03)\n"
+	if string(got) != want {
+		t.Errorf("ReplaceLinkDestinations() = %q, want %q", got, want)
+	}
+}
+
+func TestReplaceLinkDestinationErrors(t *testing.T) {
+	content := "[Yesterday][ref]\n\n[ref]: 2025-01-07\n"
+
+	doc, err := NewParser().Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	links := doc.ExtractLinks()
+	if len(links) != 1 {
+		t.Fatalf("expected 1 link, got %d", len(links))
+	}
+
+	tests := []struct {
+		name string
+		link Link
+	}{
+		{"reference link", links[0]},
+		{"link not from the document", Link{Text: "Yesterday", Destination: "2025-01-07"}},
+		{"wiki link not from the document", Link{Text: "2025-01-07", Destination: "2025-01-07", Wiki: true, offset: 40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := doc.ReplaceLinkDestination(tt.link, "2025-01-06"); err == nil {
+				t.Error("ReplaceLinkDestination() should fail")
+			}
+		})
+	}
+}
+
+func TestIsReferenceLink(t *testing.T) {
+	content := "[Yesterday][y] [Tomorrow](2025-01-09) [[2025-01-10]]\n\n[y]: 2025-01-07\n"
+
+	doc, err := NewParser().Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	links := doc.ExtractLinks()
+	if len(links) != 3 {
+		t.Fatalf("expected 3 links, got %d", len(links))
+	}
+
+	for i, want := range []bool{true, false, false} {
+		if got := doc.IsReferenceLink(links[i]); got != want {
+			t.Errorf("IsReferenceLink(%s) = %v, want %v", links[i].Format(links[i].Destination), got, want)
+		}
+	}
+
+	if _, err := doc.ReplaceLinkDestination(links[0], "2025-01-06"); !errors.Is(err, ErrNotInlineLink) {
+		t.Errorf("ReplaceLinkDestination() error = %v, want ErrNotInlineLink", err)
+	}
+}
@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"sort"
 	"strings"
 )
 
@@ -11,21 +12,64 @@ type Section struct {
 
 	// Content is the raw content of the section (everything between this heading and the next)
 	Content string
+
+	// StartByte and EndByte are the offsets of the section in the document
+	// source: from the start of the heading line to the start of the line of
+	// the heading that ends the section (or the end of the document)
+	StartByte int
+	EndByte   int
+}
+
+// SectionOption configures optional section extraction behaviour
+type SectionOption func(*sectionOptions)
+
+// sectionOptions holds the optional settings applied by SectionOption values
+type sectionOptions struct {
+	subsections bool
+}
+
+// WithSubsections includes deeper-level headings and their content in a
+// section, stopping only at a heading of the same or a higher level (see
+// ExtractSectionsNested)
+func WithSubsections() SectionOption {
+	return func(o *sectionOptions) {
+		o.subsections = true
+	}
 }
 
 // ExtractSections extracts all sections from a document
-// A section is defined as a heading and all content until the next heading
-func (doc *Document) ExtractSections() []Section {
+// A section is defined as a heading and all content until the next heading,
+// or with WithSubsections, until the next heading of the same or higher level
+func (doc *Document) ExtractSections(opts ...SectionOption) []Section {
+	var o sectionOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.subsections {
+		return doc.ExtractSectionsNested()
+	}
 	// Use simple line-based extraction to avoid duplication
 	return doc.ExtractSectionsSimple()
 }
 
-// FindSectionByHeading finds a section by its heading text (case-insensitive)
-func (doc *Document) FindSectionByHeading(headingText string) *Section {
-	sections := doc.ExtractSections()
+// FindSectionByHeading finds a section by its heading text (case-insensitive),
+// at any heading level
+func (doc *Document) FindSectionByHeading(headingText string, opts ...SectionOption) *Section {
+	return doc.FindSectionByHeadingLevel(headingText, 0, opts...)
+}
+
+// FindSectionByHeadingLevel finds a section by its heading text
+// (case-insensitive), only matching headings at the given level (1 for #,
+// 2 for ##, ...). A level of 0 matches headings at any level.
+func (doc *Document) FindSectionByHeadingLevel(headingText string, level int, opts ...SectionOption) *Section {
+	sections := doc.ExtractSections(opts...)
 	normalizedSearch := strings.ToLower(strings.TrimSpace(headingText))
 
 	for _, section := range sections {
+		if level != 0 && section.Heading.Level != level {
+			continue
+		}
 		normalizedHeading := strings.ToLower(strings.TrimSpace(section.Heading.Text))
 		if normalizedHeading == normalizedSearch {
 			return &section
@@ -37,7 +81,7 @@ func (doc *Document) FindSectionByHeading(headingText string) *Section {
 
 // FindSectionsByHeadings finds multiple sections by their heading texts (case-insensitive)
 // Returns sections in the order they appear in the document
-func (doc *Document) FindSectionsByHeadings(headingTexts []string) []Section {
+func (doc *Document) FindSectionsByHeadings(headingTexts []string, opts ...SectionOption) []Section {
 	if len(headingTexts) == 0 {
 		return []Section{}
 	}
@@ -50,7 +94,7 @@ func (doc *Document) FindSectionsByHeadings(headingTexts []string) []Section {
 
 	// Find matching sections
 	var matchingSections []Section
-	sections := doc.ExtractSections()
+	sections := doc.ExtractSections(opts...)
 
 	for _, section := range sections {
 		normalizedHeading := strings.ToLower(strings.TrimSpace(section.Heading.Text))
@@ -61,3 +105,32 @@ func (doc *Document) FindSectionsByHeadings(headingTexts []string) []Section {
 
 	return matchingSections
 }
+
+// SortSectionsByHeadingOrder sorts sections by the position of their heading in
+// headingTexts (case-insensitive), rather than the order they appear in the document.
+// Sections with the same heading keep their document order; sections whose heading
+// is not in the list are placed last.
+func SortSectionsByHeadingOrder(sections []Section, headingTexts []string) []Section {
+	order := make(map[string]int, len(headingTexts))
+	for i, text := range headingTexts {
+		key := strings.ToLower(strings.TrimSpace(text))
+		if _, exists := order[key]; !exists {
+			order[key] = i
+		}
+	}
+
+	rank := func(section Section) int {
+		if i, ok := order[strings.ToLower(strings.TrimSpace(section.Heading.Text))]; ok {
+			return i
+		}
+		return len(headingTexts)
+	}
+
+	sorted := make([]Section, len(sections))
+	copy(sorted, sections)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(sorted[i]) < rank(sorted[j])
+	})
+
+	return sorted
+}
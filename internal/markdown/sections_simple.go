@@ -1,77 +1,107 @@
 package markdown
 
 import (
-	"bufio"
 	"bytes"
+	"regexp"
 	"strings"
 )
 
-// ExtractSectionsSimple extracts sections using a simpler line-based approach
+// atxHeadingRegex matches the opening of an ATX heading line (# to ######)
+var atxHeadingRegex = regexp.MustCompile(`^ {0,3}#{1,6}(?:[ \t]|\r?\n|$)`)
+
+// ExtractSectionsSimple extracts sections using a simpler line-based approach.
+// Each section runs from its heading to the next heading of any level.
 func (doc *Document) ExtractSectionsSimple() []Section {
+	return doc.extractSections(false)
+}
+
+// ExtractSectionsNested is like ExtractSectionsSimple, but a section's content
+// includes its subsections: it runs until the next heading of the same or a
+// higher level, so a "##" section keeps its "###" children. Every heading
+// still starts a section of its own.
+func (doc *Document) ExtractSectionsNested() []Section {
+	return doc.extractSections(true)
+}
+
+// extractSections splits the document into a section per heading. Section
+// boundaries come from the heading nodes' source positions, so both ATX (#)
+// and setext (underlined) headings are handled.
+func (doc *Document) extractSections(nested bool) []Section {
 	var sections []Section
 
-	// Get all headings
 	headings := doc.GetHeadings()
-	if len(headings) == 0 {
-		return sections
-	}
-
-	// For each heading, extract content until next heading
 	for i, heading := range headings {
-		// Get start line of content (after heading)
-		startLine := heading.Node.Lines().At(0).Start
-
-		// Get end line (start of next heading or end of document)
-		var endLine int
-		if i < len(headings)-1 {
-			endLine = headings[i+1].Node.Lines().At(0).Start
-		} else {
-			endLine = len(doc.Source)
+		// End at the next heading (that isn't a subsection of this one, if nested)
+		endByte := len(doc.Source)
+		for _, next := range headings[i+1:] {
+			if !nested || next.Level <= heading.Level {
+				endByte = headingStart(doc.Source, next)
+				break
+			}
 		}
 
-		// Extract content between these lines
-		content := extractContentBetween(doc.Source, startLine, endLine)
+		contentStart := headingEnd(doc.Source, heading)
+		if contentStart > endByte {
+			contentStart = endByte
+		}
 
 		sections = append(sections, Section{
-			Heading: heading,
-			Content: content,
+			Heading:   heading,
+			Content:   normalizeContent(doc.Source[contentStart:endByte]),
+			StartByte: headingStart(doc.Source, heading),
+			EndByte:   endByte,
 		})
 	}
 
 	return sections
 }
 
-// extractContentBetween extracts content from source between start and end byte positions
-func extractContentBetween(source []byte, start, end int) string {
-	if start >= len(source) {
-		return ""
-	}
-	if end > len(source) {
-		end = len(source)
-	}
+// headingStart returns the offset of the start of a heading's first line
+func headingStart(source []byte, heading Heading) int {
+	return lineStart(source, heading.Node.Lines().At(0).Start)
+}
 
-	// Find the start of the next line after the heading
-	scanner := bufio.NewScanner(bytes.NewReader(source[start:end]))
+// headingEnd returns the offset just past a heading's last line, including the
+// underline of a setext heading
+func headingEnd(source []byte, heading Heading) int {
+	lines := heading.Node.Lines()
+	last := lines.At(lines.Len() - 1)
 
-	var lines []string
-	firstLine := true
-	for scanner.Scan() {
-		line := scanner.Text()
+	// The segment may or may not include its newline, so find the end of the
+	// line holding its last character
+	pos := last.Start
+	if last.Stop > last.Start {
+		pos = last.Stop - 1
+	}
+	end := lineEnd(source, pos)
 
-		// Skip the first line (the heading itself)
-		if firstLine {
-			firstLine = false
-			continue
-		}
+	// ATX headings start with #; otherwise the next line is a setext underline
+	if !atxHeadingRegex.Match(source[headingStart(source, heading):end]) {
+		end = lineEnd(source, end)
+	}
+	return end
+}
 
-		// Stop if we hit another heading (line starting with #)
-		trimmedLine := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmedLine, "#") {
-			break
-		}
+// lineStart returns the offset of the start of the line containing pos
+func lineStart(source []byte, pos int) int {
+	return bytes.LastIndexByte(source[:pos], '\n') + 1
+}
 
-		lines = append(lines, line)
+// lineEnd returns the offset just past the newline ending the line containing
+// pos, or the end of source if that line has no newline
+func lineEnd(source []byte, pos int) int {
+	if pos >= len(source) {
+		return len(source)
 	}
+	i := bytes.IndexByte(source[pos:], '\n')
+	if i == -1 {
+		return len(source)
+	}
+	return pos + i + 1
+}
 
-	return strings.TrimSpace(strings.Join(lines, "\n"))
+// normalizeContent returns section content with line endings normalized to
+// \n and surrounding whitespace trimmed
+func normalizeContent(content []byte) string {
+	return strings.TrimSpace(strings.ReplaceAll(string(content), "\r\n", "\n"))
 }
@@ -319,6 +319,52 @@ Random thoughts
 	}
 }
 
+func TestSortSectionsByHeadingOrder(t *testing.T) {
+	// Document order: Worked On, Meetings, Work Completed
+	content := `# Worked On
+
+Task 3 in progress
+
+# Meetings
+
+Met with team
+
+# Work Completed
+
+Task 1 done
+`
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	headings := []string{"work completed", "worked on"}
+
+	// Document order
+	sections := doc.FindSectionsByHeadings(headings)
+	if len(sections) != 2 {
+		t.Fatalf("expected 2 sections, got %d", len(sections))
+	}
+	if sections[0].Heading.Text != "Worked On" || sections[1].Heading.Text != "Work Completed" {
+		t.Errorf("document order = [%q, %q], want [Worked On, Work Completed]",
+			sections[0].Heading.Text, sections[1].Heading.Text)
+	}
+
+	// Config order
+	sorted := SortSectionsByHeadingOrder(sections, headings)
+	if sorted[0].Heading.Text != "Work Completed" || sorted[1].Heading.Text != "Worked On" {
+		t.Errorf("config order = [%q, %q], want [Work Completed, Worked On]",
+			sorted[0].Heading.Text, sorted[1].Heading.Text)
+	}
+
+	// Original slice is not modified
+	if sections[0].Heading.Text != "Worked On" {
+		t.Error("SortSectionsByHeadingOrder() should not modify its input")
+	}
+}
+
 func TestFindSectionsByHeadingsEmpty(t *testing.T) {
 	content := `# Heading
 
@@ -470,3 +516,258 @@ Text.
 
 	t.Logf("Formatted section content:\n%s", firstSection.Content)
 }
+
+func TestExtractSectionsNested(t *testing.T) {
+	content := `# Daily Log
+
+## Work Completed
+
+Preamble
+
+### Project A
+
+* Task A
+
+#### Detail
+
+* Detail item
+
+### Project B
+
+* Task B
+
+## Notes
+
+Some notes
+`
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{
+			heading: "Work Completed",
+			want:    "Preamble\n\n### Project A\n\n* Task A\n\n#### Detail\n\n* Detail item\n\n### Project B\n\n* Task B",
+		},
+		{
+			heading: "Project A",
+			want:    "* Task A\n\n#### Detail\n\n* Detail item",
+		},
+		{
+			heading: "Project B",
+			want:    "* Task B",
+		},
+		{
+			heading: "Notes",
+			want:    "Some notes",
+		},
+		{
+			heading: "Daily Log",
+			want:    strings.TrimSpace(strings.TrimPrefix(content, "# Daily Log\n")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			section := doc.FindSectionByHeading(tt.heading, WithSubsections())
+			if section == nil {
+				t.Fatalf("section %q not found", tt.heading)
+			}
+			if section.Content != tt.want {
+				t.Errorf("expected content:\n%q\ngot:\n%q", tt.want, section.Content)
+			}
+		})
+	}
+
+	// Without WithSubsections, content still stops at the first subheading
+	section := doc.FindSectionByHeading("Work Completed")
+	if section == nil || section.Content != "Preamble" {
+		t.Errorf("expected only the preamble without WithSubsections, got %+v", section)
+	}
+
+	if got := len(doc.ExtractSections(WithSubsections())); got != 6 {
+		t.Errorf("expected a section for each of the 6 headings, got %d", got)
+	}
+}
+
+func TestExtractSectionsByteRange(t *testing.T) {
+	content := "---\ntitle: Test\n---\n\n# Daily Log\n\n## Work Completed\n\n* Task A\n\n### Project\n\n* Task B\n\n## Notes\n\nNo trailing newline"
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		sections []Section
+		want     map[string]string
+	}{
+		{
+			name:     "simple",
+			sections: doc.ExtractSections(),
+			want: map[string]string{
+				"Daily Log":      "# Daily Log\n\n",
+				"Work Completed": "## Work Completed\n\n* Task A\n\n",
+				"Project":        "### Project\n\n* Task B\n\n",
+				"Notes":          "## Notes\n\nNo trailing newline",
+			},
+		},
+		{
+			name:     "nested",
+			sections: doc.ExtractSections(WithSubsections()),
+			want: map[string]string{
+				"Daily Log":      "# Daily Log\n\n## Work Completed\n\n* Task A\n\n### Project\n\n* Task B\n\n## Notes\n\nNo trailing newline",
+				"Work Completed": "## Work Completed\n\n* Task A\n\n### Project\n\n* Task B\n\n",
+				"Project":        "### Project\n\n* Task B\n\n",
+				"Notes":          "## Notes\n\nNo trailing newline",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.sections) != len(tt.want) {
+				t.Fatalf("expected %d sections, got %d", len(tt.want), len(tt.sections))
+			}
+			for _, section := range tt.sections {
+				got := string(doc.Source[section.StartByte:section.EndByte])
+				if want := tt.want[section.Heading.Text]; got != want {
+					t.Errorf("section %q: expected source range %q, got %q", section.Heading.Text, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFindSectionByHeadingLevel(t *testing.T) {
+	content := `# Notes
+
+Top-level notes
+
+## Working on Today
+
+* Review code
+
+## Notes
+
+Second-level notes
+
+### Working on Today
+
+Prose about today
+`
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		heading     string
+		level       int
+		wantContent string
+		wantFound   bool
+	}{
+		{"level 1", "Notes", 1, "Top-level notes", true},
+		{"level 2", "notes", 2, "Second-level notes", true},
+		{"any level returns first", "Notes", 0, "Top-level notes", true},
+		{"level 2 today", "Working on Today", 2, "* Review code", true},
+		{"level 3 today", "Working on Today", 3, "Prose about today", true},
+		{"no match at level", "Notes", 3, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := doc.FindSectionByHeadingLevel(tt.heading, tt.level)
+			if (section != nil) != tt.wantFound {
+				t.Fatalf("found = %v, want %v", section != nil, tt.wantFound)
+			}
+			if section != nil && section.Content != tt.wantContent {
+				t.Errorf("expected content %q, got %q", tt.wantContent, section.Content)
+			}
+		})
+	}
+
+	// FindSectionByHeading keeps matching at any level
+	if section := doc.FindSectionByHeading("Working on Today"); section == nil || section.Heading.Level != 2 {
+		t.Errorf("expected FindSectionByHeading to return the first match, got %+v", section)
+	}
+}
+
+func TestExtractSectionsSetextHeadings(t *testing.T) {
+	content := `Daily Log
+=========
+
+Intro text
+
+Work Completed
+--------------
+
+* Task A
+#blocked on review
+
+## Notes
+
+#hashtag
+========
+
+Tagged text
+
+Goals
+-----
+
+* [ ] Goal
+`
+
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	want := []struct {
+		heading string
+		level   int
+		content string
+	}{
+		{"Daily Log", 1, "Intro text"},
+		{"Work Completed", 2, "* Task A\n#blocked on review"},
+		{"Notes", 2, ""},
+		{"#hashtag", 1, "Tagged text"},
+		{"Goals", 2, "* [ ] Goal"},
+	}
+
+	sections := doc.ExtractSections()
+	if len(sections) != len(want) {
+		t.Fatalf("expected %d sections, got %d", len(want), len(sections))
+	}
+	for i, w := range want {
+		s := sections[i]
+		if s.Heading.Text != w.heading || s.Heading.Level != w.level {
+			t.Errorf("section %d: expected heading %q (level %d), got %q (level %d)", i, w.heading, w.level, s.Heading.Text, s.Heading.Level)
+		}
+		if s.Content != w.content {
+			t.Errorf("section %q: expected content %q, got %q", w.heading, w.content, s.Content)
+		}
+	}
+
+	// Nested sections include setext subsections, underlines and all
+	nested := doc.FindSectionByHeading("Daily Log", WithSubsections())
+	if nested == nil {
+		t.Fatal("expected to find Daily Log section")
+	}
+	wantNested := "Intro text\n\nWork Completed\n--------------\n\n* Task A\n#blocked on review\n\n## Notes"
+	if nested.Content != wantNested {
+		t.Errorf("expected nested content %q, got %q", wantNested, nested.Content)
+	}
+}
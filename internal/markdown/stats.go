@@ -0,0 +1,92 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// markupPrefixRegex matches heading markers, list markers and checkboxes at
+// the start of a line, which aren't counted as words
+var markupPrefixRegex = regexp.MustCompile(`^\s*(?:#{1,6}\s+|(?:[-*+]|\d+\.)\s+(?:\[[ xX]*\]\s*)?)`)
+
+// DocumentStats holds simple counts describing a note's body (frontmatter
+// is not counted)
+type DocumentStats struct {
+	Words    int
+	Lines    int // Non-blank lines
+	Headings int
+	Links    int
+
+	// Tasks is the number of checkbox items, and CompletedTasks the number of
+	// those that are checked
+	Tasks          int
+	CompletedTasks int
+}
+
+// CompletionRatio returns the fraction of checkbox items that are checked,
+// or 0 if there are none
+func (s DocumentStats) CompletionRatio() float64 {
+	if s.Tasks == 0 {
+		return 0
+	}
+	return float64(s.CompletedTasks) / float64(s.Tasks)
+}
+
+// Stats counts the words (excluding heading and list markup), lines, headings, links and checkbox items in the
+// document, ignoring any frontmatter
+func (doc *Document) Stats() DocumentStats {
+	body := string(doc.Body())
+
+	stats := DocumentStats{
+		Headings: len(doc.GetHeadings()),
+		Links:    len(doc.ExtractLinks()),
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		stats.Lines++
+		stats.Words += countWords(markupPrefixRegex.ReplaceAllString(line, ""))
+	}
+
+	for _, item := range ParseGoalItems(body) {
+		if item.HasCheckbox {
+			stats.Tasks++
+			if item.Checked {
+				stats.CompletedTasks++
+			}
+		}
+	}
+
+	return stats
+}
+
+// countWords counts the whitespace-separated words in text that contain a
+// letter or digit, so stray markup like "-" or "|" isn't counted
+func countWords(text string) int {
+	count := 0
+	for _, field := range strings.Fields(text) {
+		if strings.IndexFunc(field, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		}) != -1 {
+			count++
+		}
+	}
+	return count
+}
+
+// Body returns the document source without its frontmatter
+func (doc *Document) Body() []byte {
+	end, _, err := extractFrontmatter(doc.Source)
+	if err != nil {
+		// No (complete) frontmatter
+		return doc.Source
+	}
+	// The closing delimiter may be the last line, without a newline
+	if end > len(doc.Source) {
+		end = len(doc.Source)
+	}
+	return doc.Source[end:]
+}
@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestDocumentStats(t *testing.T) {
+	content := `---
+title: daily-log-2025-01-07
+tags: ["daily", "journal"]
+---
+
+# Daily Log
+
+## Goals of the Day
+
+* [x] Ship feature
+* [ ] Write docs
+- [X] Review PR
+
+## Links
+
+* [Yesterday](2025-01-06) and [[2025-01-08|Tomorrow]]
+* https://example.com
+`
+
+	p := NewParser()
+	doc, err := p.Parse("journal/2025-01-07.md", []byte(content))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	want := DocumentStats{
+		Words:          17,
+		Lines:          8,
+		Headings:       3,
+		Links:          2,
+		Tasks:          3,
+		CompletedTasks: 2,
+	}
+
+	got := doc.Stats()
+	if got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if ratio := got.CompletionRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("CompletionRatio() = %v, want 2/3", ratio)
+	}
+}
+
+func TestDocumentStats_NoFrontmatterOrTasks(t *testing.T) {
+	p := NewParser()
+	doc, err := p.Parse("test.md", []byte("# Title\n\nJust some words here.\n"))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	want := DocumentStats{Words: 5, Lines: 2, Headings: 1}
+	got := doc.Stats()
+	if got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if ratio := got.CompletionRatio(); ratio != 0 {
+		t.Errorf("CompletionRatio() = %v, want 0", ratio)
+	}
+}
@@ -0,0 +1,87 @@
+package notes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rdark/za/internal/markdown"
+)
+
+// DateMismatch describes a note whose frontmatter date disagrees with the
+// date in its filename
+type DateMismatch struct {
+	// Path is the note's path
+	Path string
+
+	// FilenameDate is the date parsed from the filename
+	FilenameDate time.Time
+
+	// FrontmatterDate is the frontmatter "date" value as written
+	FrontmatterDate string
+}
+
+// String describes the mismatch
+func (m DateMismatch) String() string {
+	return fmt.Sprintf("frontmatter date %q does not match filename date %s",
+		m.FrontmatterDate, m.FilenameDate.Format(DateFormat))
+}
+
+// VerifyDateConsistency compares the date in a note's filename with the
+// "date" field of its frontmatter, returning a DateMismatch if they name
+// different days (or the field isn't a date). It returns nil if they agree,
+// or if the note has no date field.
+func VerifyDateConsistency(path string, opts ...Option) (*DateMismatch, error) {
+	fileDate, err := ParseDateFromFilename(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := markdown.NewParser().ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompareFrontmatterDate(doc, fileDate), nil
+}
+
+// VerifyDateConsistencyAll runs VerifyDateConsistency on each note, returning
+// every mismatch found
+func VerifyDateConsistencyAll(paths []string, opts ...Option) ([]DateMismatch, error) {
+	var mismatches []DateMismatch
+	for _, path := range paths {
+		mismatch, err := VerifyDateConsistency(path, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if mismatch != nil {
+			mismatches = append(mismatches, *mismatch)
+		}
+	}
+	return mismatches, nil
+}
+
+// CompareFrontmatterDate is like VerifyDateConsistency for a note that has
+// already been parsed, given the date from its filename
+func CompareFrontmatterDate(doc *markdown.Document, fileDate time.Time) *DateMismatch {
+	raw, ok := doc.GetMetadata("date")
+	if !ok || raw == nil {
+		return nil
+	}
+
+	fmDate, ok := doc.GetMetadataTime("date")
+	if ok && fmDate.Year() == fileDate.Year() && fmDate.YearDay() == fileDate.YearDay() {
+		return nil
+	}
+
+	value := strings.TrimSpace(fmt.Sprint(raw))
+	if t, isTime := raw.(time.Time); isTime {
+		value = t.Format(time.RFC3339)
+	}
+
+	return &DateMismatch{
+		Path:            doc.FilePath,
+		FilenameDate:    fileDate,
+		FrontmatterDate: value,
+	}
+}
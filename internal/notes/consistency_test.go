@@ -0,0 +1,106 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVerifyDateConsistency(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantMismatch bool
+		wantValue    string
+	}{
+		{
+			name:    "matching date",
+			content: "---\ndate: 2025-01-06\n---\n# Log\n",
+		},
+		{
+			name:    "matching quoted date",
+			content: "---\ndate: \"2025-01-06\"\n---\n# Log\n",
+		},
+		{
+			name:    "matching date with time",
+			content: "---\ndate: 2025-01-06T09:30:00Z\n---\n# Log\n",
+		},
+		{
+			name:    "matching long date",
+			content: "---\ndate: January 6, 2025\n---\n# Log\n",
+		},
+		{
+			name:    "no date field",
+			content: "---\ntitle: Log\n---\n# Log\n",
+		},
+		{
+			name:    "no frontmatter",
+			content: "# Log\n",
+		},
+		{
+			name:         "different date",
+			content:      "---\ndate: 2025-01-05\n---\n# Log\n",
+			wantMismatch: true,
+			wantValue:    "2025-01-05",
+		},
+		{
+			name:         "not a date",
+			content:      "---\ndate: Monday\n---\n# Log\n",
+			wantMismatch: true,
+			wantValue:    "Monday",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "2025-01-06.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write note: %v", err)
+			}
+
+			mismatch, err := VerifyDateConsistency(path)
+			if err != nil {
+				t.Fatalf("VerifyDateConsistency() error = %v", err)
+			}
+			if (mismatch != nil) != tt.wantMismatch {
+				t.Fatalf("VerifyDateConsistency() = %+v, want mismatch %v", mismatch, tt.wantMismatch)
+			}
+			if mismatch == nil {
+				return
+			}
+			if mismatch.Path != path || mismatch.FrontmatterDate != tt.wantValue ||
+				mismatch.FilenameDate.Format(DateFormat) != "2025-01-06" {
+				t.Errorf("VerifyDateConsistency() = %+v", mismatch)
+			}
+		})
+	}
+}
+
+func TestVerifyDateConsistencyAll(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"2025-01-06.md": "---\ndate: 2025-01-06\n---\n",
+		"2025-01-07.md": "---\ndate: 2025-01-06\n---\n",
+		"2025-01-08.md": "# No frontmatter\n",
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write note: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	mismatches, err := VerifyDateConsistencyAll(paths)
+	if err != nil {
+		t.Fatalf("VerifyDateConsistencyAll() error = %v", err)
+	}
+	if len(mismatches) != 1 || filepath.Base(mismatches[0].Path) != "2025-01-07.md" {
+		t.Errorf("VerifyDateConsistencyAll() = %+v, want only 2025-01-07.md", mismatches)
+	}
+
+	if _, err := VerifyDateConsistencyAll([]string{filepath.Join(dir, "notes.md")}); err == nil {
+		t.Error("expected error for a filename without a date")
+	}
+}
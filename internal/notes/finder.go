@@ -1,17 +1,261 @@
 package notes
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/rdark/za/internal/markdown"
 )
 
 const (
-	// DateFormat is the format used for note filenames (YYYY-MM-DD)
+	// DateFormat is the default format used for note filenames (YYYY-MM-DD)
 	DateFormat = "2006-01-02"
+
+	// MonthFormat is the format used for year-month arguments (YYYY-MM)
+	MonthFormat = "2006-01"
+)
+
+// Errors returned by the finder, wrapped with details; test for them with
+// errors.Is
+var (
+	// ErrNoteNotFound means no note exists for the date or search window
+	ErrNoteNotFound = errors.New("note not found")
+
+	// ErrInvalidNoteType means the note type is neither journal nor standup
+	ErrInvalidNoteType = errors.New("invalid note type")
+
+	// ErrInvalidSearchWindow means the search window isn't a positive
+	// number of days
+	ErrInvalidSearchWindow = errors.New("invalid search window")
+
+	// ErrDirNotExist means the directory to search doesn't exist
+	ErrDirNotExist = errors.New("directory does not exist")
 )
 
+// Option configures optional finder behaviour
+type Option func(*finderOptions)
+
+// finderOptions holds the optional settings applied by Option values
+type finderOptions struct {
+	skipEmptyNotes bool
+	draftField     string
+	filenameFormat string
+	pathLayout     string
+	cache          *IndexCache
+}
+
+// WithSkipEmptyNotes treats empty or frontmatter-only notes as not present,
+// so searches skip over placeholder files
+func WithSkipEmptyNotes(skip bool) Option {
+	return func(o *finderOptions) {
+		o.skipEmptyNotes = skip
+	}
+}
+
+// WithSkipDrafts treats notes whose frontmatter field is true (e.g.
+// "draft: true") as not present, so searches fall back to the note before.
+// An empty field skips nothing.
+func WithSkipDrafts(field string) Option {
+	return func(o *finderOptions) {
+		o.draftField = field
+	}
+}
+
+// WithFilenameFormat sets the Go time layout used for note filenames
+// (without the .md extension), e.g. "20060102" or "2006-01-02-daily".
+// An empty layout means DateFormat.
+func WithFilenameFormat(layout string) Option {
+	return func(o *finderOptions) {
+		o.filenameFormat = layout
+	}
+}
+
+// WithPathLayout sets the subdirectory layout notes are stored under, using
+// {year}, {month} and {day} placeholders (e.g. "{year}/{month}" for
+// journal/2025/01/2025-01-06.md). An empty layout means notes live directly
+// in the directory.
+func WithPathLayout(layout string) Option {
+	return func(o *finderOptions) {
+		o.pathLayout = layout
+	}
+}
+
+// WithIndexCache shares directory listings between searches through cache,
+// so a directory is only read once
+func WithIndexCache(cache *IndexCache) Option {
+	return func(o *finderOptions) {
+		o.cache = cache
+	}
+}
+
+// IndexCache holds the notes found in each directory searched with
+// WithIndexCache. It is safe for concurrent use. Notes created or removed
+// after a directory was first read are not seen, so a cache should only live
+// as long as one batch of work.
+type IndexCache struct {
+	mu      sync.Mutex
+	indexes map[indexKey]noteIndex
+}
+
+// indexKey identifies a directory listing; notes are indexed differently
+// depending on the filename format and path layout
+type indexKey struct {
+	dir            string
+	filenameFormat string
+	pathLayout     string
+}
+
+// NewIndexCache creates an empty IndexCache
+func NewIndexCache() *IndexCache {
+	return &IndexCache{indexes: make(map[indexKey]noteIndex)}
+}
+
+// newFinderOptions applies the given options over the defaults
+func newFinderOptions(opts []Option) finderOptions {
+	var o finderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// filename returns the note filename for date in the configured format
+func (o finderOptions) filename(date time.Time) string {
+	layout := o.filenameFormat
+	if layout == "" {
+		layout = DateFormat
+	}
+	return date.Format(layout) + ".md"
+}
+
+// subdir returns the subdirectory for date according to the path layout
+func (o finderOptions) subdir(date time.Time) string {
+	if o.pathLayout == "" {
+		return ""
+	}
+	return filepath.FromSlash(strings.NewReplacer(
+		"{year}", date.Format("2006"),
+		"{month}", date.Format("01"),
+		"{day}", date.Format("02"),
+	).Replace(o.pathLayout))
+}
+
+// noteIndex maps dates (in DateFormat) to the paths of notes in a directory
+type noteIndex map[string]string
+
+// readNoteIndex lists dir once and indexes every note whose filename matches
+// the configured format for its date, so searches don't need an os.Stat per
+// day. With a path layout, notes are only indexed if they are in the
+// subdirectory the layout gives for their date. A missing directory yields an
+// empty index. With an IndexCache, each directory is only listed once.
+func (o finderOptions) readNoteIndex(dir string) (noteIndex, error) {
+	if o.cache == nil {
+		return o.listNoteIndex(dir)
+	}
+
+	key := indexKey{dir: dir, filenameFormat: o.filenameFormat, pathLayout: o.pathLayout}
+	o.cache.mu.Lock()
+	defer o.cache.mu.Unlock()
+	if index, ok := o.cache.indexes[key]; ok {
+		return index, nil
+	}
+	index, err := o.listNoteIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	o.cache.indexes[key] = index
+	return index, nil
+}
+
+// listNoteIndex reads dir and builds its note index
+func (o finderOptions) listNoteIndex(dir string) (noteIndex, error) {
+	index := noteIndex{}
+
+	if o.pathLayout == "" {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return index, nil
+			}
+			return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			o.indexEntry(index, dir, "", entry)
+		}
+		return index, nil
+	}
+
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		rel, err := filepath.Rel(dir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		o.indexEntry(index, dir, rel, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	return index, nil
+}
+
+// indexEntry adds entry (found in the rel subdirectory of dir) to the index if
+// it is a note stored where the configured filename and layout expect it
+func (o finderOptions) indexEntry(index noteIndex, dir, rel string, entry os.DirEntry) {
+	if entry.IsDir() {
+		return
+	}
+
+	name := entry.Name()
+	date, err := ParseDateFromFilename(name, WithFilenameFormat(o.filenameFormat))
+	if err != nil || name != o.filename(date) {
+		return
+	}
+	if rel == "." {
+		rel = ""
+	}
+	if rel != o.subdir(date) {
+		return
+	}
+
+	path := filepath.Join(dir, rel, name)
+	// Symlinks must point at a regular file
+	if entry.Type()&os.ModeSymlink != 0 && !fileExists(path) {
+		return
+	}
+
+	index[date.Format(DateFormat)] = path
+}
+
+// lookup returns the path of the note for date, honouring the options
+func (o finderOptions) lookup(index noteIndex, date time.Time) (string, bool) {
+	path, ok := index[date.Format(DateFormat)]
+	if !ok {
+		return "", false
+	}
+	if o.skipEmptyNotes && isEmptyNote(path) {
+		return "", false
+	}
+	if o.draftField != "" && isDraftNote(path, o.draftField) {
+		return "", false
+	}
+	return path, true
+}
+
 // FindNoteByDate finds a note file for the given date, with fallback to previous dates
 // within the search window if the exact date doesn't exist.
 //
@@ -20,44 +264,198 @@ const (
 //   - noteType: the type of note (journal or standup)
 //   - dir: the directory to search in
 //   - searchWindowDays: how many days back to search if exact date not found
+//   - opts: optional finder behaviour (e.g. WithSkipEmptyNotes)
 //
 // Returns:
 //   - the absolute path to the found note file
 //   - error if no note found within search window or other errors
-func FindNoteByDate(date time.Time, noteType NoteType, dir string, searchWindowDays int) (string, error) {
+func FindNoteByDate(date time.Time, noteType NoteType, dir string, searchWindowDays int, opts ...Option) (string, error) {
 	if !noteType.IsValid() {
-		return "", fmt.Errorf("invalid note type: %s", noteType)
+		return "", fmt.Errorf("%w: %s", ErrInvalidNoteType, noteType)
 	}
 
 	if searchWindowDays <= 0 {
-		return "", fmt.Errorf("searchWindowDays must be positive, got %d", searchWindowDays)
+		return "", fmt.Errorf("%w: searchWindowDays must be positive, got %d", ErrInvalidSearchWindow, searchWindowDays)
 	}
 
 	// Ensure directory exists
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return "", fmt.Errorf("directory does not exist: %s", dir)
+		return "", fmt.Errorf("%w: %s", ErrDirNotExist, dir)
+	}
+
+	o := newFinderOptions(opts)
+	index, err := o.readNoteIndex(dir)
+	if err != nil {
+		return "", err
 	}
 
 	// Try exact date first
-	exactPath := filepath.Join(dir, date.Format(DateFormat)+".md")
-	if fileExists(exactPath) {
+	if exactPath, ok := o.lookup(index, date); ok {
 		return exactPath, nil
 	}
 
 	// Fall back to searching previous dates within window
 	for i := 1; i <= searchWindowDays; i++ {
-		previousDate := date.AddDate(0, 0, -i)
-		previousPath := filepath.Join(dir, previousDate.Format(DateFormat)+".md")
+		if previousPath, ok := o.lookup(index, date.AddDate(0, 0, -i)); ok {
+			return previousPath, nil
+		}
+	}
 
-		if fileExists(previousPath) {
+	// No note found within search window
+	return "", fmt.Errorf(
+		"%s %w for %s or within %d days before",
+		noteType,
+		ErrNoteNotFound,
+		date.Format(DateFormat),
+		searchWindowDays,
+	)
+}
+
+// FindNoteByDateMulti is like FindNoteByDate but searches several
+// directories, listed in priority order. The most recent note on or before
+// date wins; if more than one directory has a note for that day, the earlier
+// directory wins. Directories that don't exist are skipped.
+func FindNoteByDateMulti(date time.Time, noteType NoteType, dirs []string, searchWindowDays int, opts ...Option) (string, error) {
+	if len(dirs) == 0 {
+		return "", fmt.Errorf("no directories to search")
+	}
+
+	path, err := findMulti(dirs, opts, time.Time.After, func(dir string) (string, error) {
+		return FindNoteByDate(date, noteType, dir, searchWindowDays, opts...)
+	})
+	if err != nil && len(dirs) > 1 {
+		return "", fmt.Errorf(
+			"%s %w for %s or within %d days before in any of %d directories",
+			noteType,
+			ErrNoteNotFound,
+			date.Format(DateFormat),
+			searchWindowDays,
+			len(dirs),
+		)
+	}
+	return path, err
+}
+
+// FindPreviousNoteMulti is like FindPreviousNote but searches several
+// directories, listed in priority order. The most recent note before date
+// wins; if more than one directory has a note for that day, the earlier
+// directory wins.
+func FindPreviousNoteMulti(date time.Time, noteType NoteType, dirs []string, searchWindowDays int, opts ...Option) (string, error) {
+	if len(dirs) == 0 {
+		return "", fmt.Errorf("no directories to search")
+	}
+
+	path, err := findMulti(dirs, opts, time.Time.After, func(dir string) (string, error) {
+		return FindPreviousNote(date, noteType, dir, searchWindowDays, opts...)
+	})
+	if err != nil && len(dirs) > 1 {
+		return "", fmt.Errorf(
+			"%s %w before %s within %d days in any of %d directories",
+			noteType,
+			ErrNoteNotFound,
+			date.Format(DateFormat),
+			searchWindowDays,
+			len(dirs),
+		)
+	}
+	return path, err
+}
+
+// FindNextNoteMulti is like FindNextNote but searches several directories,
+// listed in priority order. The earliest note after date wins; if more than
+// one directory has a note for that day, the earlier directory wins.
+func FindNextNoteMulti(date time.Time, noteType NoteType, dirs []string, searchWindowDays int, opts ...Option) (string, error) {
+	if len(dirs) == 0 {
+		return "", fmt.Errorf("no directories to search")
+	}
+
+	path, err := findMulti(dirs, opts, time.Time.Before, func(dir string) (string, error) {
+		return FindNextNote(date, noteType, dir, searchWindowDays, opts...)
+	})
+	if err != nil && len(dirs) > 1 {
+		return "", fmt.Errorf(
+			"%s %w after %s within %d days in any of %d directories",
+			noteType,
+			ErrNoteNotFound,
+			date.Format(DateFormat),
+			searchWindowDays,
+			len(dirs),
+		)
+	}
+	return path, err
+}
+
+// findMulti calls find for each of dirs in turn and returns the path whose
+// date is better than the others', keeping the first found on a tie. If no
+// directory has a note, it returns the last error.
+func findMulti(dirs []string, opts []Option, better func(a, b time.Time) bool, find func(dir string) (string, error)) (string, error) {
+	var bestPath string
+	var bestDate time.Time
+	var lastErr error
+	for _, dir := range dirs {
+		path, err := find(dir)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		found, err := ParseDateFromFilename(path, opts...)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if bestPath == "" || better(found, bestDate) {
+			bestPath, bestDate = path, found
+		}
+	}
+
+	if bestPath == "" {
+		return "", lastErr
+	}
+	return bestPath, nil
+}
+
+// FindPreviousNote finds the previous note file before the given date
+// within the search window.
+//
+// Parameters:
+//   - date: the starting date
+//   - noteType: the type of note (journal or standup)
+//   - dir: the directory to search in
+//   - searchWindowDays: how many days backward to search
+//   - opts: optional finder behaviour (e.g. WithSkipEmptyNotes)
+//
+// Returns:
+//   - the absolute path to the found note file
+//   - error if no note found within search window
+func FindPreviousNote(date time.Time, noteType NoteType, dir string, searchWindowDays int, opts ...Option) (string, error) {
+	if !noteType.IsValid() {
+		return "", fmt.Errorf("%w: %s", ErrInvalidNoteType, noteType)
+	}
+
+	if searchWindowDays <= 0 {
+		return "", fmt.Errorf("%w: searchWindowDays must be positive, got %d", ErrInvalidSearchWindow, searchWindowDays)
+	}
+
+	o := newFinderOptions(opts)
+	index, err := o.readNoteIndex(dir)
+	if err != nil {
+		return "", err
+	}
+
+	// Search backward from the previous day
+	for i := 1; i <= searchWindowDays; i++ {
+		if previousPath, ok := o.lookup(index, date.AddDate(0, 0, -i)); ok {
 			return previousPath, nil
 		}
 	}
 
 	// No note found within search window
 	return "", fmt.Errorf(
-		"no %s note found for %s or within %d days before",
+		"%s %w before %s within %d days",
 		noteType,
+		ErrNoteNotFound,
 		date.Format(DateFormat),
 		searchWindowDays,
 	)
@@ -71,43 +469,180 @@ func FindNoteByDate(date time.Time, noteType NoteType, dir string, searchWindowD
 //   - noteType: the type of note (journal or standup)
 //   - dir: the directory to search in
 //   - searchWindowDays: how many days forward to search
+//   - opts: optional finder behaviour (e.g. WithSkipEmptyNotes)
 //
 // Returns:
 //   - the absolute path to the found note file
 //   - error if no note found within search window
-func FindNextNote(date time.Time, noteType NoteType, dir string, searchWindowDays int) (string, error) {
+func FindNextNote(date time.Time, noteType NoteType, dir string, searchWindowDays int, opts ...Option) (string, error) {
 	if !noteType.IsValid() {
-		return "", fmt.Errorf("invalid note type: %s", noteType)
+		return "", fmt.Errorf("%w: %s", ErrInvalidNoteType, noteType)
 	}
 
 	if searchWindowDays <= 0 {
-		return "", fmt.Errorf("searchWindowDays must be positive, got %d", searchWindowDays)
+		return "", fmt.Errorf("%w: searchWindowDays must be positive, got %d", ErrInvalidSearchWindow, searchWindowDays)
+	}
+
+	o := newFinderOptions(opts)
+	index, err := o.readNoteIndex(dir)
+	if err != nil {
+		return "", err
 	}
 
 	// Search forward from the next day
 	for i := 1; i <= searchWindowDays; i++ {
-		nextDate := date.AddDate(0, 0, i)
-		nextPath := filepath.Join(dir, nextDate.Format(DateFormat)+".md")
-
-		if fileExists(nextPath) {
+		if nextPath, ok := o.lookup(index, date.AddDate(0, 0, i)); ok {
 			return nextPath, nil
 		}
 	}
 
 	// No note found within search window
 	return "", fmt.Errorf(
-		"no %s note found after %s within %d days",
+		"%s %w after %s within %d days",
 		noteType,
+		ErrNoteNotFound,
 		date.Format(DateFormat),
 		searchWindowDays,
 	)
 }
 
-// ParseDateFromFilename extracts the date from a note filename
-// Expected format: YYYY-MM-DD.md
-func ParseDateFromFilename(filename string) (time.Time, error) {
-	// Remove extension
+// FindNearestNote finds the note closest to the given date within the search
+// window, in either direction. The exact date wins if it exists; otherwise the
+// search expands outward (date-1, date+1, date-2, date+2, ...) so that when an
+// earlier and a later note are equally distant, the earlier one is returned.
+//
+// Parameters:
+//   - date: the target date
+//   - noteType: the type of note (journal or standup)
+//   - dir: the directory to search in
+//   - searchWindowDays: how many days to search in each direction
+//   - opts: optional finder behaviour (e.g. WithSkipEmptyNotes)
+//
+// Returns:
+//   - the absolute path to the found note file
+//   - error if no note found within search window
+func FindNearestNote(date time.Time, noteType NoteType, dir string, searchWindowDays int, opts ...Option) (string, error) {
+	if !noteType.IsValid() {
+		return "", fmt.Errorf("%w: %s", ErrInvalidNoteType, noteType)
+	}
+
+	if searchWindowDays <= 0 {
+		return "", fmt.Errorf("%w: searchWindowDays must be positive, got %d", ErrInvalidSearchWindow, searchWindowDays)
+	}
+
+	o := newFinderOptions(opts)
+	index, err := o.readNoteIndex(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if exactPath, ok := o.lookup(index, date); ok {
+		return exactPath, nil
+	}
+
+	// Expand outward, backward first so it wins ties
+	for i := 1; i <= searchWindowDays; i++ {
+		if previousPath, ok := o.lookup(index, date.AddDate(0, 0, -i)); ok {
+			return previousPath, nil
+		}
+		if nextPath, ok := o.lookup(index, date.AddDate(0, 0, i)); ok {
+			return nextPath, nil
+		}
+	}
+
+	// No note found within search window
+	return "", fmt.Errorf(
+		"%s %w within %d days of %s",
+		noteType,
+		ErrNoteNotFound,
+		searchWindowDays,
+		date.Format(DateFormat),
+	)
+}
+
+// FindNotesInRange finds all notes between start and end (inclusive),
+// returning their paths in date order. Days without a note are skipped.
+func FindNotesInRange(start, end time.Time, noteType NoteType, dir string, opts ...Option) ([]string, error) {
+	if !noteType.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidNoteType, noteType)
+	}
+
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date %s is before start date %s", end.Format(DateFormat), start.Format(DateFormat))
+	}
+
+	// Ensure directory exists
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrDirNotExist, dir)
+	}
+
+	o := newFinderOptions(opts)
+	index, err := o.readNoteIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if path, ok := o.lookup(index, date); ok {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, nil
+}
+
+// FindNotesInRangeMulti is like FindNotesInRange but searches several
+// directories, listed in priority order. Paths are returned in date order;
+// notes for the same day are ordered by directory priority. Directories that
+// don't exist are skipped unless none of them exist.
+func FindNotesInRangeMulti(start, end time.Time, noteType NoteType, dirs []string, opts ...Option) ([]string, error) {
+	var paths []string
+	var lastErr error
+	searched := 0
+	for _, dir := range dirs {
+		found, err := FindNotesInRange(start, end, noteType, dir, opts...)
+		if err != nil {
+			if _, statErr := os.Stat(dir); os.IsNotExist(statErr) {
+				lastErr = err
+				continue
+			}
+			return nil, err
+		}
+		searched++
+		paths = append(paths, found...)
+	}
+
+	if searched == 0 {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no directories to search")
+		}
+		return nil, lastErr
+	}
+
+	// Each directory's paths are already in date order
+	sort.SliceStable(paths, func(i, j int) bool {
+		di, _ := ParseDateFromFilename(paths[i], opts...)
+		dj, _ := ParseDateFromFilename(paths[j], opts...)
+		return di.Before(dj)
+	})
+
+	return paths, nil
+}
+
+// ParseDateFromFilename extracts the date from a note filename.
+// The configured filename format (see WithFilenameFormat) is tried first,
+// falling back to a YYYY-MM-DD prefix.
+func ParseDateFromFilename(filename string, opts ...Option) (time.Time, error) {
 	base := filepath.Base(filename)
+
+	// Try the configured format against the name without extension
+	if o := newFinderOptions(opts); o.filenameFormat != "" {
+		if date, err := time.Parse(o.filenameFormat, strings.TrimSuffix(base, ".md")); err == nil {
+			return date, nil
+		}
+	}
+
 	if len(base) < 10 {
 		return time.Time{}, fmt.Errorf("filename too short: %s", filename)
 	}
@@ -122,9 +657,17 @@ func ParseDateFromFilename(filename string) (time.Time, error) {
 	return date, nil
 }
 
-// GenerateFilename generates a filename for a note of the given date
-func GenerateFilename(date time.Time) string {
-	return date.Format(DateFormat) + ".md"
+// GenerateFilename generates a filename for a note of the given date,
+// honouring WithFilenameFormat
+func GenerateFilename(date time.Time, opts ...Option) string {
+	return newFinderOptions(opts).filename(date)
+}
+
+// NotePath returns the path a note for date is expected at within dir,
+// honouring WithFilenameFormat and WithPathLayout
+func NotePath(dir string, date time.Time, opts ...Option) string {
+	o := newFinderOptions(opts)
+	return filepath.Join(dir, o.subdir(date), o.filename(date))
 }
 
 // fileExists checks if a file exists and is not a directory
@@ -135,3 +678,32 @@ func fileExists(path string) bool {
 	}
 	return !info.IsDir()
 }
+
+// isEmptyNote checks if a note has no content beyond optional YAML frontmatter
+func isEmptyNote(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	content = bytes.TrimSpace(content)
+	if bytes.HasPrefix(content, []byte("---")) {
+		// Skip past the closing frontmatter delimiter, if any
+		rest := content[3:]
+		if end := bytes.Index(rest, []byte("\n---")); end != -1 {
+			content = bytes.TrimSpace(rest[end+4:])
+		}
+	}
+
+	return len(content) == 0
+}
+
+// isDraftNote checks if a note's frontmatter field is true
+func isDraftNote(path, field string) bool {
+	doc, err := markdown.NewParser().ParseFile(path)
+	if err != nil {
+		return false
+	}
+	draft, _ := doc.GetMetadataBool(field)
+	return draft
+}
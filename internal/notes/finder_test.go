@@ -1,8 +1,10 @@
 package notes
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -82,6 +84,88 @@ func TestGenerateFilename(t *testing.T) {
 	}
 }
 
+func TestParseDateFromFilenameWithFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		filename string
+		want     string
+		wantErr  bool
+	}{
+		{"compact format", "20060102", "20250106.md", "2025-01-06", false},
+		{"suffixed format", "2006-01-02-daily", "/notes/2025-01-07-daily.md", "2025-01-07", false},
+		{"falls back to legacy prefix", "20060102", "2025-01-08.md", "2025-01-08", false},
+		{"no match", "20060102", "notes.md", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateFromFilename(tt.filename, WithFilenameFormat(tt.format))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDateFromFilename() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Format(DateFormat) != tt.want {
+				t.Errorf("ParseDateFromFilename() = %v, want %v", got.Format(DateFormat), tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateFilenameWithFormat(t *testing.T) {
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	if got := GenerateFilename(date, WithFilenameFormat("20060102")); got != "20250106.md" {
+		t.Errorf("GenerateFilename() = %v, want %v", got, "20250106.md")
+	}
+	if got := GenerateFilename(date, WithFilenameFormat("")); got != "2025-01-06.md" {
+		t.Errorf("GenerateFilename() with empty format = %v, want %v", got, "2025-01-06.md")
+	}
+}
+
+func TestFindersWithFilenameFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFiles := []string{"20250106.md", "20250107.md", "20250110.md"}
+	for _, name := range testFiles {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("test"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	opt := WithFilenameFormat("20060102")
+
+	// Fallback over the 01-08/01-09 gap
+	path, err := FindNoteByDate(time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), NoteTypeJournal, tmpDir, 30, opt)
+	if err != nil {
+		t.Fatalf("FindNoteByDate() failed: %v", err)
+	}
+	if filepath.Base(path) != "20250107.md" {
+		t.Errorf("FindNoteByDate() = %s, want 20250107.md", filepath.Base(path))
+	}
+
+	path, err = FindNextNote(time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), NoteTypeJournal, tmpDir, 30, opt)
+	if err != nil {
+		t.Fatalf("FindNextNote() failed: %v", err)
+	}
+	if filepath.Base(path) != "20250110.md" {
+		t.Errorf("FindNextNote() = %s, want 20250110.md", filepath.Base(path))
+	}
+
+	path, err = FindPreviousNote(time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), NoteTypeJournal, tmpDir, 30, opt)
+	if err != nil {
+		t.Fatalf("FindPreviousNote() failed: %v", err)
+	}
+	if filepath.Base(path) != "20250106.md" {
+		t.Errorf("FindPreviousNote() = %s, want 20250106.md", filepath.Base(path))
+	}
+
+	// The default format doesn't see these files
+	if _, err := FindNoteByDate(time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), NoteTypeJournal, tmpDir, 30); err == nil {
+		t.Error("FindNoteByDate() without format should not find compact filenames")
+	}
+}
+
 func TestFindNoteByDateExact(t *testing.T) {
 	// Create temp directory with test files
 	tmpDir := t.TempDir()
@@ -172,16 +256,16 @@ func TestFindNoteByDateNotFound(t *testing.T) {
 	// Try to find a date too far in the future (outside search window)
 	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
 	_, err := FindNoteByDate(date, NoteTypeJournal, tmpDir, 30)
-	if err == nil {
-		t.Error("FindNoteByDate() should fail when no note found within window")
+	if !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("FindNoteByDate() error = %v, want ErrNoteNotFound", err)
 	}
 }
 
 func TestFindNoteByDateInvalidDirectory(t *testing.T) {
 	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
 	_, err := FindNoteByDate(date, NoteTypeJournal, "/nonexistent/directory", 30)
-	if err == nil {
-		t.Error("FindNoteByDate() should fail for non-existent directory")
+	if !errors.Is(err, ErrDirNotExist) {
+		t.Errorf("FindNoteByDate() error = %v, want ErrDirNotExist", err)
 	}
 }
 
@@ -189,8 +273,8 @@ func TestFindNoteByDateInvalidNoteType(t *testing.T) {
 	tmpDir := t.TempDir()
 	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
 	_, err := FindNoteByDate(date, NoteType("invalid"), tmpDir, 30)
-	if err == nil {
-		t.Error("FindNoteByDate() should fail for invalid note type")
+	if !errors.Is(err, ErrInvalidNoteType) {
+		t.Errorf("FindNoteByDate() error = %v, want ErrInvalidNoteType", err)
 	}
 }
 
@@ -198,8 +282,8 @@ func TestFindNoteByDateInvalidSearchWindow(t *testing.T) {
 	tmpDir := t.TempDir()
 	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
 	_, err := FindNoteByDate(date, NoteTypeJournal, tmpDir, 0)
-	if err == nil {
-		t.Error("FindNoteByDate() should fail for zero search window")
+	if !errors.Is(err, ErrInvalidSearchWindow) {
+		t.Errorf("FindNoteByDate() error = %v, want ErrInvalidSearchWindow", err)
 	}
 }
 
@@ -256,6 +340,10 @@ func TestFindNextNote(t *testing.T) {
 				t.Errorf("FindNextNote() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr && !errors.Is(err, ErrNoteNotFound) {
+				t.Errorf("FindNextNote() error = %v, want ErrNoteNotFound", err)
+				return
+			}
 
 			if !tt.wantErr {
 				expectedPath := filepath.Join(tmpDir, tt.wantDate+".md")
@@ -273,14 +361,328 @@ func TestFindNextNoteInvalidInputs(t *testing.T) {
 
 	// Invalid note type
 	_, err := FindNextNote(date, NoteType("invalid"), tmpDir, 30)
-	if err == nil {
-		t.Error("FindNextNote() should fail for invalid note type")
+	if !errors.Is(err, ErrInvalidNoteType) {
+		t.Errorf("FindNextNote() error = %v, want ErrInvalidNoteType", err)
 	}
 
 	// Invalid search window
 	_, err = FindNextNote(date, NoteTypeJournal, tmpDir, -1)
-	if err == nil {
-		t.Error("FindNextNote() should fail for negative search window")
+	if !errors.Is(err, ErrInvalidSearchWindow) {
+		t.Errorf("FindNextNote() error = %v, want ErrInvalidSearchWindow", err)
+	}
+}
+
+func TestFindPreviousNote(t *testing.T) {
+	// Create temp directory with test files
+	tmpDir := t.TempDir()
+
+	// Create files with gaps
+	testDates := []string{"2025-01-06", "2025-01-07", "2025-01-10", "2025-01-13"}
+	for _, dateStr := range testDates {
+		filename := filepath.Join(tmpDir, dateStr+".md")
+		if err := os.WriteFile(filename, []byte("test"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		startDate string
+		wantDate  string
+		wantErr   bool
+	}{
+		{
+			name:      "previous day exists",
+			startDate: "2025-01-07",
+			wantDate:  "2025-01-06",
+			wantErr:   false,
+		},
+		{
+			name:      "skip gap to find previous",
+			startDate: "2025-01-10",
+			wantDate:  "2025-01-07",
+			wantErr:   false,
+		},
+		{
+			name:      "skip weekend gap",
+			startDate: "2025-01-13",
+			wantDate:  "2025-01-10",
+			wantErr:   false,
+		},
+		{
+			name:      "start date without note",
+			startDate: "2025-01-12",
+			wantDate:  "2025-01-10",
+			wantErr:   false,
+		},
+		{
+			name:      "no previous note",
+			startDate: "2025-01-06",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, _ := time.Parse(DateFormat, tt.startDate)
+			path, err := FindPreviousNote(date, NoteTypeJournal, tmpDir, 30)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindPreviousNote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrNoteNotFound) {
+				t.Errorf("FindPreviousNote() error = %v, want ErrNoteNotFound", err)
+				return
+			}
+
+			if !tt.wantErr {
+				expectedPath := filepath.Join(tmpDir, tt.wantDate+".md")
+				if path != expectedPath {
+					t.Errorf("FindPreviousNote() = %v, want %v", path, expectedPath)
+				}
+			}
+		})
+	}
+}
+
+func TestFindPreviousNoteSearchWindow(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "2025-01-06.md"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	// 2025-01-06 is 4 days before, so a 3-day window must not reach it
+	if _, err := FindPreviousNote(date, NoteTypeJournal, tmpDir, 3); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("FindPreviousNote() error = %v, want ErrNoteNotFound", err)
+	}
+
+	if _, err := FindPreviousNote(date, NoteTypeJournal, tmpDir, 4); err != nil {
+		t.Errorf("FindPreviousNote() failed at window edge: %v", err)
+	}
+}
+
+func TestFindPreviousNoteInvalidInputs(t *testing.T) {
+	tmpDir := t.TempDir()
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	// Invalid note type
+	_, err := FindPreviousNote(date, NoteType("invalid"), tmpDir, 30)
+	if !errors.Is(err, ErrInvalidNoteType) {
+		t.Errorf("FindPreviousNote() error = %v, want ErrInvalidNoteType", err)
+	}
+
+	// Invalid search window
+	_, err = FindPreviousNote(date, NoteTypeJournal, tmpDir, -1)
+	if !errors.Is(err, ErrInvalidSearchWindow) {
+		t.Errorf("FindPreviousNote() error = %v, want ErrInvalidSearchWindow", err)
+	}
+}
+
+func TestFindNearestNote(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testDates := []string{"2025-01-06", "2025-01-10", "2025-01-12", "2025-01-20"}
+	for _, dateStr := range testDates {
+		filename := filepath.Join(tmpDir, dateStr+".md")
+		if err := os.WriteFile(filename, []byte("test"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		searchDate string
+		window     int
+		wantDate   string
+		wantErr    bool
+	}{
+		{
+			name:       "exact date exists",
+			searchDate: "2025-01-10",
+			window:     30,
+			wantDate:   "2025-01-10",
+		},
+		{
+			name:       "tie prefers backward",
+			searchDate: "2025-01-11",
+			window:     30,
+			wantDate:   "2025-01-10",
+		},
+		{
+			name:       "equidistant gap prefers backward",
+			searchDate: "2025-01-08",
+			window:     30,
+			wantDate:   "2025-01-06",
+		},
+		{
+			name:       "closer forward wins",
+			searchDate: "2025-01-09",
+			window:     30,
+			wantDate:   "2025-01-10",
+		},
+		{
+			name:       "closer backward wins",
+			searchDate: "2025-01-15",
+			window:     30,
+			wantDate:   "2025-01-12",
+		},
+		{
+			name:       "forward only",
+			searchDate: "2025-01-04",
+			window:     30,
+			wantDate:   "2025-01-06",
+		},
+		{
+			name:       "outside window",
+			searchDate: "2025-01-16",
+			window:     3,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, _ := time.Parse(DateFormat, tt.searchDate)
+			path, err := FindNearestNote(date, NoteTypeJournal, tmpDir, tt.window)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindNearestNote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrNoteNotFound) {
+				t.Errorf("FindNearestNote() error = %v, want ErrNoteNotFound", err)
+				return
+			}
+
+			if !tt.wantErr {
+				expectedPath := filepath.Join(tmpDir, tt.wantDate+".md")
+				if path != expectedPath {
+					t.Errorf("FindNearestNote() = %v, want %v", path, expectedPath)
+				}
+			}
+		})
+	}
+}
+
+func TestFindNearestNoteInvalidInputs(t *testing.T) {
+	tmpDir := t.TempDir()
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	// Invalid note type
+	_, err := FindNearestNote(date, NoteType("invalid"), tmpDir, 30)
+	if !errors.Is(err, ErrInvalidNoteType) {
+		t.Errorf("FindNearestNote() error = %v, want ErrInvalidNoteType", err)
+	}
+
+	// Invalid search window
+	_, err = FindNearestNote(date, NoteTypeJournal, tmpDir, 0)
+	if !errors.Is(err, ErrInvalidSearchWindow) {
+		t.Errorf("FindNearestNote() error = %v, want ErrInvalidSearchWindow", err)
+	}
+}
+
+func TestFindersWithPathLayout(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testFiles := []string{
+		"2024/12/2024-12-31.md",
+		"2025/01/2025-01-06.md",
+		"2025/01/2025-01-10.md",
+		"2025/02/2025-02-03.md",
+		// Misplaced notes are ignored
+		"2025-01-08.md",
+		"2025/02/2025-01-09.md",
+	}
+	for _, name := range testFiles {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	opt := WithPathLayout("{year}/{month}")
+	want := func(name string) string {
+		return filepath.Join(tmpDir, filepath.FromSlash(name))
+	}
+
+	tests := []struct {
+		name string
+		find func() (string, error)
+		want string
+	}{
+		{
+			name: "exact date",
+			find: func() (string, error) {
+				return FindNoteByDate(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), NoteTypeJournal, tmpDir, 30, opt)
+			},
+			want: "2025/01/2025-01-06.md",
+		},
+		{
+			name: "fallback skips misplaced notes",
+			find: func() (string, error) {
+				return FindNoteByDate(time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC), NoteTypeJournal, tmpDir, 30, opt)
+			},
+			want: "2025/01/2025-01-06.md",
+		},
+		{
+			name: "previous across year",
+			find: func() (string, error) {
+				return FindPreviousNote(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), NoteTypeJournal, tmpDir, 30, opt)
+			},
+			want: "2024/12/2024-12-31.md",
+		},
+		{
+			name: "next across month",
+			find: func() (string, error) {
+				return FindNextNote(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), NoteTypeJournal, tmpDir, 30, opt)
+			},
+			want: "2025/02/2025-02-03.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := tt.find()
+			if err != nil {
+				t.Fatalf("find failed: %v", err)
+			}
+			if path != want(tt.want) {
+				t.Errorf("found %v, want %v", path, want(tt.want))
+			}
+		})
+	}
+
+	// Missing directory is not found rather than an error from walking
+	if _, err := FindNextNote(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), NoteTypeJournal, filepath.Join(tmpDir, "missing"), 30, opt); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("FindNextNote() error = %v, want ErrNoteNotFound", err)
+	}
+}
+
+func TestNotePath(t *testing.T) {
+	date := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"flat", nil, "/notes/2025-01-06.md"},
+		{"year and month", []Option{WithPathLayout("{year}/{month}")}, "/notes/2025/01/2025-01-06.md"},
+		{"layout and format", []Option{WithPathLayout("{year}"), WithFilenameFormat("20060102")}, "/notes/2025/20250106.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NotePath("/notes", date, tt.opts...); got != filepath.FromSlash(tt.want) {
+				t.Errorf("NotePath() = %v, want %v", got, tt.want)
+			}
+		})
 	}
 }
 
@@ -368,3 +770,393 @@ func TestFindNextNoteWithRealTestData(t *testing.T) {
 
 	t.Logf("Successfully found next note: %s", path)
 }
+
+func TestFindNoteByDateSkipEmptyNotes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Real notes on 01-06 and 01-08, an empty placeholder on 01-07
+	files := map[string]string{
+		"2025-01-06": "# Daily Log\n\n* Did work\n",
+		"2025-01-07": "",
+		"2025-01-08": "# Daily Log\n\n* Did more work\n",
+	}
+	for dateStr, content := range files {
+		filename := filepath.Join(tmpDir, dateStr+".md")
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	date := time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC)
+
+	// Default behaviour returns the placeholder
+	path, err := FindNoteByDate(date, NoteTypeJournal, tmpDir, 30)
+	if err != nil {
+		t.Fatalf("FindNoteByDate() failed: %v", err)
+	}
+	if filepath.Base(path) != "2025-01-07.md" {
+		t.Errorf("FindNoteByDate() = %s, want 2025-01-07.md", filepath.Base(path))
+	}
+
+	// With skipping enabled, falls back past the placeholder
+	path, err = FindNoteByDate(date, NoteTypeJournal, tmpDir, 30, WithSkipEmptyNotes(true))
+	if err != nil {
+		t.Fatalf("FindNoteByDate() failed: %v", err)
+	}
+	if filepath.Base(path) != "2025-01-06.md" {
+		t.Errorf("FindNoteByDate() = %s, want 2025-01-06.md", filepath.Base(path))
+	}
+
+	// FindNextNote skips the placeholder going forward
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	path, err = FindNextNote(start, NoteTypeJournal, tmpDir, 30, WithSkipEmptyNotes(true))
+	if err != nil {
+		t.Fatalf("FindNextNote() failed: %v", err)
+	}
+	if filepath.Base(path) != "2025-01-08.md" {
+		t.Errorf("FindNextNote() = %s, want 2025-01-08.md", filepath.Base(path))
+	}
+}
+
+func TestFindNoteByDateSkipDrafts(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A draft on 01-07 between finished notes, and one using another field
+	files := map[string]string{
+		"2025-01-06": "# Daily Log\n\n* Did work\n",
+		"2025-01-07": "---\ndraft: true\n---\n# Daily Log\n\n* Half written\n",
+		"2025-01-08": "---\ndraft: false\nwip: true\n---\n# Daily Log\n\n* Did more work\n",
+	}
+	for dateStr, content := range files {
+		filename := filepath.Join(tmpDir, dateStr+".md")
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		date  time.Time
+		field string
+		want  string
+	}{
+		{"drafts included by default", time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), "", "2025-01-07.md"},
+		{"draft falls back to the day before", time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), "draft", "2025-01-06.md"},
+		{"draft false is not a draft", time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC), "draft", "2025-01-08.md"},
+		{"custom field", time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC), "wip", "2025-01-07.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := FindNoteByDate(tt.date, NoteTypeJournal, tmpDir, 30, WithSkipDrafts(tt.field))
+			if err != nil {
+				t.Fatalf("FindNoteByDate() failed: %v", err)
+			}
+			if filepath.Base(path) != tt.want {
+				t.Errorf("FindNoteByDate() = %s, want %s", filepath.Base(path), tt.want)
+			}
+		})
+	}
+
+	// Drafts are left out of ranges
+	paths, err := FindNotesInRange(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		NoteTypeJournal, tmpDir, WithSkipDrafts("draft"))
+	if err != nil {
+		t.Fatalf("FindNotesInRange() failed: %v", err)
+	}
+	if len(paths) != 2 || filepath.Base(paths[0]) != "2025-01-06.md" || filepath.Base(paths[1]) != "2025-01-08.md" {
+		t.Errorf("FindNotesInRange() = %v, want 2025-01-06.md and 2025-01-08.md", paths)
+	}
+}
+
+func TestIsEmptyNote(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"zero bytes", "", true},
+		{"whitespace only", "\n\n  \n", true},
+		{"frontmatter only", "---\ntitle: placeholder\n---\n", true},
+		{"frontmatter and body", "---\ntitle: note\n---\n\n# Daily Log\n", false},
+		{"body only", "# Daily Log\n", false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, GenerateFilename(time.Date(2025, 1, i+1, 0, 0, 0, 0, time.UTC)))
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+			if got := isEmptyNote(path); got != tt.want {
+				t.Errorf("isEmptyNote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindNotesInRange(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	testDates := []string{"2024-12-31", "2025-01-06", "2025-01-07", "2025-01-31", "2025-02-01"}
+	for _, dateStr := range testDates {
+		filename := filepath.Join(tmpDir, dateStr+".md")
+		if err := os.WriteFile(filename, []byte("test"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	paths, err := FindNotesInRange(start, end, NoteTypeJournal, tmpDir)
+	if err != nil {
+		t.Fatalf("FindNotesInRange() failed: %v", err)
+	}
+
+	expected := []string{"2025-01-06.md", "2025-01-07.md", "2025-01-31.md"}
+	if len(paths) != len(expected) {
+		t.Fatalf("FindNotesInRange() = %v, want %v", paths, expected)
+	}
+	for i, want := range expected {
+		if filepath.Base(paths[i]) != want {
+			t.Errorf("FindNotesInRange()[%d] = %s, want %s", i, filepath.Base(paths[i]), want)
+		}
+	}
+
+	// End before start is an error
+	if _, err := FindNotesInRange(end, start, NoteTypeJournal, tmpDir); err == nil {
+		t.Error("FindNotesInRange() should fail when end is before start")
+	}
+}
+
+func TestFindNoteByDateMulti(t *testing.T) {
+	workDir := t.TempDir()
+	personalDir := t.TempDir()
+
+	notesByDir := map[string][]string{
+		workDir:     {"2025-01-06", "2025-01-08"},
+		personalDir: {"2025-01-07", "2025-01-08", "2025-01-10"},
+	}
+	for dir, dates := range notesByDir {
+		for _, dateStr := range dates {
+			if err := os.WriteFile(filepath.Join(dir, dateStr+".md"), []byte("test"), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+		}
+	}
+
+	missingDir := filepath.Join(t.TempDir(), "missing")
+	dirs := []string{workDir, personalDir}
+
+	tests := []struct {
+		name     string
+		date     string
+		dirs     []string
+		wantDir  string
+		wantDate string
+		wantErr  bool
+	}{
+		{"only in first directory", "2025-01-06", dirs, workDir, "2025-01-06", false},
+		{"only in second directory", "2025-01-07", dirs, personalDir, "2025-01-07", false},
+		{"in both directories, first wins", "2025-01-08", dirs, workDir, "2025-01-08", false},
+		{"most recent fallback wins", "2025-01-09", dirs, workDir, "2025-01-08", false},
+		{"later note in second directory", "2025-01-11", dirs, personalDir, "2025-01-10", false},
+		{"missing directory is skipped", "2025-01-07", []string{missingDir, personalDir}, personalDir, "2025-01-07", false},
+		{"nothing found", "2025-01-01", dirs, "", "", true},
+		{"no directories", "2025-01-06", nil, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, _ := time.Parse(DateFormat, tt.date)
+			path, err := FindNoteByDateMulti(date, NoteTypeJournal, tt.dirs, 30)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindNoteByDateMulti() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if want := filepath.Join(tt.wantDir, tt.wantDate+".md"); path != want {
+				t.Errorf("FindNoteByDateMulti() = %s, want %s", path, want)
+			}
+		})
+	}
+	if _, err := FindNoteByDateMulti(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), NoteTypeJournal, dirs, 30); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("FindNoteByDateMulti() error = %v, want ErrNoteNotFound", err)
+	}
+}
+
+func TestFindPreviousNextNoteMulti(t *testing.T) {
+	workDir := t.TempDir()
+	personalDir := t.TempDir()
+
+	for dir, dates := range map[string][]string{
+		workDir:     {"2025-01-06", "2025-01-10"},
+		personalDir: {"2025-01-07", "2025-01-09", "2025-01-10"},
+	} {
+		for _, dateStr := range dates {
+			if err := os.WriteFile(filepath.Join(dir, dateStr+".md"), []byte("test"), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+		}
+	}
+
+	dirs := []string{workDir, personalDir}
+	missingDir := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name     string
+		find     func(time.Time, NoteType, []string, int, ...Option) (string, error)
+		date     string
+		dirs     []string
+		wantPath string
+		wantErr  bool
+	}{
+		{"previous in second directory", FindPreviousNoteMulti, "2025-01-08", dirs, filepath.Join(personalDir, "2025-01-07.md"), false},
+		{"previous in first directory", FindPreviousNoteMulti, "2025-01-07", dirs, filepath.Join(workDir, "2025-01-06.md"), false},
+		{"previous in both directories, first wins", FindPreviousNoteMulti, "2025-01-11", dirs, filepath.Join(workDir, "2025-01-10.md"), false},
+		{"previous skips missing directory", FindPreviousNoteMulti, "2025-01-08", []string{missingDir, personalDir}, filepath.Join(personalDir, "2025-01-07.md"), false},
+		{"previous not found", FindPreviousNoteMulti, "2025-01-06", dirs, "", true},
+		{"next in second directory", FindNextNoteMulti, "2025-01-06", dirs, filepath.Join(personalDir, "2025-01-07.md"), false},
+		{"next in both directories, first wins", FindNextNoteMulti, "2025-01-09", dirs, filepath.Join(workDir, "2025-01-10.md"), false},
+		{"next not found", FindNextNoteMulti, "2025-01-10", dirs, "", true},
+		{"no directories", FindNextNoteMulti, "2025-01-06", nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, _ := time.Parse(DateFormat, tt.date)
+			path, err := tt.find(date, NoteTypeJournal, tt.dirs, 30)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if path != tt.wantPath {
+				t.Errorf("got %s, want %s", path, tt.wantPath)
+			}
+		})
+	}
+	if _, err := FindPreviousNoteMulti(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), NoteTypeJournal, dirs, 30); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("FindPreviousNoteMulti() error = %v, want ErrNoteNotFound", err)
+	}
+}
+
+func TestFindNotesInRangeMulti(t *testing.T) {
+	workDir := t.TempDir()
+	personalDir := t.TempDir()
+
+	for dir, dates := range map[string][]string{
+		workDir:     {"2025-01-06", "2025-01-08"},
+		personalDir: {"2025-01-07", "2025-01-08"},
+	} {
+		for _, dateStr := range dates {
+			if err := os.WriteFile(filepath.Join(dir, dateStr+".md"), []byte("test"), 0644); err != nil {
+				t.Fatalf("failed to create test file: %v", err)
+			}
+		}
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	missingDir := filepath.Join(t.TempDir(), "missing")
+	paths, err := FindNotesInRangeMulti(start, end, NoteTypeJournal, []string{workDir, missingDir, personalDir})
+	if err != nil {
+		t.Fatalf("FindNotesInRangeMulti() failed: %v", err)
+	}
+
+	expected := []string{
+		filepath.Join(workDir, "2025-01-06.md"),
+		filepath.Join(personalDir, "2025-01-07.md"),
+		filepath.Join(workDir, "2025-01-08.md"),
+		filepath.Join(personalDir, "2025-01-08.md"),
+	}
+	if strings.Join(paths, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("FindNotesInRangeMulti() = %v, want %v", paths, expected)
+	}
+
+	if _, err := FindNotesInRangeMulti(start, end, NoteTypeJournal, []string{missingDir}); !errors.Is(err, ErrDirNotExist) {
+		t.Errorf("FindNotesInRangeMulti() error = %v, want ErrDirNotExist", err)
+	}
+}
+
+func TestWithIndexCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "2025-01-06.md"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	cache := NewIndexCache()
+	date := time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)
+	path, err := FindNoteByDate(date, NoteTypeJournal, tmpDir, 30, WithIndexCache(cache))
+	if err != nil || filepath.Base(path) != "2025-01-06.md" {
+		t.Fatalf("FindNoteByDate() = %s, %v, want 2025-01-06.md", path, err)
+	}
+
+	// A note added later isn't in the cached listing
+	if err := os.WriteFile(filepath.Join(tmpDir, "2025-01-07.md"), []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+	if path, _ := FindNoteByDate(date, NoteTypeJournal, tmpDir, 30, WithIndexCache(cache)); filepath.Base(path) != "2025-01-06.md" {
+		t.Errorf("FindNoteByDate() with cache = %s, want 2025-01-06.md", path)
+	}
+	if path, _ := FindNoteByDate(date, NoteTypeJournal, tmpDir, 30); filepath.Base(path) != "2025-01-07.md" {
+		t.Errorf("FindNoteByDate() without cache = %s, want 2025-01-07.md", path)
+	}
+
+	// A different filename format is listed separately
+	compact := WithFilenameFormat("20060102")
+	if _, err := FindNoteByDate(date, NoteTypeJournal, tmpDir, 30, WithIndexCache(cache), compact); !errors.Is(err, ErrNoteNotFound) {
+		t.Errorf("FindNoteByDate() with compact format error = %v, want ErrNoteNotFound", err)
+	}
+}
+
+// statPerDayFind is the previous FindNoteByDate strategy, kept as a benchmark
+// baseline: one os.Stat per day in the search window
+func statPerDayFind(date time.Time, dir string, searchWindowDays int) (string, int) {
+	stats := 0
+	for i := 0; i <= searchWindowDays; i++ {
+		path := filepath.Join(dir, date.AddDate(0, 0, -i).Format(DateFormat)+".md")
+		stats++
+		if fileExists(path) {
+			return path, stats
+		}
+	}
+	return "", stats
+}
+
+// BenchmarkFindNoteByDate compares a single directory listing against an
+// os.Stat per day when the nearest note is far outside the recent past
+func BenchmarkFindNoteByDate(b *testing.B) {
+	tmpDir := b.TempDir()
+
+	// A year of notes, followed by a long gap
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 365; i++ {
+		name := filepath.Join(tmpDir, GenerateFilename(start.AddDate(0, 0, i)))
+		if err := os.WriteFile(name, []byte("test"), 0644); err != nil {
+			b.Fatalf("failed to create test file: %v", err)
+		}
+	}
+	searchDate := start.AddDate(0, 0, 365+300)
+
+	b.Run("directory_listing", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := FindNoteByDate(searchDate, NoteTypeJournal, tmpDir, 365); err != nil {
+				b.Fatalf("FindNoteByDate() failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("stat_per_day", func(b *testing.B) {
+		var stats int
+		for i := 0; i < b.N; i++ {
+			path, n := statPerDayFind(searchDate, tmpDir, 365)
+			if path == "" {
+				b.Fatal("statPerDayFind() found nothing")
+			}
+			stats = n
+		}
+		b.ReportMetric(float64(stats), "stats/op")
+	})
+}
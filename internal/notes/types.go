@@ -1,5 +1,7 @@
 package notes
 
+import "strings"
+
 // NoteType represents the type of note (journal, standup, etc.)
 type NoteType string
 
@@ -25,3 +27,20 @@ func (nt NoteType) IsValid() bool {
 		return false
 	}
 }
+
+// TypeFromPath returns the note type named by the innermost journal/ or
+// standup/ directory (case-insensitive) in path, or "" if there is none.
+// Both / and \ separate directories.
+func TypeFromPath(path string) NoteType {
+	dirs := strings.Split(strings.ReplaceAll(path, "\\", "/"), "/")
+	// The last element is the filename
+	for i := len(dirs) - 2; i >= 0; i-- {
+		switch NoteType(strings.ToLower(dirs[i])) {
+		case NoteTypeJournal:
+			return NoteTypeJournal
+		case NoteTypeStandup:
+			return NoteTypeStandup
+		}
+	}
+	return ""
+}
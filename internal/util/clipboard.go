@@ -0,0 +1,38 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// ErrNoClipboard is returned by CopyToClipboard when no clipboard tool is installed
+var ErrNoClipboard = errors.New("no clipboard tool found (install pbcopy, xclip or clip)")
+
+// clipboardCommands are the clipboard tools tried by CopyToClipboard, in order
+var clipboardCommands = []ExecConfig{
+	{Command: "pbcopy"},
+	{Command: "xclip", Args: []string{"-selection", "clipboard"}},
+	{Command: "clip"},
+}
+
+// CopyToClipboard writes text to the system clipboard using the first
+// available of pbcopy (macOS), xclip (Linux/X11) or clip (Windows)
+func CopyToClipboard(text string) error {
+	for _, tool := range clipboardCommands {
+		if _, err := exec.LookPath(tool.Command); err != nil {
+			continue
+		}
+
+		tool.Stdin = text
+		tool.Timeout = 5 * time.Second
+		result := ExecuteCommand(tool)
+		if result.Error != nil {
+			return fmt.Errorf("%s failed: %w (stderr: %s)", tool.Command, result.Error, result.Stderr)
+		}
+		return nil
+	}
+
+	return ErrNoClipboard
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	oldCommands := clipboardCommands
+	defer func() { clipboardCommands = oldCommands }()
+
+	t.Run("uses first available tool", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "clipboard")
+		clipboardCommands = []ExecConfig{
+			{Command: "this-command-does-not-exist-12345"},
+			{Command: "sh", Args: []string{"-c", "cat > " + out}},
+		}
+
+		if err := CopyToClipboard("hello"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("failed to read clipboard output: %v", err)
+		}
+		if string(got) != "hello" {
+			t.Errorf("expected clipboard to contain 'hello', got '%s'", got)
+		}
+	})
+
+	t.Run("no tool available", func(t *testing.T) {
+		clipboardCommands = []ExecConfig{{Command: "this-command-does-not-exist-12345"}}
+
+		if err := CopyToClipboard("hello"); !errors.Is(err, ErrNoClipboard) {
+			t.Errorf("expected ErrNoClipboard, got %v", err)
+		}
+	})
+}
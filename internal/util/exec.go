@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,9 @@ type ExecConfig struct {
 	Command string
 	Args    []string
 	Timeout time.Duration
+
+	// Stdin is written to the command's standard input, if not empty
+	Stdin string
 }
 
 // DefaultTimeout is the default timeout for command execution (30 seconds)
@@ -43,6 +47,12 @@ func ExecuteCommand(cfg ExecConfig) CommandResult {
 
 	// Create command
 	cmd := exec.CommandContext(ctx, cfg.Command, cfg.Args...)
+	// Don't wait on children (e.g. of sh -c) still holding the output pipes
+	// once the command is killed
+	cmd.WaitDelay = time.Second
+	if cfg.Stdin != "" {
+		cmd.Stdin = strings.NewReader(cfg.Stdin)
+	}
 
 	// Capture stdout and stderr
 	stdout, err := cmd.Output()
@@ -74,6 +74,18 @@ func TestExecuteCommand_Timeout(t *testing.T) {
 	}
 }
 
+func TestExecuteShellCommand_TimeoutWithChild(t *testing.T) {
+	start := time.Now()
+	result := ExecuteShellCommand("sleep 10; echo done", 100*time.Millisecond)
+
+	if result.Error == nil {
+		t.Fatal("expected timeout error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected command to stop at its timeout, took %v", elapsed)
+	}
+}
+
 func TestExecuteCommand_DefaultTimeout(t *testing.T) {
 	// Quick command should complete with default timeout
 	result := ExecuteCommand(ExecConfig{
@@ -154,3 +166,18 @@ func TestExecuteShellCommand_Failure(t *testing.T) {
 		t.Errorf("expected exit code 99, got %d", result.ExitCode)
 	}
 }
+
+func TestExecuteCommand_Stdin(t *testing.T) {
+	result := ExecuteCommand(ExecConfig{
+		Command: "cat",
+		Stdin:   "hello from stdin\n",
+	})
+
+	if result.Error != nil {
+		t.Fatalf("expected no error, got %v", result.Error)
+	}
+
+	if result.Stdout != "hello from stdin\n" {
+		t.Errorf("expected stdin to be echoed, got '%s'", result.Stdout)
+	}
+}
@@ -0,0 +1,70 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// BackupSuffix is appended to a file's path to name its backup
+const BackupSuffix = ".bak"
+
+// BackupOnRewrite makes RewriteFile save a file's original content to
+// <file>.bak before replacing it. Set by the global --backup flag.
+var BackupOnRewrite bool
+
+// RewriteFile replaces the content of the file at path with data, first
+// backing up the original if BackupOnRewrite is set. The file keeps its
+// permissions, which the backup shares. data is expected to use "\n" line
+// endings; they're converted to "\r\n" if that's what the original file
+// mostly used.
+func RewriteFile(path string, data []byte) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if BackupOnRewrite && original != nil {
+		if err := writeFileMode(path+BackupSuffix, original, perm); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+
+	if Newline(original) == "\r\n" {
+		data = bytes.ReplaceAll(NormalizeNewlines(data), []byte("\n"), []byte("\r\n"))
+	}
+	return writeFileMode(path, data, perm)
+}
+
+// writeFileMode writes data to the file at path and sets its permissions to
+// perm, including when the file already exists
+func writeFileMode(path string, data []byte, perm os.FileMode) error {
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	return os.Chmod(path, perm)
+}
+
+// Newline returns the line ending most lines of content end with: "\r\n" or,
+// if there's a tie or no line endings, "\n"
+func Newline(content []byte) string {
+	lines := bytes.Count(content, []byte("\n"))
+	crlf := bytes.Count(content, []byte("\r\n"))
+	if crlf > lines-crlf {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// NormalizeNewlines returns content with "\r\n" line endings converted to "\n"
+func NormalizeNewlines(content []byte) []byte {
+	if !bytes.Contains(content, []byte("\r\n")) {
+		return content
+	}
+	return bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+}
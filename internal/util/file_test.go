@@ -0,0 +1,183 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRewriteFile(t *testing.T) {
+	oldBackup := BackupOnRewrite
+	defer func() { BackupOnRewrite = oldBackup }()
+
+	tests := []struct {
+		name       string
+		backup     bool
+		wantBackup bool
+	}{
+		{name: "backup enabled", backup: true, wantBackup: true},
+		{name: "backup disabled", backup: false, wantBackup: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			BackupOnRewrite = tt.backup
+			path := filepath.Join(t.TempDir(), "2025-01-06.md")
+			if err := os.WriteFile(path, []byte("original\n"), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			if err := RewriteFile(path, []byte("updated\n")); err != nil {
+				t.Fatalf("RewriteFile() error = %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+			if string(got) != "updated\n" {
+				t.Errorf("file content = %q, want %q", got, "updated\n")
+			}
+
+			backup, err := os.ReadFile(path + BackupSuffix)
+			if !tt.wantBackup {
+				if !os.IsNotExist(err) {
+					t.Errorf("expected no backup, got err = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read backup: %v", err)
+			}
+			if string(backup) != "original\n" {
+				t.Errorf("backup content = %q, want %q", backup, "original\n")
+			}
+		})
+	}
+}
+
+func TestRewriteFileKeepsMode(t *testing.T) {
+	oldBackup := BackupOnRewrite
+	BackupOnRewrite = true
+	defer func() { BackupOnRewrite = oldBackup }()
+
+	tests := []struct {
+		name string
+		perm os.FileMode
+	}{
+		{name: "private", perm: 0600},
+		{name: "executable", perm: 0755},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "note.md")
+			if err := os.WriteFile(path, []byte("original\n"), tt.perm); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			if err := os.Chmod(path, tt.perm); err != nil {
+				t.Fatalf("failed to chmod file: %v", err)
+			}
+
+			if err := RewriteFile(path, []byte("updated\n")); err != nil {
+				t.Fatalf("RewriteFile() error = %v", err)
+			}
+
+			for _, p := range []string{path, path + BackupSuffix} {
+				info, err := os.Stat(p)
+				if err != nil {
+					t.Fatalf("failed to stat %s: %v", p, err)
+				}
+				if info.Mode().Perm() != tt.perm {
+					t.Errorf("%s mode = %v, want %v", filepath.Base(p), info.Mode().Perm(), tt.perm)
+				}
+			}
+		})
+	}
+}
+
+func TestRewriteFileNewFile(t *testing.T) {
+	oldBackup := BackupOnRewrite
+	BackupOnRewrite = true
+	defer func() { BackupOnRewrite = oldBackup }()
+
+	path := filepath.Join(t.TempDir(), "new.md")
+	if err := RewriteFile(path, []byte("new\n")); err != nil {
+		t.Fatalf("RewriteFile() error = %v", err)
+	}
+	if _, err := os.Stat(path + BackupSuffix); !os.IsNotExist(err) {
+		t.Errorf("expected no backup for a new file, got err = %v", err)
+	}
+}
+
+func TestRewriteFileLineEndings(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		data     string
+		want     string
+	}{
+		{
+			name:     "keeps CRLF",
+			original: "# Log\r\n\r\n[Yesterday](2025-01-05)\r\n",
+			data:     "# Log\n\n[Yesterday](2025-01-06)\n",
+			want:     "# Log\r\n\r\n[Yesterday](2025-01-06)\r\n",
+		},
+		{
+			name:     "CRLF with mixed data",
+			original: "a\r\nb\r\n",
+			data:     "a\r\nb\nc\n",
+			want:     "a\r\nb\r\nc\r\n",
+		},
+		{
+			name:     "keeps LF",
+			original: "a\nb\n",
+			data:     "a\nc\n",
+			want:     "a\nc\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "note.md")
+			if err := os.WriteFile(path, []byte(tt.original), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			if err := RewriteFile(path, []byte(tt.data)); err != nil {
+				t.Fatalf("RewriteFile() error = %v", err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("file content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewline(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "empty", content: "", want: "\n"},
+		{name: "no newline", content: "text", want: "\n"},
+		{name: "LF", content: "a\nb\n", want: "\n"},
+		{name: "CRLF", content: "a\r\nb\r\n", want: "\r\n"},
+		{name: "mostly CRLF", content: "a\r\nb\r\nc\n", want: "\r\n"},
+		{name: "mostly LF", content: "a\r\nb\nc\n", want: "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Newline([]byte(tt.content)); got != tt.want {
+				t.Errorf("Newline(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}